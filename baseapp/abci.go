@@ -880,7 +880,8 @@ func (app *BaseApp) FinalizeBlock(req *abci.FinalizeBlockRequest) (res *abci.Fin
 	}()
 
 	if app.optimisticExec.Initialized() {
-		// check if the hash we got is the same as the one we are executing
+		// check if the hash we got is the same as the one we are executing, or
+		// as one of the previous proposals of the height executed before
 		aborted := app.optimisticExec.AbortIfNeeded(req.Hash)
 		// Wait for the OE to finish, regardless of whether it was aborted or not
 		res, err = app.optimisticExec.WaitResult()
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"math/rand"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"testing"
@@ -2411,6 +2412,84 @@ func TestOptimisticExecution(t *testing.T) {
 	require.Equal(t, int64(50), suite.baseApp.LastBlockHeight())
 }
 
+func TestOptimisticExecution_ReuseAcrossRounds(t *testing.T) {
+	newSuite := func(opts ...func(*baseapp.BaseApp)) *BaseAppSuite {
+		suite := NewBaseAppSuite(t, opts...)
+		baseapptestutil.RegisterKeyValueServer(suite.baseApp.MsgServiceRouter(), MsgKeyValueImpl{})
+		_, err := suite.baseApp.InitChain(&abci.InitChainRequest{
+			ConsensusParams: &cmtproto.ConsensusParams{},
+		})
+		require.NoError(t, err)
+		return suite
+	}
+	// the end blocker signals the end of the executions, as a proposal
+	// executing when the next one arrives is aborted
+	executed := make(chan struct{}, 1)
+	suite := newSuite(baseapp.SetOptimisticExecution(), func(app *baseapp.BaseApp) {
+		app.SetEndBlocker(func(sdk.Context) (sdk.EndBlock, error) {
+			select {
+			case executed <- struct{}{}:
+			default:
+			}
+			return sdk.EndBlock{}, nil
+		})
+	})
+	refSuite := newSuite()
+
+	newTx := func(value string) []byte {
+		builder := suite.txConfig.NewTxBuilder()
+		_, _, addr := testdata.KeyTestPubAddr()
+		require.NoError(t, builder.SetMsgs(&baseapptestutil.MsgKeyValue{Key: []byte("key"), Value: []byte(value), Signer: addr.String()}))
+		setTxSignature(t, builder, 0)
+		txBytes, err := suite.txConfig.TxEncoder()(builder.GetTx())
+		require.NoError(t, err)
+		return txBytes
+	}
+
+	// the optimistic execution starts after the initial height
+	for _, s := range []*BaseAppSuite{suite, refSuite} {
+		_, err := s.baseApp.FinalizeBlock(&abci.FinalizeBlockRequest{Height: 1})
+		require.NoError(t, err)
+		_, err = s.baseApp.Commit()
+		require.NoError(t, err)
+	}
+	<-executed
+
+	// each round proposes another block, the proposal of the first round is
+	// proposed again in the last one, and either is decided
+	for height := int64(2); height <= 5; height++ {
+		var proposals []abci.ProcessProposalRequest
+		for round := 0; round < 3; round++ {
+			proposal := abci.ProcessProposalRequest{
+				Txs:    [][]byte{newTx(fmt.Sprintf("value%d-%d", height, round))},
+				Height: height,
+				Hash:   []byte(fmt.Sprintf("hash%d-%d", height, round)),
+			}
+			resp, err := suite.baseApp.ProcessProposal(&proposal)
+			require.NoError(t, err)
+			require.Equal(t, abci.PROCESS_PROPOSAL_STATUS_ACCEPT, resp.Status)
+			<-executed
+			proposals = append(proposals, proposal)
+		}
+		resp, err := suite.baseApp.ProcessProposal(&proposals[0])
+		require.NoError(t, err)
+		require.Equal(t, abci.PROCESS_PROPOSAL_STATUS_ACCEPT, resp.Status)
+
+		decided := proposals[height%2]
+		req := &abci.FinalizeBlockRequest{Height: height, Txs: decided.Txs, Hash: decided.Hash}
+		res, err := suite.baseApp.FinalizeBlock(req)
+		require.NoError(t, err)
+		refRes, err := refSuite.baseApp.FinalizeBlock(req)
+		require.NoError(t, err)
+		require.Equal(t, refRes.AppHash, res.AppHash)
+
+		_, err = suite.baseApp.Commit()
+		require.NoError(t, err)
+		_, err = refSuite.baseApp.Commit()
+		require.NoError(t, err)
+	}
+}
+
 func TestABCI_Proposal_FailReCheckTx(t *testing.T) {
 	pool := mempool.NewPriorityMempool[int64](mempool.PriorityNonceMempoolConfig[int64]{
 		TxPriority:      mempool.NewDefaultTxPriority(),
@@ -2511,3 +2590,54 @@ func TestABCI_Proposal_FailReCheckTx(t *testing.T) {
 	require.NotEmpty(t, res.TxResults[0].Events)
 	require.True(t, res.TxResults[0].IsOK(), fmt.Sprintf("%v", res))
 }
+
+func TestABCI_RestoreMempool(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mempool.wal")
+	newApp := func(invalidCounter int64) (*BaseAppSuite, *mempool.PersistentMempool) {
+		pool, err := mempool.NewPersistentMempool(
+			mempool.NewSenderNonceMempool(mempool.SenderNonceMaxTxOpt(5000)),
+			mempool.PersistentMempoolConfig{Path: path},
+		)
+		require.NoError(t, err)
+		anteOpt := func(bapp *baseapp.BaseApp) {
+			bapp.SetAnteHandler(func(ctx sdk.Context, tx sdk.Tx, simulate bool) (sdk.Context, error) {
+				if counter, _ := parseTxMemo(t, tx); counter == invalidCounter {
+					return ctx, errorsmod.Wrap(sdkerrors.ErrUnauthorized, "invalid tx")
+				}
+				return ctx, nil
+			})
+		}
+
+		suite := NewBaseAppSuite(t, anteOpt, baseapp.SetMempool(pool))
+		baseapptestutil.RegisterCounterServer(suite.baseApp.MsgServiceRouter(), NoopCounterServerImpl{})
+		_, err = suite.baseApp.InitChain(&abci.InitChainRequest{
+			ConsensusParams: &cmtproto.ConsensusParams{},
+		})
+		require.NoError(t, err)
+		return suite, pool
+	}
+
+	suite, pool := newApp(-1)
+	for i := int64(0); i < 3; i++ {
+		txBytes, err := suite.txConfig.TxEncoder()(newTxCounter(t, suite.txConfig, i, i))
+		require.NoError(t, err)
+		res, err := suite.baseApp.CheckTx(&abci.CheckTxRequest{Tx: txBytes, Type: abci.CHECK_TX_TYPE_CHECK})
+		require.NoError(t, err)
+		require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	}
+	require.Equal(t, 3, pool.CountTx())
+	require.NoError(t, suite.baseApp.Close())
+
+	// on restart, the txs are re-inserted through CheckTx, which drops the ones
+	// which are no longer valid
+	suite, pool = newApp(1)
+	require.Equal(t, 0, pool.CountTx())
+	require.NoError(t, suite.baseApp.RestoreMempool())
+	require.Equal(t, 2, pool.CountTx())
+	require.NoError(t, suite.baseApp.Close())
+
+	suite, pool = newApp(-1)
+	require.NoError(t, suite.baseApp.RestoreMempool())
+	require.Equal(t, 2, pool.CountTx())
+	require.NoError(t, suite.baseApp.Close())
+}
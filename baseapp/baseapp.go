@@ -1163,9 +1163,44 @@ func (app *BaseApp) Close() error {
 		}
 	}
 
+	// Close the write-ahead file of a persistent mempool
+	if mp, ok := app.mempool.(*mempool.PersistentMempool); ok {
+		if err := mp.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
 	return errors.Join(errs...)
 }
 
+// RestoreMempool re-inserts the transactions persisted by a
+// mempool.PersistentMempool through CheckTx, dropping the ones which are no
+// longer valid. It is a no-op for the other mempools, and must be called once
+// the latest version is loaded and before the node starts.
+func (app *BaseApp) RestoreMempool() error {
+	mp, ok := app.mempool.(*mempool.PersistentMempool)
+	if !ok {
+		return nil
+	}
+
+	restored, dropped, err := mp.Restore(func(txBz []byte) error {
+		res, err := app.CheckTx(&abci.CheckTxRequest{Tx: txBz, Type: abci.CHECK_TX_TYPE_CHECK})
+		if err != nil {
+			return err
+		}
+		if res.Code != abci.CodeTypeOK {
+			return errors.New(res.Log)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore the mempool: %w", err)
+	}
+
+	app.logger.Info("restored mempool", "restored", restored, "dropped", dropped)
+	return nil
+}
+
 // GetBaseApp returns the pointer to itself.
 func (app *BaseApp) GetBaseApp() *BaseApp {
 	return app
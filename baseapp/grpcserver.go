@@ -15,6 +15,7 @@ import (
 
 	errorsmod "cosmossdk.io/errors"
 
+	mempoolservice "github.com/cosmos/cosmos-sdk/client/grpc/mempool"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	grpctypes "github.com/cosmos/cosmos-sdk/types/grpc"
@@ -70,33 +71,47 @@ func (app *BaseApp) RegisterGRPCServer(server gogogrpc.Server) {
 		return handler(grpcCtx, req)
 	}
 
-	// Loop through all services and methods, add the interceptor, and register
-	// the service.
+	// Register all the services with the interceptor.
+	srv := interceptedServer{server: server, interceptor: interceptor}
 	for _, data := range app.GRPCQueryRouter().serviceData {
-		desc := data.serviceDesc
-		newMethods := make([]grpc.MethodDesc, len(desc.Methods))
-
-		for i, method := range desc.Methods {
-			methodHandler := method.Handler
-			newMethods[i] = grpc.MethodDesc{
-				MethodName: method.MethodName,
-				Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
-					return methodHandler(srv, ctx, dec, grpcmiddleware.ChainUnaryServer(
-						grpcrecovery.UnaryServerInterceptor(),
-						interceptor,
-					))
-				},
-			}
-		}
+		srv.RegisterService(data.serviceDesc, data.handler)
+	}
+
+	// The mempool service reads the app-side mempool rather than the state.
+	mempoolservice.RegisterMempoolService(srv, app.mempool, app.txEncoder)
+}
 
-		newDesc := &grpc.ServiceDesc{
-			ServiceName: desc.ServiceName,
-			HandlerType: desc.HandlerType,
-			Methods:     newMethods,
-			Streams:     desc.Streams,
-			Metadata:    desc.Metadata,
+// interceptedServer registers services on a gRPC server, adding an interceptor
+// to all their methods.
+type interceptedServer struct {
+	server      gogogrpc.Server
+	interceptor grpc.UnaryServerInterceptor
+}
+
+// RegisterService implements gogogrpc.Server.
+func (s interceptedServer) RegisterService(desc *grpc.ServiceDesc, handler interface{}) {
+	newMethods := make([]grpc.MethodDesc, len(desc.Methods))
+
+	for i, method := range desc.Methods {
+		methodHandler := method.Handler
+		newMethods[i] = grpc.MethodDesc{
+			MethodName: method.MethodName,
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, _ grpc.UnaryServerInterceptor) (interface{}, error) {
+				return methodHandler(srv, ctx, dec, grpcmiddleware.ChainUnaryServer(
+					grpcrecovery.UnaryServerInterceptor(),
+					s.interceptor,
+				))
+			},
 		}
+	}
 
-		server.RegisterService(newDesc, data.handler)
+	newDesc := &grpc.ServiceDesc{
+		ServiceName: desc.ServiceName,
+		HandlerType: desc.HandlerType,
+		Methods:     newMethods,
+		Streams:     desc.Streams,
+		Metadata:    desc.Metadata,
 	}
+
+	s.server.RegisterService(newDesc, handler)
 }
@@ -0,0 +1,226 @@
+package baseapp
+
+import (
+	"errors"
+
+	abci "github.com/cometbft/cometbft/api/cometbft/abci/v1"
+	cmttypes "github.com/cometbft/cometbft/types"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+)
+
+// LaneProposalHandler defines ABCI PrepareProposal and ProcessProposal handlers
+// building blocks from the lanes of a mempool.LaneMempool. The transactions of
+// a block are ordered by lane, and the transactions of each lane use at most
+// the share of the max tx bytes and gas of the block of their lane, so that the
+// traffic of a lane cannot crowd out the traffic of the lanes after it.
+type LaneProposalHandler struct {
+	mempool          *mempool.LaneMempool
+	txVerifier       ProposalTxVerifier
+	signerExtAdapter mempool.SignerExtractionAdapter
+}
+
+// NewLaneProposalHandler returns the proposal handlers of the given lane mempool.
+func NewLaneProposalHandler(mp *mempool.LaneMempool, txVerifier ProposalTxVerifier) *LaneProposalHandler {
+	return &LaneProposalHandler{
+		mempool:          mp,
+		txVerifier:       txVerifier,
+		signerExtAdapter: mempool.NewDefaultSignerExtractionAdapter(),
+	}
+}
+
+// PrepareProposalHandler returns the PrepareProposal handler selecting the
+// transactions of the lanes, lane by lane. Each lane can fill its share of the
+// max tx bytes and gas of the block, capped by the space the previous lanes
+// left. As in the DefaultProposalHandler, the transactions must be valid and
+// in sequence for their signers, and the invalid ones are removed from the
+// mempool.
+//
+// The shares of the lanes are taken of the max tx bytes of a block without
+// evidence, which ProcessProposal can compute as well, and the transactions of
+// all the lanes are capped by the max tx bytes of the request, the evidence of
+// the block taking up the space of the last lanes.
+func (h *LaneProposalHandler) PrepareProposalHandler() sdk.PrepareProposalHandler {
+	return func(ctx sdk.Context, req *abci.PrepareProposalRequest) (*abci.PrepareProposalResponse, error) {
+		maxTxBytes := uint64(req.MaxTxBytes)
+		laneTxBytes, maxBlockGas := laneBlockLimits(ctx, len(req.LocalLastCommit.Votes))
+
+		var (
+			selectedTxs            [][]byte
+			usedBytes, usedGas     uint64
+			selectedTxsSignersSeqs = make(map[string]uint64)
+		)
+		for _, lane := range h.mempool.Lanes() {
+			space := newLaneSpace(lane, laneTxBytes, maxBlockGas, usedBytes, usedGas)
+			space.maxBytes = min(space.maxBytes, laneLimit(maxTxBytes, math.LegacyDec{}, usedBytes))
+
+			for iterator := lane.Mempool.Select(ctx, req.Txs); iterator != nil && !space.full(); iterator = iterator.Next() {
+				memTx := iterator.Tx()
+				signerData, err := h.signerExtAdapter.GetSigners(memTx)
+				if err != nil {
+					return nil, err
+				}
+
+				// the signers seen before in this block must be in sequence
+				shouldAdd := true
+				txSignersSeqs := make(map[string]uint64)
+				for _, signer := range signerData {
+					seq, ok := selectedTxsSignersSeqs[signer.Signer.String()]
+					if ok && seq+1 != signer.Sequence {
+						shouldAdd = false
+						break
+					}
+					txSignersSeqs[signer.Signer.String()] = signer.Sequence
+				}
+				if !shouldAdd {
+					continue
+				}
+
+				txBz, err := h.txVerifier.PrepareProposalVerifyTx(memTx)
+				if err != nil {
+					err := h.mempool.Remove(memTx)
+					if err != nil && !errors.Is(err, mempool.ErrTxNotFound) {
+						return nil, err
+					}
+					continue
+				}
+
+				txSize, txGas := txSpace(memTx, txBz)
+				added := space.fits(txSize, txGas)
+				if added {
+					space.add(txSize, txGas)
+					selectedTxs = append(selectedTxs, txBz)
+				}
+				for sender, seq := range txSignersSeqs {
+					if added {
+						selectedTxsSignersSeqs[sender] = seq
+					} else if _, ok := selectedTxsSignersSeqs[sender]; !ok {
+						// the tx is valid but does not fit, so the next txs of
+						// the sender cannot be added either
+						selectedTxsSignersSeqs[sender] = seq - 1
+					}
+				}
+			}
+
+			usedBytes += space.bytes
+			usedGas += space.gas
+		}
+
+		return &abci.PrepareProposalResponse{Txs: selectedTxs}, nil
+	}
+}
+
+// ProcessProposalHandler returns the ProcessProposal handler verifying that the
+// transactions of a proposal are valid, ordered by lane and within the limits
+// of their lane.
+//
+// Note that ProcessProposal is not given the max tx bytes of the block, so the
+// byte limits of the lanes are checked against the max tx bytes of a block
+// without evidence, the base of the shares of the lanes in PrepareProposal.
+func (h *LaneProposalHandler) ProcessProposalHandler() sdk.ProcessProposalHandler {
+	return func(ctx sdk.Context, req *abci.ProcessProposalRequest) (*abci.ProcessProposalResponse, error) {
+		maxTxBytes, maxBlockGas := laneBlockLimits(ctx, len(req.ProposedLastCommit.Votes))
+
+		reject := &abci.ProcessProposalResponse{Status: abci.PROCESS_PROPOSAL_STATUS_REJECT}
+		lanes := h.mempool.Lanes()
+		laneIdx := 0
+		var usedBytes, usedGas uint64
+		space := newLaneSpace(lanes[laneIdx], maxTxBytes, maxBlockGas, usedBytes, usedGas)
+		for _, txBz := range req.Txs {
+			tx, err := h.txVerifier.ProcessProposalVerifyTx(txBz)
+			if err != nil {
+				return reject, nil
+			}
+
+			idx := h.mempool.LaneIndex(ctx, tx)
+			if idx < laneIdx {
+				// the tx belongs to no lane or to a lane before the current one
+				return reject, nil
+			}
+			for ; laneIdx < idx; laneIdx++ {
+				usedBytes += space.bytes
+				usedGas += space.gas
+				space = newLaneSpace(lanes[laneIdx+1], maxTxBytes, maxBlockGas, usedBytes, usedGas)
+			}
+
+			txSize, txGas := txSpace(tx, txBz)
+			if !space.fits(txSize, txGas) {
+				return reject, nil
+			}
+			space.add(txSize, txGas)
+		}
+
+		return &abci.ProcessProposalResponse{Status: abci.PROCESS_PROPOSAL_STATUS_ACCEPT}, nil
+	}
+}
+
+// laneBlockLimits returns the max tx bytes of a block without evidence, given
+// the max bytes of the consensus params and the number of votes of the last
+// commit, and the max gas of the block.
+func laneBlockLimits(ctx sdk.Context, valsCount int) (maxTxBytes, maxGas uint64) {
+	maxBytes := int64(cmttypes.MaxBlockSizeBytes)
+	if b := ctx.ConsensusParams().Block; b != nil { // nolint:staticcheck // ignore linting error
+		maxGas = uint64(b.MaxGas)
+		if b.MaxBytes > 0 {
+			maxBytes = b.MaxBytes
+		}
+	}
+	return uint64(cmttypes.MaxDataBytesNoEvidence(maxBytes, valsCount)), maxGas
+}
+
+// laneSpace is the space of a block the transactions of a lane can use, and the
+// space they use.
+type laneSpace struct {
+	maxBytes, maxGas uint64
+	bytes, gas       uint64
+	limitGas         bool
+}
+
+// newLaneSpace returns the space of a lane given the limits of the block and the
+// space used by the previous lanes. A max block gas of 0 means no gas limit.
+func newLaneSpace(lane mempool.Lane, maxTxBytes, maxBlockGas, usedBytes, usedGas uint64) *laneSpace {
+	return &laneSpace{
+		maxBytes: laneLimit(maxTxBytes, lane.MaxTxBytesShare, usedBytes),
+		maxGas:   laneLimit(maxBlockGas, lane.MaxGasShare, usedGas),
+		limitGas: maxBlockGas > 0,
+	}
+}
+
+// laneLimit returns the share of a block limit, capped by what the previous
+// lanes left.
+func laneLimit(blockLimit uint64, share math.LegacyDec, used uint64) uint64 {
+	if used >= blockLimit {
+		return 0
+	}
+	limit := blockLimit - used
+	if !share.IsNil() {
+		shareLimit := math.LegacyNewDecFromInt(math.NewIntFromUint64(blockLimit)).Mul(share).TruncateInt().Uint64()
+		limit = min(limit, shareLimit)
+	}
+	return limit
+}
+
+func (s *laneSpace) fits(txSize, txGas uint64) bool {
+	return s.bytes+txSize <= s.maxBytes && (!s.limitGas || s.gas+txGas <= s.maxGas)
+}
+
+func (s *laneSpace) add(txSize, txGas uint64) {
+	s.bytes += txSize
+	s.gas += txGas
+}
+
+func (s *laneSpace) full() bool {
+	return s.bytes >= s.maxBytes || (s.limitGas && s.gas >= s.maxGas)
+}
+
+// txSpace returns the size and the gas of a transaction in a block.
+func txSpace(tx sdk.Tx, txBz []byte) (txSize, txGas uint64) {
+	txSize = uint64(cmttypes.ComputeProtoSizeForTxs([]cmttypes.Tx{txBz}))
+	if gasTx, ok := tx.(GasTx); ok {
+		txGas = gasTx.GetGas()
+	}
+	return txSize, txGas
+}
@@ -0,0 +1,170 @@
+package baseapp_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	abci "github.com/cometbft/cometbft/api/cometbft/abci/v1"
+	cmtproto "github.com/cometbft/cometbft/api/cometbft/types/v1"
+	cmttypes "github.com/cometbft/cometbft/types"
+	"github.com/golang/mock/gomock"
+
+	"cosmossdk.io/math"
+	authtx "cosmossdk.io/x/auth/tx"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	baseapptestutil "github.com/cosmos/cosmos-sdk/baseapp/testutil"
+	"github.com/cosmos/cosmos-sdk/baseapp/testutil/mock"
+	codectestutil "github.com/cosmos/cosmos-sdk/codec/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+)
+
+func (s *ABCIUtilsTestSuite) TestLaneProposalHandler() {
+	cdc := codectestutil.CodecOptions{}.NewCodec()
+	baseapptestutil.RegisterInterfaces(cdc.InterfaceRegistry())
+	signingCtx := cdc.InterfaceRegistry().SigningContext()
+	txConfig := authtx.NewTxConfig(cdc, signingCtx.AddressCodec(), signingCtx.ValidatorAddressCodec(), authtx.DefaultSignModes)
+
+	// the txs whose value starts with "p" belong to the priority lane, which
+	// can use half of the block
+	newLaneMempool := func() *mempool.LaneMempool {
+		mp, err := mempool.NewLaneMempool(
+			mempool.Lane{
+				Name:    "priority",
+				Mempool: mempool.DefaultPriorityMempool(),
+				Match: func(_ context.Context, tx sdk.Tx) bool {
+					return tx.GetMsgs()[0].(*baseapptestutil.MsgKeyValue).Value[0] == 'p'
+				},
+				MaxTxBytesShare: math.LegacyNewDecWithPrec(5, 1),
+			},
+			mempool.Lane{Name: "default", Mempool: mempool.DefaultPriorityMempool()},
+		)
+		s.Require().NoError(err)
+		return mp
+	}
+
+	type testTx struct {
+		tx       sdk.Tx
+		priority int64
+		bz       []byte
+	}
+	testTxs := []testTx{
+		{tx: buildMsg(s.T(), txConfig, []byte(`p0`), [][]byte{[]byte("secret0")}, []uint64{1}), priority: 3},
+		{tx: buildMsg(s.T(), txConfig, []byte(`p1`), [][]byte{[]byte("secret1")}, []uint64{1}), priority: 2},
+		{tx: buildMsg(s.T(), txConfig, []byte(`p2`), [][]byte{[]byte("secret2")}, []uint64{1}), priority: 1},
+		{tx: buildMsg(s.T(), txConfig, []byte(`d3`), [][]byte{[]byte("secret3")}, []uint64{1}), priority: 3},
+		{tx: buildMsg(s.T(), txConfig, []byte(`d4`), [][]byte{[]byte("secret4")}, []uint64{1}), priority: 2},
+		{tx: buildMsg(s.T(), txConfig, []byte(`d5`), [][]byte{[]byte("secret5")}, []uint64{1}), priority: 1},
+	}
+	for i := range testTxs {
+		bz, err := txConfig.TxEncoder()(testTxs[i].tx)
+		s.Require().NoError(err)
+		testTxs[i].bz = bz
+	}
+	txSize := cmttypes.ComputeProtoSizeForTxs([]cmttypes.Tx{testTxs[0].bz})
+	for _, tx := range testTxs {
+		s.Require().Equal(txSize, cmttypes.ComputeProtoSizeForTxs([]cmttypes.Tx{tx.bz}))
+	}
+
+	ctrl := gomock.NewController(s.T())
+	app := mock.NewMockProposalTxVerifier(ctrl)
+	for _, tx := range testTxs {
+		app.EXPECT().PrepareProposalVerifyTx(tx.tx).Return(tx.bz, nil).AnyTimes()
+		app.EXPECT().ProcessProposalVerifyTx(tx.bz).Return(tx.tx, nil).AnyTimes()
+	}
+	app.EXPECT().ProcessProposalVerifyTx(gomock.Any()).Return(nil, errors.New("invalid tx")).AnyTimes()
+
+	txIndexes := func(txs [][]byte) []int {
+		indexes := []int{}
+		for _, bz := range txs {
+			for i, tx := range testTxs {
+				if bytes.Equal(bz, tx.bz) {
+					indexes = append(indexes, i)
+				}
+			}
+		}
+		return indexes
+	}
+
+	// the max bytes of a block whose txs, without evidence, take up to 4 txs
+	maxBlockBytes := 4*txSize + cmttypes.MaxOverheadForBlock + cmttypes.MaxHeaderBytes + cmttypes.MaxCommitBytes(0)
+	ctx := s.ctx.WithConsensusParams(cmtproto.ConsensusParams{Block: &cmtproto.BlockParams{MaxBytes: maxBlockBytes}})
+
+	s.Run("prepare proposal", func() {
+		mp := newLaneMempool()
+		for _, tx := range testTxs {
+			s.Require().NoError(mp.Insert(s.ctx.WithPriority(tx.priority), tx.tx))
+		}
+		ph := baseapp.NewLaneProposalHandler(mp, app)
+
+		// the priority lane fills its half of the block, and the default lane
+		// the rest
+		resp, err := ph.PrepareProposalHandler()(ctx, &abci.PrepareProposalRequest{MaxTxBytes: 4 * txSize})
+		s.Require().NoError(err)
+		s.Require().Equal([]int{0, 1, 3, 4}, txIndexes(resp.Txs))
+
+		// the evidence of the block takes up the space of the last lanes
+		resp, err = ph.PrepareProposalHandler()(ctx, &abci.PrepareProposalRequest{MaxTxBytes: 3 * txSize})
+		s.Require().NoError(err)
+		s.Require().Equal([]int{0, 1, 3}, txIndexes(resp.Txs))
+
+		// the default lane can use the space the priority lane leaves
+		s.Require().NoError(mp.Remove(testTxs[0].tx))
+		s.Require().NoError(mp.Remove(testTxs[1].tx))
+		s.Require().NoError(mp.Remove(testTxs[2].tx))
+		resp, err = ph.PrepareProposalHandler()(ctx, &abci.PrepareProposalRequest{MaxTxBytes: 4 * txSize})
+		s.Require().NoError(err)
+		s.Require().Equal([]int{3, 4, 5}, txIndexes(resp.Txs))
+	})
+
+	// the shares of the lanes are taken of the max tx bytes, not of the max
+	// bytes of the block, which would leave room for a tx more in the priority
+	// lane
+	s.Require().Less(txSize, (maxBlockBytes-4*txSize)/2)
+	testCases := map[string]struct {
+		txs    []int
+		status abci.ProcessProposalStatus
+	}{
+		"lanes in order and within their limits": {
+			txs:    []int{0, 1, 3, 4},
+			status: abci.PROCESS_PROPOSAL_STATUS_ACCEPT,
+		},
+		"empty lane": {
+			txs:    []int{3, 4, 5},
+			status: abci.PROCESS_PROPOSAL_STATUS_ACCEPT,
+		},
+		"lanes out of order": {
+			txs:    []int{3, 0},
+			status: abci.PROCESS_PROPOSAL_STATUS_REJECT,
+		},
+		"lane over its limit by less than the share of the block overhead": {
+			txs:    []int{0, 1, 2},
+			status: abci.PROCESS_PROPOSAL_STATUS_REJECT,
+		},
+		"lane over the space left by the previous lanes": {
+			txs:    []int{0, 1, 3, 4, 5},
+			status: abci.PROCESS_PROPOSAL_STATUS_REJECT,
+		},
+	}
+	for name, tc := range testCases {
+		s.Run(name, func() {
+			ph := baseapp.NewLaneProposalHandler(newLaneMempool(), app)
+			req := &abci.ProcessProposalRequest{}
+			for _, i := range tc.txs {
+				req.Txs = append(req.Txs, testTxs[i].bz)
+			}
+			resp, err := ph.ProcessProposalHandler()(ctx, req)
+			s.Require().NoError(err)
+			s.Require().Equal(tc.status, resp.Status)
+		})
+	}
+
+	s.Run("invalid tx", func() {
+		ph := baseapp.NewLaneProposalHandler(newLaneMempool(), app)
+		resp, err := ph.ProcessProposalHandler()(ctx, &abci.ProcessProposalRequest{Txs: [][]byte{[]byte("invalid")}})
+		s.Require().NoError(err)
+		s.Require().Equal(abci.PROCESS_PROPOSAL_STATUS_REJECT, resp.Status)
+	})
+}
@@ -11,34 +11,78 @@ import (
 	abci "github.com/cometbft/cometbft/api/cometbft/abci/v1"
 
 	"cosmossdk.io/core/log"
+
+	"github.com/cosmos/cosmos-sdk/telemetry"
 )
 
+// DefaultCacheSize is the default number of executions of candidate proposals
+// kept for reuse.
+const DefaultCacheSize = 3
+
 // FinalizeBlockFunc is the function that is called by the OE to finalize the
 // block. It is the same as the one in the ABCI app.
 type FinalizeBlockFunc func(context.Context, *abci.FinalizeBlockRequest) (*abci.FinalizeBlockResponse, error)
 
 // OptimisticExecution is a struct that contains the OE context. It is used to
 // run the FinalizeBlock function in a goroutine, and to abort it if needed.
+//
+// When the state of the executions can be saved and restored, see
+// WithExecutionState, the executions of the last candidate proposals of a
+// height are kept, keyed by their hash. When a proposal is seen again in a later
+// round, or when the decided block is one of them, its execution is reused
+// instead of running the block again.
 type OptimisticExecution struct {
 	finalizeBlockFunc FinalizeBlockFunc // ABCI FinalizeBlock function with a context
 	logger            log.Logger
 
-	mtx         sync.Mutex
-	stopCh      chan struct{}
-	request     *abci.FinalizeBlockRequest
-	response    *abci.FinalizeBlockResponse
-	err         error
-	cancelFunc  func() // cancel function for the context
-	initialized bool   // A boolean value indicating whether the struct has been initialized
+	mtx     sync.Mutex
+	current *execution // the execution of the last proposal, nil if not initialized
+
+	// executions kept for reuse, all of the same height
+	cacheSize    int
+	height       int64
+	executions   map[string]*execution
+	order        []string // hashes of the kept executions, least recently used first
+	saveState    func() any
+	restoreState func(any)
+	hits, misses uint64
 
 	// debugging/testing options
 	abortRate int // number from 0 to 100 that determines the percentage of OE that should be aborted
 }
 
+// execution is the execution of a proposal. Its result is set once stopCh is
+// closed.
+type execution struct {
+	request    *abci.FinalizeBlockRequest
+	state      any
+	cancelFunc func() // cancel function for the context
+	stopCh     chan struct{}
+
+	response *abci.FinalizeBlockResponse
+	err      error
+	aborted  bool // whether the execution was aborted before it finished
+}
+
+// finished returns true if the execution finished without being aborted.
+func (ex *execution) finished() bool {
+	select {
+	case <-ex.stopCh:
+		return !ex.aborted
+	default:
+		return false
+	}
+}
+
 // NewOptimisticExecution initializes the Optimistic Execution context but does not start it.
 func NewOptimisticExecution(logger log.Logger, fn FinalizeBlockFunc, opts ...func(*OptimisticExecution)) *OptimisticExecution {
 	logger = logger.With(log.ModuleKey, "oe")
-	oe := &OptimisticExecution{logger: logger, finalizeBlockFunc: fn}
+	oe := &OptimisticExecution{
+		logger:            logger,
+		finalizeBlockFunc: fn,
+		cacheSize:         DefaultCacheSize,
+		executions:        make(map[string]*execution),
+	}
 	for _, opt := range opts {
 		opt(oe)
 	}
@@ -54,15 +98,32 @@ func WithAbortRate(rate int) func(*OptimisticExecution) {
 	}
 }
 
+// WithCacheSize sets the number of executions of candidate proposals kept for
+// reuse, which bounds the memory held by the OE. A size of 0 disables the reuse.
+func WithCacheSize(size int) func(*OptimisticExecution) {
+	return func(oe *OptimisticExecution) {
+		oe.cacheSize = size
+	}
+}
+
+// WithExecutionState sets the functions saving the state an execution is about
+// to write to, and restoring the state of a kept execution when it is reused.
+// The executions are only kept for reuse when they are set.
+func WithExecutionState(save func() any, restore func(any)) func(*OptimisticExecution) {
+	return func(oe *OptimisticExecution) {
+		oe.saveState = save
+		oe.restoreState = restore
+	}
+}
+
 // Reset resets the OE context. Must be called whenever we want to invalidate
-// the current OE.
+// the current OE. The kept executions are dropped as well.
 func (oe *OptimisticExecution) Reset() {
 	oe.mtx.Lock()
 	defer oe.mtx.Unlock()
-	oe.request = nil
-	oe.response = nil
-	oe.err = nil
-	oe.initialized = false
+	oe.current = nil
+	oe.executions = make(map[string]*execution)
+	oe.order = nil
 }
 
 func (oe *OptimisticExecution) Enabled() bool {
@@ -78,48 +139,72 @@ func (oe *OptimisticExecution) Initialized() bool {
 	oe.mtx.Lock()
 	defer oe.mtx.Unlock()
 
-	return oe.initialized
+	return oe.current != nil
 }
 
-// Execute initializes the OE and starts it in a goroutine.
+// Execute initializes the OE and starts it in a goroutine. If the proposal was
+// already executed in a previous round of the height, its execution is reused
+// instead.
 func (oe *OptimisticExecution) Execute(req *abci.ProcessProposalRequest) {
 	oe.mtx.Lock()
 	defer oe.mtx.Unlock()
 
-	oe.stopCh = make(chan struct{})
-	oe.request = &abci.FinalizeBlockRequest{
-		Txs:                req.Txs,
-		DecidedLastCommit:  req.ProposedLastCommit,
-		Misbehavior:        req.Misbehavior,
-		Hash:               req.Hash,
-		Height:             req.Height,
-		Time:               req.Time,
-		NextValidatorsHash: req.NextValidatorsHash,
-		ProposerAddress:    req.ProposerAddress,
+	if oe.reuseEnabled() {
+		// the kept executions of another height are stale
+		if oe.height != req.Height {
+			oe.executions = make(map[string]*execution)
+			oe.order = nil
+			oe.height = req.Height
+		}
+
+		if ex := oe.lookup(req.Hash); ex != nil {
+			oe.logger.Debug("OE reused", "height", req.Height, "hash", hex.EncodeToString(req.Hash))
+			oe.current = ex
+			oe.restoreState(ex.state)
+			return
+		}
 	}
 
-	oe.logger.Debug("OE started", "height", req.Height, "hash", hex.EncodeToString(req.Hash), "time", req.Time.String())
 	ctx, cancel := context.WithCancel(context.Background())
-	oe.cancelFunc = cancel
-	oe.initialized = true
+	ex := &execution{
+		request: &abci.FinalizeBlockRequest{
+			Txs:                req.Txs,
+			DecidedLastCommit:  req.ProposedLastCommit,
+			Misbehavior:        req.Misbehavior,
+			Hash:               req.Hash,
+			Height:             req.Height,
+			Time:               req.Time,
+			NextValidatorsHash: req.NextValidatorsHash,
+			ProposerAddress:    req.ProposerAddress,
+		},
+		cancelFunc: cancel,
+		stopCh:     make(chan struct{}),
+	}
+	if oe.reuseEnabled() {
+		ex.state = oe.saveState()
+		oe.keep(ex)
+	}
+	oe.current = ex
+
+	oe.logger.Debug("OE started", "height", req.Height, "hash", hex.EncodeToString(req.Hash), "time", req.Time.String())
 
 	go func() {
 		start := time.Now()
-		resp, err := oe.finalizeBlockFunc(ctx, oe.request)
-
-		oe.mtx.Lock()
+		resp, err := oe.finalizeBlockFunc(ctx, ex.request)
 
 		executionTime := time.Since(start)
-		oe.logger.Debug("OE finished", "duration", executionTime.String(), "height", oe.request.Height, "hash", hex.EncodeToString(oe.request.Hash))
-		oe.response, oe.err = resp, err
+		oe.logger.Debug("OE finished", "duration", executionTime.String(), "height", ex.request.Height, "hash", hex.EncodeToString(ex.request.Hash))
+		ex.response, ex.err = resp, err
+		ex.aborted = err != nil && ctx.Err() != nil
 
-		close(oe.stopCh)
-		oe.mtx.Unlock()
+		close(ex.stopCh)
 	}()
 }
 
 // AbortIfNeeded aborts the OE if the request hash is not the same as the one in
-// the running OE. Returns true if the OE was aborted.
+// the running OE. If the request is one of the proposals executed in a previous
+// round of the height, its execution becomes the current one instead. Returns
+// true if the OE was aborted.
 func (oe *OptimisticExecution) AbortIfNeeded(reqHash []byte) bool {
 	if oe == nil {
 		return false
@@ -128,33 +213,125 @@ func (oe *OptimisticExecution) AbortIfNeeded(reqHash []byte) bool {
 	oe.mtx.Lock()
 	defer oe.mtx.Unlock()
 
-	if !bytes.Equal(oe.request.Hash, reqHash) {
-		oe.logger.Error("OE aborted due to hash mismatch", "oe_hash", hex.EncodeToString(oe.request.Hash), "req_hash", hex.EncodeToString(reqHash), "oe_height", oe.request.Height, "req_height", oe.request.Height)
-		oe.cancelFunc()
+	current := oe.current
+	if !bytes.Equal(current.request.Hash, reqHash) {
+		current.cancelFunc()
+		if oe.reuseEnabled() {
+			if ex := oe.lookup(reqHash); ex != nil {
+				// the aborted execution must stop writing to the state before
+				// the reused one is restored
+				<-current.stopCh
+				oe.logger.Debug("OE reused", "height", ex.request.Height, "hash", hex.EncodeToString(reqHash))
+				oe.current = ex
+				oe.restoreState(ex.state)
+				return false
+			}
+		}
+
+		oe.logger.Error("OE aborted due to hash mismatch", "oe_hash", hex.EncodeToString(current.request.Hash), "req_hash", hex.EncodeToString(reqHash), "oe_height", current.request.Height, "req_height", current.request.Height)
 		return true
 	} else if oe.abortRate > 0 && rand.Intn(100) < oe.abortRate {
 		// this is for test purposes only, we can emulate a certain percentage of
 		// OE needed to be aborted.
-		oe.cancelFunc()
+		current.cancelFunc()
 		oe.logger.Error("OE aborted due to test abort rate")
 		return true
 	}
 
+	if oe.reuseEnabled() {
+		// the execution may have been aborted by a later proposal, and the
+		// state of the later proposal may have replaced its state
+		<-current.stopCh
+		if current.aborted {
+			return true
+		}
+		oe.restoreState(current.state)
+	}
+
 	return false
 }
 
 // Abort aborts the OE unconditionally and waits for it to finish.
 func (oe *OptimisticExecution) Abort() {
-	if oe == nil || oe.cancelFunc == nil {
+	if oe == nil {
+		return
+	}
+
+	oe.mtx.Lock()
+	current := oe.current
+	oe.mtx.Unlock()
+	if current == nil {
 		return
 	}
 
-	oe.cancelFunc()
-	<-oe.stopCh
+	current.cancelFunc()
+	<-current.stopCh
 }
 
 // WaitResult waits for the OE to finish and returns the result.
 func (oe *OptimisticExecution) WaitResult() (*abci.FinalizeBlockResponse, error) {
-	<-oe.stopCh
-	return oe.response, oe.err
+	oe.mtx.Lock()
+	current := oe.current
+	oe.mtx.Unlock()
+
+	<-current.stopCh
+	return current.response, current.err
+}
+
+// CacheStats returns the number of proposals whose execution was reused, and
+// the number of proposals which had to be executed.
+func (oe *OptimisticExecution) CacheStats() (hits, misses uint64) {
+	oe.mtx.Lock()
+	defer oe.mtx.Unlock()
+	return oe.hits, oe.misses
+}
+
+func (oe *OptimisticExecution) reuseEnabled() bool {
+	return oe.cacheSize > 0 && oe.saveState != nil && oe.restoreState != nil
+}
+
+// lookup returns the kept execution of a proposal if it finished, and records
+// the hit or miss. The caller must hold the lock.
+func (oe *OptimisticExecution) lookup(hash []byte) *execution {
+	key := string(hash)
+	ex, ok := oe.executions[key]
+	if ok && ex.finished() {
+		oe.hits++
+		telemetry.IncrCounter(1, "oe", "cache", "hit")
+		oe.touch(key)
+	} else {
+		ex = nil
+		oe.misses++
+		telemetry.IncrCounter(1, "oe", "cache", "miss")
+	}
+	telemetry.SetGauge(float32(oe.hits)/float32(oe.hits+oe.misses), "oe", "cache", "hit_rate")
+	return ex
+}
+
+// keep keeps an execution for reuse, dropping the least recently used one when
+// the cache is full. The caller must hold the lock.
+func (oe *OptimisticExecution) keep(ex *execution) {
+	key := string(ex.request.Hash)
+	if _, ok := oe.executions[key]; ok {
+		oe.touch(key)
+	} else {
+		oe.order = append(oe.order, key)
+	}
+	oe.executions[key] = ex
+
+	for len(oe.order) > oe.cacheSize {
+		delete(oe.executions, oe.order[0])
+		oe.order = oe.order[1:]
+	}
+}
+
+// touch marks a kept execution as the most recently used one. The caller must
+// hold the lock.
+func (oe *OptimisticExecution) touch(key string) {
+	for i, k := range oe.order {
+		if k == key {
+			oe.order = append(append(oe.order[:i:i], oe.order[i+1:]...), key)
+			return
+		}
+	}
 }
@@ -7,6 +7,7 @@ import (
 
 	abci "github.com/cometbft/cometbft/api/cometbft/abci/v1"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"cosmossdk.io/core/log"
 )
@@ -32,3 +33,74 @@ func TestOptimisticExecution(t *testing.T) {
 
 	oe.Reset()
 }
+
+func TestOptimisticExecution_Reuse(t *testing.T) {
+	// each execution writes the hash of its block to its own state
+	type testState struct{ hash string }
+	var state *testState
+	executed := 0
+	finalizeBlock := func(_ context.Context, req *abci.FinalizeBlockRequest) (*abci.FinalizeBlockResponse, error) {
+		executed++
+		state.hash = string(req.Hash)
+		return &abci.FinalizeBlockResponse{AppHash: req.Hash}, nil
+	}
+
+	oe := NewOptimisticExecution(log.NewNopLogger(), finalizeBlock, WithCacheSize(2), WithExecutionState(
+		func() any { return state },
+		func(s any) { state = s.(*testState) },
+	))
+
+	// processProposal resets the state and executes the proposal, as BaseApp does
+	processProposal := func(height int64, hash string) {
+		oe.Abort()
+		state = &testState{}
+		oe.Execute(&abci.ProcessProposalRequest{Height: height, Hash: []byte(hash)})
+		_, err := oe.WaitResult()
+		require.NoError(t, err)
+	}
+	decide := func(hash string) bool {
+		if oe.AbortIfNeeded([]byte(hash)) {
+			return false
+		}
+		resp, err := oe.WaitResult()
+		require.NoError(t, err)
+		require.Equal(t, hash, string(resp.AppHash))
+		require.Equal(t, hash, state.hash)
+		return true
+	}
+
+	// a proposal of a previous round is decided
+	processProposal(1, "a")
+	processProposal(1, "b")
+	require.True(t, decide("a"))
+	require.Equal(t, 2, executed)
+
+	// a proposal is proposed again in a later round
+	processProposal(2, "c")
+	processProposal(2, "d")
+	processProposal(2, "c")
+	require.Equal(t, 4, executed)
+	require.True(t, decide("c"))
+
+	// the least recently used execution is dropped
+	processProposal(2, "e")
+	require.Equal(t, 5, executed)
+	require.False(t, decide("d"))
+
+	// the executions of a previous height are dropped
+	processProposal(3, "f")
+	require.False(t, decide("e"))
+
+	hits, misses := oe.CacheStats()
+	require.Equal(t, uint64(2), hits)
+	require.Equal(t, uint64(8), misses)
+
+	// the executions are not kept without a cache
+	oe = NewOptimisticExecution(log.NewNopLogger(), finalizeBlock, WithCacheSize(0), WithExecutionState(
+		func() any { return state },
+		func(s any) { state = s.(*testState) },
+	))
+	processProposal(1, "a")
+	processProposal(1, "b")
+	require.False(t, decide("a"))
+}
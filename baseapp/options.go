@@ -112,9 +112,16 @@ func SetStoreLoader(loader StoreLoader) func(*BaseApp) {
 	return func(app *BaseApp) { app.SetStoreLoader(loader) }
 }
 
-// SetOptimisticExecution enables optimistic execution.
+// SetOptimisticExecution enables optimistic execution. The executions of the
+// candidate proposals of a height are kept in their own FinalizeBlock state, so
+// that they can be reused across rounds.
 func SetOptimisticExecution(opts ...func(*oe.OptimisticExecution)) func(*BaseApp) {
 	return func(app *BaseApp) {
+		executionState := oe.WithExecutionState(
+			func() any { return app.finalizeBlockState },
+			func(s any) { app.finalizeBlockState = s.(*state) },
+		)
+		opts = append([]func(*oe.OptimisticExecution){executionState}, opts...)
 		app.optimisticExec = oe.NewOptimisticExecution(app.logger, app.internalFinalizeBlock, opts...)
 	}
 }
@@ -0,0 +1,186 @@
+package mempool
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+
+	gogogrpc "github.com/cosmos/gogoproto/grpc"
+	"github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+	"github.com/cosmos/cosmos-sdk/types/query"
+)
+
+// RegisterMempoolService registers the mempool gRPC service on the provided gRPC
+// router.
+func RegisterMempoolService(server gogogrpc.Server, mp mempool.Mempool, txEncoder sdk.TxEncoder) {
+	RegisterServiceServer(server, NewQueryServer(mp, txEncoder))
+}
+
+// RegisterGRPCGatewayRoutes mounts the mempool gRPC service's GRPC-gateway routes
+// on the given mux object.
+func RegisterGRPCGatewayRoutes(clientConn gogogrpc.ClientConn, mux *runtime.ServeMux) {
+	_ = RegisterServiceHandlerClient(context.Background(), mux, NewServiceClient(clientConn))
+}
+
+var _ ServiceServer = queryServer{}
+
+// priorityIterator is implemented by the mempool iterators exposing the int64
+// priority of their transactions, such as the PriorityNonceMempool ones.
+type priorityIterator interface {
+	Priority() int64
+}
+
+type queryServer struct {
+	mempool         mempool.Mempool
+	txEncoder       sdk.TxEncoder
+	signerExtractor mempool.SignerExtractionAdapter
+}
+
+// NewQueryServer returns the mempool gRPC service of the given mempool. The
+// transactions are read from a snapshot the mempool copies while holding its
+// lock, so the queries neither race with nor change a mempool receiving
+// transactions. The queries fail if the mempool is not a mempool.Snapshotter.
+func NewQueryServer(mp mempool.Mempool, txEncoder sdk.TxEncoder) ServiceServer {
+	return queryServer{
+		mempool:         mp,
+		txEncoder:       txEncoder,
+		signerExtractor: mempool.NewDefaultSignerExtractionAdapter(),
+	}
+}
+
+// PendingTxs implements ServiceServer. The pagination key is the position of
+// the next transaction in the selection order.
+func (s queryServer) PendingTxs(ctx context.Context, req *PendingTxsRequest) (*PendingTxsResponse, error) {
+	if req == nil {
+		return nil, status.Error(codes.InvalidArgument, "empty request")
+	}
+
+	pageReq := req.Pagination
+	if pageReq == nil {
+		pageReq = &query.PageRequest{}
+	}
+	if pageReq.Offset > 0 && len(pageReq.Key) > 0 {
+		return nil, status.Error(codes.InvalidArgument, "either offset or key is expected, got both")
+	}
+	if pageReq.Reverse {
+		return nil, status.Error(codes.InvalidArgument, "reverse pagination is not supported")
+	}
+	offset, limit, countTotal := pageReq.Offset, pageReq.Limit, pageReq.CountTotal
+	if len(pageReq.Key) > 0 {
+		if len(pageReq.Key) != 8 {
+			return nil, status.Error(codes.InvalidArgument, "invalid pagination key")
+		}
+		offset = binary.BigEndian.Uint64(pageReq.Key)
+	}
+	if limit == 0 {
+		limit = query.DefaultLimit
+		countTotal = true
+	}
+
+	res := &PendingTxsResponse{Pagination: &query.PageResponse{}}
+	var count uint64
+	err := s.iterate(ctx, func(it mempool.Iterator, signer mempool.SignerData) (bool, error) {
+		if req.Sender != "" && signer.Signer.String() != req.Sender {
+			return true, nil
+		}
+
+		count++
+		switch {
+		case count <= offset:
+		case count <= offset+limit:
+			tx, err := s.pendingTx(it, signer)
+			if err != nil {
+				return false, err
+			}
+			res.Txs = append(res.Txs, tx)
+		case count == offset+limit+1:
+			res.Pagination.NextKey = binary.BigEndian.AppendUint64(nil, count-1)
+			return countTotal, nil
+		}
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if countTotal {
+		res.Pagination.Total = count
+	}
+	return res, nil
+}
+
+// Stats implements ServiceServer.
+func (s queryServer) Stats(ctx context.Context, _ *StatsRequest) (*StatsResponse, error) {
+	res := &StatsResponse{}
+	senders := make(map[string]bool)
+	err := s.iterate(ctx, func(it mempool.Iterator, signer mempool.SignerData) (bool, error) {
+		txBz, err := s.txEncoder(it.Tx())
+		if err != nil {
+			return false, status.Errorf(codes.Internal, "failed to encode tx: %v", err)
+		}
+		res.TxCount++
+		res.TotalBytes += uint64(len(txBz))
+		senders[signer.Signer.String()] = true
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res.SenderCount = uint64(len(senders))
+	return res, nil
+}
+
+// iterate calls the given function with the transactions of a snapshot of the
+// mempool and their first signer, in the selection order, until it returns
+// false or an error.
+func (s queryServer) iterate(ctx context.Context, fn func(mempool.Iterator, mempool.SignerData) (bool, error)) error {
+	snapshotter, ok := s.mempool.(mempool.Snapshotter)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "mempool of type %T cannot be snapshotted", s.mempool)
+	}
+	it, err := snapshotter.Snapshot(ctx)
+	if err != nil {
+		return status.Errorf(codes.Internal, "failed to snapshot the mempool: %v", err)
+	}
+
+	for ; it != nil; it = it.Next() {
+		signers, err := s.signerExtractor.GetSigners(it.Tx())
+		if err != nil {
+			return status.Errorf(codes.Internal, "failed to get the signers of a tx: %v", err)
+		}
+		if len(signers) == 0 {
+			continue
+		}
+
+		cont, err := fn(it, signers[0])
+		if err != nil || !cont {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s queryServer) pendingTx(it mempool.Iterator, signer mempool.SignerData) (*PendingTx, error) {
+	txBz, err := s.txEncoder(it.Tx())
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to encode tx: %v", err)
+	}
+	hash := sha256.Sum256(txBz)
+
+	tx := &PendingTx{
+		Hash:   hash[:],
+		Sender: signer.Signer.String(),
+		Nonce:  signer.Sequence,
+		Size_:  uint64(len(txBz)),
+	}
+	if it, ok := it.(priorityIterator); ok {
+		tx.Priority = it.Priority()
+	}
+	return tx, nil
+}
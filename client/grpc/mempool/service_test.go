@@ -0,0 +1,171 @@
+package mempool
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/core/log"
+	"cosmossdk.io/math"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	cryptotypes "github.com/cosmos/cosmos-sdk/crypto/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+	"github.com/cosmos/cosmos-sdk/types/query"
+	txsigning "github.com/cosmos/cosmos-sdk/types/tx/signing"
+)
+
+// testTx is a tx signed by a single signer, whose encoding is size bytes long.
+type testTx struct {
+	sdk.Tx
+	id     int
+	signer secp256k1.PubKey
+	nonce  uint64
+	size   int
+}
+
+func (tx testTx) GetSigners() ([][]byte, error) { return [][]byte{tx.signer.Address()}, nil }
+
+func (tx testTx) GetPubKeys() ([]cryptotypes.PubKey, error) { return []cryptotypes.PubKey{&tx.signer}, nil }
+
+func (tx testTx) GetSignaturesV2() ([]txsigning.SignatureV2, error) {
+	return []txsigning.SignatureV2{{PubKey: &tx.signer, Sequence: tx.nonce}}, nil
+}
+
+func (tx testTx) Bytes() []byte {
+	bz, _ := testTxEncoder(tx)
+	return bz
+}
+
+func testTxEncoder(tx sdk.Tx) ([]byte, error) {
+	bz := make([]byte, tx.(testTx).size)
+	bz[0] = byte(tx.(testTx).id)
+	return bz, nil
+}
+
+func TestServiceServer(t *testing.T) {
+	ctx := sdk.NewContext(nil, false, log.NewNopLogger())
+	sa := *secp256k1.GenPrivKey().PubKey().(*secp256k1.PubKey)
+	sb := *secp256k1.GenPrivKey().PubKey().(*secp256k1.PubKey)
+	txs := []testTx{
+		{id: 0, signer: sa, nonce: 1, size: 10},
+		{id: 1, signer: sa, nonce: 2, size: 20},
+		{id: 2, signer: sb, nonce: 1, size: 30},
+		{id: 3, signer: sb, nonce: 2, size: 40},
+	}
+	priorities := []int64{5, 5, 3, 3}
+
+	mp := mempool.DefaultPriorityMempool()
+	for i, tx := range txs {
+		require.NoError(t, mp.Insert(ctx.WithPriority(priorities[i]), tx))
+	}
+	svr := NewQueryServer(mp, testTxEncoder)
+
+	pendingTxIDs := func(res *PendingTxsResponse) []int {
+		ids := []int{}
+		for _, tx := range res.Txs {
+			ids = append(ids, int(tx.Size_/10)-1)
+		}
+		return ids
+	}
+
+	// the txs are listed in the selection order
+	res, err := svr.PendingTxs(ctx, &PendingTxsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2, 3}, pendingTxIDs(res))
+	require.Equal(t, uint64(4), res.Pagination.Total)
+	require.Nil(t, res.Pagination.NextKey)
+	require.Equal(t, sdk.AccAddress(sa.Address()).String(), res.Txs[0].Sender)
+	require.Equal(t, uint64(2), res.Txs[1].Nonce)
+	require.Equal(t, int64(3), res.Txs[2].Priority)
+	require.Len(t, res.Txs[0].Hash, 32)
+
+	// filtered by sender
+	res, err = svr.PendingTxs(ctx, &PendingTxsRequest{Sender: sdk.AccAddress(sb.Address()).String()})
+	require.NoError(t, err)
+	require.Equal(t, []int{2, 3}, pendingTxIDs(res))
+
+	// paginated by key
+	res, err = svr.PendingTxs(ctx, &PendingTxsRequest{Pagination: &query.PageRequest{Limit: 3}})
+	require.NoError(t, err)
+	require.Equal(t, []int{0, 1, 2}, pendingTxIDs(res))
+	require.Zero(t, res.Pagination.Total)
+	res, err = svr.PendingTxs(ctx, &PendingTxsRequest{Pagination: &query.PageRequest{Key: res.Pagination.NextKey, Limit: 3}})
+	require.NoError(t, err)
+	require.Equal(t, []int{3}, pendingTxIDs(res))
+	require.Nil(t, res.Pagination.NextKey)
+
+	// paginated by offset
+	res, err = svr.PendingTxs(ctx, &PendingTxsRequest{Pagination: &query.PageRequest{Offset: 1, Limit: 2, CountTotal: true}})
+	require.NoError(t, err)
+	require.Equal(t, []int{1, 2}, pendingTxIDs(res))
+	require.Equal(t, uint64(4), res.Pagination.Total)
+	require.NotNil(t, res.Pagination.NextKey)
+
+	_, err = svr.PendingTxs(ctx, &PendingTxsRequest{Pagination: &query.PageRequest{Offset: 1, Key: res.Pagination.NextKey}})
+	require.ErrorContains(t, err, "either offset or key")
+	_, err = svr.PendingTxs(ctx, &PendingTxsRequest{Pagination: &query.PageRequest{Reverse: true}})
+	require.ErrorContains(t, err, "reverse pagination")
+
+	stats, err := svr.Stats(ctx, &StatsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, &StatsResponse{TxCount: 4, TotalBytes: 100, SenderCount: 2}, stats)
+}
+
+func TestServiceServerSnapshot(t *testing.T) {
+	ctx := sdk.NewContext(nil, false, log.NewNopLogger()).WithBlockHeight(1)
+	sa := *secp256k1.GenPrivKey().PubKey().(*secp256k1.PubKey)
+	mp := mempool.NewFeeMarketMempool(mempool.FeeMarketMempoolConfig{
+		TxFeeRate: func(context.Context, sdk.Tx) (math.LegacyDec, error) { return math.LegacyOneDec(), nil },
+		TxTTL:     1,
+	})
+	svr := NewQueryServer(mp, testTxEncoder)
+
+	// the queries read the mempool while txs are inserted and removed, which
+	// the race detector checks
+	const n = 200
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < n; i++ {
+			tx := testTx{id: i, signer: sa, nonce: uint64(i), size: 10}
+			if err := mp.Insert(ctx, tx); err != nil {
+				t.Errorf("failed to insert tx %d: %v", i, err)
+				return
+			}
+			if i%2 == 1 {
+				if err := mp.Remove(tx); err != nil {
+					t.Errorf("failed to remove tx %d: %v", i, err)
+					return
+				}
+			}
+		}
+	}()
+	for running := true; running; {
+		select {
+		case <-done:
+			running = false
+		default:
+		}
+		_, err := svr.PendingTxs(ctx, &PendingTxsRequest{})
+		require.NoError(t, err)
+		_, err = svr.Stats(ctx, &StatsRequest{})
+		require.NoError(t, err)
+	}
+	require.Equal(t, n/2, mp.CountTx())
+
+	// the queries do not expire the txs, as selecting them does
+	expiredCtx := ctx.WithBlockHeight(5)
+	stats, err := svr.Stats(expiredCtx, &StatsRequest{})
+	require.NoError(t, err)
+	require.Equal(t, uint64(n/2), stats.TxCount)
+	require.Equal(t, n/2, mp.CountTx())
+	require.Nil(t, mp.Select(expiredCtx, nil))
+	require.Zero(t, mp.CountTx())
+
+	// the mempools which cannot be snapshotted are not queried
+	_, err = NewQueryServer(struct{ mempool.Mempool }{mp}, testTxEncoder).Stats(ctx, &StatsRequest{})
+	require.ErrorContains(t, err, "cannot be snapshotted")
+}
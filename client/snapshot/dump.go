@@ -2,7 +2,10 @@ package snapshot
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -15,7 +18,9 @@ import (
 	"github.com/cosmos/cosmos-sdk/server"
 )
 
-// DumpArchiveCmd returns a command to dump the snapshot as portable archive format
+// DumpArchiveCmd returns a command to dump the snapshot as portable archive format.
+// The archive starts with a manifest holding the chain-id, the app hash at the
+// height of the snapshot and the checksums of its chunks.
 func DumpArchiveCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "dump <height> <format>",
@@ -55,6 +60,33 @@ func DumpArchiveCmd() *cobra.Command {
 				return errors.New("snapshot doesn't exist")
 			}
 
+			cfg := client.GetConfigFromCmd(cmd)
+			chainID, err := server.GetChainID(viper)
+			if err != nil {
+				return err
+			}
+			appHash, err := getAppHash(cfg.RootDir, viper, height)
+			if err != nil {
+				return err
+			}
+
+			chunkSizes := make([]int64, snapshot.Chunks)
+			for i := range chunkSizes {
+				st, err := os.Stat(snapshotStore.PathChunk(height, uint32(format), uint32(i)))
+				if err != nil {
+					return fmt.Errorf("failed to stat chunk file: %w", err)
+				}
+				chunkSizes[i] = st.Size()
+			}
+			manifest, err := NewManifest(chainID, appHash, snapshot, chunkSizes)
+			if err != nil {
+				return err
+			}
+			manifestBz, err := json.MarshalIndent(manifest, "", "  ")
+			if err != nil {
+				return err
+			}
+
 			bz, err := snapshot.Marshal()
 			if err != nil {
 				return err
@@ -72,6 +104,17 @@ func DumpArchiveCmd() *cobra.Command {
 				return err
 			}
 			tarWriter := tar.NewWriter(gzipWriter)
+			if err := tarWriter.WriteHeader(&tar.Header{
+				Name: ManifestFileName,
+				Mode: 0o644,
+				Size: int64(len(manifestBz)),
+			}); err != nil {
+				return fmt.Errorf("failed to write manifest header to tar: %w", err)
+			}
+			if _, err := tarWriter.Write(manifestBz); err != nil {
+				return fmt.Errorf("failed to write manifest to tar: %w", err)
+			}
+
 			if err := tarWriter.WriteHeader(&tar.Header{
 				Name: SnapshotFileName,
 				Mode: 0o644,
@@ -86,7 +129,7 @@ func DumpArchiveCmd() *cobra.Command {
 			for i := uint32(0); i < snapshot.Chunks; i++ {
 				path := snapshotStore.PathChunk(height, uint32(format), i)
 				tarName := strconv.FormatUint(uint64(i), 10)
-				if err := processChunk(tarWriter, path, tarName); err != nil {
+				if err := processChunk(tarWriter, path, tarName, snapshot.Metadata.ChunkHashes[i]); err != nil {
 					return err
 				}
 			}
@@ -108,7 +151,7 @@ func DumpArchiveCmd() *cobra.Command {
 	return cmd
 }
 
-func processChunk(tarWriter *tar.Writer, path, tarName string) error {
+func processChunk(tarWriter *tar.Writer, path, tarName string, checksum []byte) error {
 	file, err := os.Open(path)
 	if err != nil {
 		return fmt.Errorf("failed to open chunk file %s: %w", path, err)
@@ -128,9 +171,13 @@ func processChunk(tarWriter *tar.Writer, path, tarName string) error {
 		return fmt.Errorf("failed to write chunk header to tar: %w", err)
 	}
 
-	if _, err := io.Copy(tarWriter, file); err != nil {
+	hasher := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(tarWriter, hasher), file); err != nil {
 		return fmt.Errorf("failed to write chunk to tar: %w", err)
 	}
+	if !bytes.Equal(hasher.Sum(nil), checksum) {
+		return fmt.Errorf("chunk file %s does not match its checksum %X", path, checksum)
+	}
 
 	return nil
 }
@@ -44,25 +44,10 @@ func LoadArchiveCmd() *cobra.Command {
 				return fmt.Errorf("failed to create gzip reader: %w", err)
 			}
 
-			var snapshot snapshottypes.Snapshot
 			tr := tar.NewReader(reader)
+			manifest, snapshot, err := readArchiveHeader(tr)
 			if err != nil {
-				return fmt.Errorf("failed to create tar reader: %w", err)
-			}
-
-			hdr, err := tr.Next()
-			if err != nil {
-				return fmt.Errorf("failed to read snapshot file header: %w", err)
-			}
-			if hdr.Name != SnapshotFileName {
-				return fmt.Errorf("invalid archive, expect file: snapshot, got: %s", hdr.Name)
-			}
-			bz, err := io.ReadAll(tr)
-			if err != nil {
-				return fmt.Errorf("failed to read snapshot file: %w", err)
-			}
-			if err := snapshot.Unmarshal(bz); err != nil {
-				return fmt.Errorf("failed to unmarshal snapshot: %w", err)
+				return err
 			}
 
 			// make sure the channel is unbuffered, because the tar reader can't do concurrency
@@ -71,7 +56,15 @@ func LoadArchiveCmd() *cobra.Command {
 			go func() {
 				defer close(quitChan)
 
-				savedSnapshot, err := snapshotStore.Save(snapshot.Height, snapshot.Format, chunks)
+				var (
+					savedSnapshot *snapshottypes.Snapshot
+					err           error
+				)
+				if snapshot.Format == snapshottypes.FormatDelta {
+					savedSnapshot, err = snapshotStore.SaveDelta(snapshot.Height, snapshot.Metadata.BaseHeight, chunks)
+				} else {
+					savedSnapshot, err = snapshotStore.Save(snapshot.Height, snapshot.Format, chunks)
+				}
 				if err != nil {
 					cmd.Println("failed to save snapshot", err)
 					return
@@ -80,7 +73,7 @@ func LoadArchiveCmd() *cobra.Command {
 			}()
 
 			for i := uint32(0); i < snapshot.Chunks; i++ {
-				hdr, err = tr.Next()
+				hdr, err := tr.Next()
 				if err != nil {
 					if errors.Is(err, io.EOF) {
 						break
@@ -96,6 +89,11 @@ func LoadArchiveCmd() *cobra.Command {
 				if err != nil {
 					return fmt.Errorf("failed to read chunk file: %w", err)
 				}
+				if manifest != nil {
+					if err := manifest.VerifyChunk(i, bz); err != nil {
+						return err
+					}
+				}
 				chunks <- io.NopCloser(bytes.NewReader(bz))
 			}
 			close(chunks)
@@ -105,7 +103,7 @@ func LoadArchiveCmd() *cobra.Command {
 				return fmt.Errorf("failed to save snapshot")
 			}
 
-			if !reflect.DeepEqual(&snapshot, savedSnapshot) {
+			if !reflect.DeepEqual(snapshot, savedSnapshot) {
 				_ = snapshotStore.Delete(snapshot.Height, snapshot.Format)
 				return fmt.Errorf("invalid archive, the saved snapshot is not equal to the original one")
 			}
@@ -0,0 +1,168 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/metrics"
+	"cosmossdk.io/store/rootmulti"
+	snapshottypes "cosmossdk.io/store/snapshots/types"
+
+	"github.com/cosmos/cosmos-sdk/server"
+	"github.com/cosmos/cosmos-sdk/server/types"
+)
+
+const ManifestFileName = "_manifest"
+
+// Manifest describes the snapshot of a snapshot archive, so that the archive
+// can be verified on its own.
+type Manifest struct {
+	ChainID string `json:"chain_id"`
+	Height  uint64 `json:"height"`
+	Format  uint32 `json:"format"`
+	// AppHash is the app hash of the commit at the height of the snapshot.
+	AppHash cmtbytes.HexBytes `json:"app_hash"`
+	// Hash is the hash of the snapshot, i.e. the SHA-256 hash of its chunks.
+	Hash cmtbytes.HexBytes `json:"hash"`
+	// Chunks are the chunks of the snapshot, in order.
+	Chunks []ChunkManifest `json:"chunks"`
+}
+
+// ChunkManifest describes a chunk of a snapshot archive.
+type ChunkManifest struct {
+	Size     int64             `json:"size"`
+	Checksum cmtbytes.HexBytes `json:"checksum"`
+}
+
+// NewManifest returns the manifest of the snapshot of the given chain, whose
+// chunks have the given sizes.
+func NewManifest(chainID string, appHash []byte, snapshot *snapshottypes.Snapshot, chunkSizes []int64) (*Manifest, error) {
+	if len(chunkSizes) != int(snapshot.Chunks) || len(snapshot.Metadata.ChunkHashes) != int(snapshot.Chunks) {
+		return nil, fmt.Errorf("expected %d chunks, got %d sizes and %d checksums", snapshot.Chunks, len(chunkSizes), len(snapshot.Metadata.ChunkHashes))
+	}
+
+	manifest := &Manifest{
+		ChainID: chainID,
+		Height:  snapshot.Height,
+		Format:  snapshot.Format,
+		AppHash: appHash,
+		Hash:    snapshot.Hash,
+		Chunks:  make([]ChunkManifest, snapshot.Chunks),
+	}
+	for i, size := range chunkSizes {
+		manifest.Chunks[i] = ChunkManifest{Size: size, Checksum: snapshot.Metadata.ChunkHashes[i]}
+	}
+
+	return manifest, nil
+}
+
+// Validate checks that the manifest describes the given snapshot.
+func (m *Manifest) Validate(snapshot *snapshottypes.Snapshot) error {
+	switch {
+	case m.ChainID == "":
+		return errors.New("invalid manifest, empty chain-id")
+	case len(m.AppHash) == 0:
+		return errors.New("invalid manifest, empty app hash")
+	case m.Height != snapshot.Height || m.Format != snapshot.Format:
+		return fmt.Errorf("invalid manifest, expect snapshot at height %d and format %d, got height %d and format %d", m.Height, m.Format, snapshot.Height, snapshot.Format)
+	case !bytes.Equal(m.Hash, snapshot.Hash):
+		return fmt.Errorf("invalid manifest, expect snapshot hash %X, got %X", m.Hash, snapshot.Hash)
+	case len(m.Chunks) != int(snapshot.Chunks) || len(snapshot.Metadata.ChunkHashes) != int(snapshot.Chunks):
+		return fmt.Errorf("invalid manifest, expect %d chunks, got %d", snapshot.Chunks, len(m.Chunks))
+	}
+
+	for i, chunk := range m.Chunks {
+		if !bytes.Equal(chunk.Checksum, snapshot.Metadata.ChunkHashes[i]) {
+			return fmt.Errorf("invalid manifest, expect checksum %X for chunk %d, got %X", chunk.Checksum, i, snapshot.Metadata.ChunkHashes[i])
+		}
+	}
+
+	return nil
+}
+
+// VerifyChunk checks the size and the checksum of the given chunk.
+func (m *Manifest) VerifyChunk(index uint32, chunk []byte) error {
+	if int(index) >= len(m.Chunks) {
+		return fmt.Errorf("invalid archive, unexpected chunk %d", index)
+	}
+
+	expected := m.Chunks[index]
+	if int64(len(chunk)) != expected.Size {
+		return fmt.Errorf("invalid archive, expect %d bytes for chunk %d, got %d", expected.Size, index, len(chunk))
+	}
+	if checksum := sha256.Sum256(chunk); !bytes.Equal(checksum[:], expected.Checksum) {
+		return fmt.Errorf("invalid archive, expect checksum %X for chunk %d, got %X", expected.Checksum, index, checksum)
+	}
+
+	return nil
+}
+
+// readArchiveHeader reads the manifest and the snapshot at the start of an
+// archive. The manifest is nil for the archives dumped before it was added.
+func readArchiveHeader(tr *tar.Reader) (*Manifest, *snapshottypes.Snapshot, error) {
+	hdr, err := tr.Next()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read archive file header: %w", err)
+	}
+
+	var manifest *Manifest
+	if hdr.Name == ManifestFileName {
+		bz, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read manifest file: %w", err)
+		}
+		manifest = &Manifest{}
+		if err := json.Unmarshal(bz, manifest); err != nil {
+			return nil, nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
+		}
+
+		if hdr, err = tr.Next(); err != nil {
+			return nil, nil, fmt.Errorf("failed to read snapshot file header: %w", err)
+		}
+	}
+
+	if hdr.Name != SnapshotFileName {
+		return nil, nil, fmt.Errorf("invalid archive, expect file: snapshot, got: %s", hdr.Name)
+	}
+	bz, err := io.ReadAll(tr)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to read snapshot file: %w", err)
+	}
+	snapshot := &snapshottypes.Snapshot{}
+	if err := snapshot.Unmarshal(bz); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal snapshot: %w", err)
+	}
+
+	if manifest != nil {
+		if err := manifest.Validate(snapshot); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return manifest, snapshot, nil
+}
+
+// getAppHash returns the app hash of the commit at the given height, read from
+// the application database of the home directory.
+func getAppHash(home string, appOpts types.AppOptions, height uint64) ([]byte, error) {
+	db, err := openDB(home, server.GetAppDBBackend(appOpts))
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	cInfo, err := rootmulti.NewStore(db, log.NewNopLogger(), metrics.NewNoOpMetrics()).GetCommitInfo(int64(height))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit info at height %d: %w", height, err)
+	}
+
+	return cInfo.Hash(), nil
+}
@@ -0,0 +1,119 @@
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/server"
+)
+
+// VerifyArchiveCmd returns a command to verify a portable archive format snapshot
+// against the local chain, without starting the node.
+func VerifyArchiveCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "verify <archive-file>",
+		Short: "Verify a snapshot archive file (.tar.gz) against the local chain",
+		Long: `Verify a snapshot archive file (.tar.gz) against the local chain.
+The checksums of the chunks must match the manifest of the archive, and the chain-id and the app hash
+of the manifest must match the ones of the local chain at the height of the snapshot.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg := client.GetConfigFromCmd(cmd)
+			viper := client.GetViperFromCmd(cmd)
+
+			chainID, err := server.GetChainID(viper)
+			if err != nil {
+				return err
+			}
+
+			fp, err := os.Open(args[0])
+			if err != nil {
+				return fmt.Errorf("failed to open archive file: %w", err)
+			}
+			defer fp.Close()
+
+			manifest, err := VerifyArchive(fp, chainID, func(height uint64) ([]byte, error) {
+				return getAppHash(cfg.RootDir, viper, height)
+			})
+			if err != nil {
+				return err
+			}
+
+			cmd.Printf("Snapshot archive at height %d, format %d, chunks %d, app hash %s is valid\n", manifest.Height, manifest.Format, len(manifest.Chunks), manifest.AppHash)
+			return nil
+		},
+	}
+}
+
+// VerifyArchive verifies the gzipped snapshot archive read from r, whose chunks
+// must match its manifest, and returns the manifest. The chain-id and the app
+// hash of the manifest must match the given chain-id and the app hash returned
+// by getAppHash for the height of the snapshot.
+func VerifyArchive(r io.Reader, chainID string, getAppHash func(height uint64) ([]byte, error)) (*Manifest, error) {
+	reader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create gzip reader: %w", err)
+	}
+
+	tr := tar.NewReader(reader)
+	manifest, snapshot, err := readArchiveHeader(tr)
+	if err != nil {
+		return nil, err
+	}
+	if manifest == nil {
+		return nil, errors.New("invalid archive, missing manifest")
+	}
+
+	if manifest.ChainID != chainID {
+		return nil, fmt.Errorf("invalid archive, expect chain-id %s, got %s", chainID, manifest.ChainID)
+	}
+	appHash, err := getAppHash(manifest.Height)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.Equal(manifest.AppHash, appHash) {
+		return nil, fmt.Errorf("invalid archive, expect app hash %X at height %d, got %X", appHash, manifest.Height, manifest.AppHash)
+	}
+
+	snapshotHasher := sha256.New()
+	for i := uint32(0); i < snapshot.Chunks; i++ {
+		hdr, err := tr.Next()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chunk file header: %w", err)
+		}
+		if hdr.Name != strconv.FormatInt(int64(i), 10) {
+			return nil, fmt.Errorf("invalid archive, expect file: %d, got: %s", i, hdr.Name)
+		}
+
+		bz, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read chunk file: %w", err)
+		}
+		if err := manifest.VerifyChunk(i, bz); err != nil {
+			return nil, err
+		}
+		snapshotHasher.Write(bz)
+	}
+
+	if hash := snapshotHasher.Sum(nil); !bytes.Equal(hash, manifest.Hash) {
+		return nil, fmt.Errorf("invalid archive, expect snapshot hash %X, got %X", manifest.Hash, hash)
+	}
+	if hdr, err := tr.Next(); !errors.Is(err, io.EOF) {
+		if err != nil {
+			return nil, fmt.Errorf("failed to read archive: %w", err)
+		}
+		return nil, fmt.Errorf("invalid archive, unexpected file: %s", hdr.Name)
+	}
+
+	return manifest, nil
+}
@@ -0,0 +1,97 @@
+package snapshot_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	snapshottypes "cosmossdk.io/store/snapshots/types"
+
+	"github.com/cosmos/cosmos-sdk/client/snapshot"
+)
+
+func makeArchive(t *testing.T, manifest *snapshot.Manifest, s *snapshottypes.Snapshot, chunks [][]byte) []byte {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	gzipWriter := gzip.NewWriter(buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	writeFile := func(name string, bz []byte) {
+		require.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(bz))}))
+		_, err := tarWriter.Write(bz)
+		require.NoError(t, err)
+	}
+
+	if manifest != nil {
+		bz, err := json.Marshal(manifest)
+		require.NoError(t, err)
+		writeFile(snapshot.ManifestFileName, bz)
+	}
+	bz, err := s.Marshal()
+	require.NoError(t, err)
+	writeFile(snapshot.SnapshotFileName, bz)
+	for i, chunk := range chunks {
+		writeFile(strconv.Itoa(i), chunk)
+	}
+
+	require.NoError(t, tarWriter.Close())
+	require.NoError(t, gzipWriter.Close())
+	return buf.Bytes()
+}
+
+func TestVerifyArchive(t *testing.T) {
+	chunks := [][]byte{{1, 2, 3}, {4, 5}, {6}}
+	s := &snapshottypes.Snapshot{Height: 3, Format: snapshottypes.CurrentFormat, Chunks: uint32(len(chunks))}
+	hasher := sha256.New()
+	sizes := make([]int64, len(chunks))
+	for i, chunk := range chunks {
+		checksum := sha256.Sum256(chunk)
+		s.Metadata.ChunkHashes = append(s.Metadata.ChunkHashes, checksum[:])
+		hasher.Write(chunk)
+		sizes[i] = int64(len(chunk))
+	}
+	s.Hash = hasher.Sum(nil)
+
+	appHash := []byte("app-hash")
+	manifest, err := snapshot.NewManifest("test-chain", appHash, s, sizes)
+	require.NoError(t, err)
+	getAppHash := func(height uint64) ([]byte, error) {
+		require.Equal(t, s.Height, height)
+		return appHash, nil
+	}
+
+	verified, err := snapshot.VerifyArchive(bytes.NewReader(makeArchive(t, manifest, s, chunks)), "test-chain", getAppHash)
+	require.NoError(t, err)
+	require.Equal(t, manifest, verified)
+
+	// the archive must be of the local chain, at the same app hash
+	_, err = snapshot.VerifyArchive(bytes.NewReader(makeArchive(t, manifest, s, chunks)), "other-chain", getAppHash)
+	require.ErrorContains(t, err, "chain-id")
+	_, err = snapshot.VerifyArchive(bytes.NewReader(makeArchive(t, manifest, s, chunks)), "test-chain", func(uint64) ([]byte, error) {
+		return []byte("other-app-hash"), nil
+	})
+	require.ErrorContains(t, err, "app hash")
+
+	// the chunks must match their checksums, and all be there
+	tampered := [][]byte{chunks[0], {4, 6}, chunks[2]}
+	_, err = snapshot.VerifyArchive(bytes.NewReader(makeArchive(t, manifest, s, tampered)), "test-chain", getAppHash)
+	require.ErrorContains(t, err, "checksum")
+	_, err = snapshot.VerifyArchive(bytes.NewReader(makeArchive(t, manifest, s, chunks[:2])), "test-chain", getAppHash)
+	require.Error(t, err)
+
+	// the manifest must describe the snapshot
+	invalid := *manifest
+	invalid.Height = 4
+	_, err = snapshot.VerifyArchive(bytes.NewReader(makeArchive(t, &invalid, s, chunks)), "test-chain", getAppHash)
+	require.ErrorContains(t, err, "invalid manifest")
+
+	// and archives without manifest can't be verified
+	_, err = snapshot.VerifyArchive(bytes.NewReader(makeArchive(t, nil, s, chunks)), "test-chain", getAppHash)
+	require.ErrorContains(t, err, "missing manifest")
+}
@@ -171,17 +171,22 @@ require (
 	pgregory.net/rapid v1.1.0 // indirect
 )
 
-require github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+require (
+	cosmossdk.io/schema v0.0.0-00010101000000-000000000000 // indirect
+	github.com/munnerz/goautoneg v0.0.0-20191010083416-a7dc8b61c822 // indirect
+)
 
 replace github.com/cosmos/cosmos-sdk => ./../../
 
 // TODO remove post spinning out all modules
 replace (
 	cosmossdk.io/api => ./../../api
+	cosmossdk.io/collections => ./../../collections
 	cosmossdk.io/core => ./../../core
 	cosmossdk.io/core/testing => ../../core/testing
 	cosmossdk.io/depinject => ./../../depinject
 	cosmossdk.io/log => ./../../log
+	cosmossdk.io/schema => ./../../schema
 	cosmossdk.io/store => ./../../store
 	cosmossdk.io/x/accounts => ./../../x/accounts
 	cosmossdk.io/x/auth => ./../../x/auth
@@ -0,0 +1,302 @@
+package codec
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+
+	"cosmossdk.io/schema"
+)
+
+// HasSchemaCodec is an interface that all codec's should implement in order
+// to properly support indexing. It is not required by KeyCodec or ValueCodec
+// in order to preserve backwards compatibility, but a future version of collections
+// may make it required and all codec's should aim to implement it. If it is not
+// implemented, fallback defaults will be used for indexing that may be sub-optimal.
+type HasSchemaCodec[T any] interface {
+	// SchemaCodec returns the schema codec for the collections codec.
+	SchemaCodec() (SchemaCodec[T], error)
+}
+
+// SchemaCodec is a codec that supports converting collection codec values to
+// schema codec values.
+type SchemaCodec[T any] struct {
+	// Fields are the schema fields that the codec represents. If this is empty,
+	// it will be assumed that this codec represents no value (such as an item key
+	// or key set value).
+	Fields []schema.Field
+
+	// ToSchemaType converts a codec value of type T to a value corresponding to
+	// a schema object key or value (depending on whether this is a key or value
+	// codec). The returned value should pass validation with schema.ValidateForKeyFields
+	// or schema.ValidateForValueFields depending on whether the codec is a key or
+	// value codec respectively.
+	ToSchemaType func(T) (interface{}, error)
+}
+
+// KeySchemaCodec gets the schema codec for the provided KeyCodec either
+// by casting to HasSchemaCodec or returning a fallback codec which represents
+// the key with the simplest schema.Kind matching its go type, or as a string
+// using KeyCodec.Stringify if no such kind exists. Keys are never represented
+// with schema.ObjectKind or schema.ListKind fields.
+func KeySchemaCodec[K any](cdc KeyCodec[K]) (SchemaCodec[K], error) {
+	if indexable, ok := cdc.(HasSchemaCodec[K]); ok {
+		return indexable.SchemaCodec()
+	}
+
+	if res, ok := reflectSchemaCodec[K](); ok && scalarFields(res.Fields) {
+		return res, nil
+	}
+
+	return SchemaCodec[K]{
+		Fields: []schema.Field{{Kind: schema.StringKind}},
+		ToSchemaType: func(k K) (interface{}, error) {
+			return cdc.Stringify(k), nil
+		},
+	}, nil
+}
+
+// ValueSchemaCodec gets the schema codec for the provided ValueCodec either
+// by casting to HasSchemaCodec or returning a fallback codec which represents
+// the value with the simplest schema.Kind matching its go type, or as JSON
+// using ValueCodec.EncodeJSON if no such kind exists. Struct values, such as
+// protobuf messages, are represented with one field per struct field when all
+// their fields can be represented.
+func ValueSchemaCodec[V any](cdc ValueCodec[V]) (SchemaCodec[V], error) {
+	if indexable, ok := cdc.(HasSchemaCodec[V]); ok {
+		return indexable.SchemaCodec()
+	}
+
+	if res, ok := reflectSchemaCodec[V](); ok {
+		return res, nil
+	}
+
+	return SchemaCodec[V]{
+		Fields: []schema.Field{{Kind: schema.JSONKind}},
+		ToSchemaType: func(v V) (interface{}, error) {
+			bz, err := cdc.EncodeJSON(v)
+			if err != nil {
+				return nil, err
+			}
+			return json.RawMessage(bz), nil
+		},
+	}, nil
+}
+
+// reflectSchemaCodec returns a schema codec for T if the go type of T can be represented
+// with schema fields. Named types (ex. type Address []byte) are converted to their underlying
+// type, slices of scalars are represented as schema.ListKind, structs are flattened into one
+// field per struct field and nested structs are represented as schema.ObjectKind.
+func reflectSchemaCodec[T any]() (SchemaCodec[T], bool) {
+	var zero T
+	kind := schema.KindForGoValue(zero)
+	if kind.Validate() == nil {
+		return SchemaCodec[T]{
+			Fields: []schema.Field{{Kind: kind}},
+			ToSchemaType: func(t T) (interface{}, error) {
+				return t, nil
+			},
+		}, true
+	}
+
+	typ := reflect.TypeOf(&zero).Elem()
+	if typ.Kind() == reflect.Struct && typ != timeType {
+		fields, convert, ok := reflectObjectFields(typ, map[reflect.Type]bool{})
+		if !ok {
+			return SchemaCodec[T]{}, false
+		}
+
+		return SchemaCodec[T]{
+			Fields: fields,
+			ToSchemaType: func(t T) (interface{}, error) {
+				values := convert(reflect.ValueOf(t)).([]interface{})
+				if len(values) == 1 {
+					return values[0], nil
+				}
+				return values, nil
+			},
+		}, true
+	}
+
+	field, convert, ok := reflectField(typ, map[reflect.Type]bool{})
+	if !ok {
+		return SchemaCodec[T]{}, false
+	}
+
+	return SchemaCodec[T]{
+		Fields: []schema.Field{field},
+		ToSchemaType: func(t T) (interface{}, error) {
+			return convert(reflect.ValueOf(t)), nil
+		},
+	}, true
+}
+
+var (
+	timeType       = reflect.TypeOf(time.Time{})
+	durationType   = reflect.TypeOf(time.Duration(0))
+	rawMessageType = reflect.TypeOf(json.RawMessage(nil))
+)
+
+var underlyingKinds = map[reflect.Kind]struct {
+	typ  reflect.Type
+	kind schema.Kind
+}{
+	reflect.String:  {reflect.TypeOf(""), schema.StringKind},
+	reflect.Bool:    {reflect.TypeOf(false), schema.BoolKind},
+	reflect.Int8:    {reflect.TypeOf(int8(0)), schema.Int8Kind},
+	reflect.Int16:   {reflect.TypeOf(int16(0)), schema.Int16Kind},
+	reflect.Int32:   {reflect.TypeOf(int32(0)), schema.Int32Kind},
+	reflect.Int64:   {reflect.TypeOf(int64(0)), schema.Int64Kind},
+	reflect.Uint8:   {reflect.TypeOf(uint8(0)), schema.Uint8Kind},
+	reflect.Uint16:  {reflect.TypeOf(uint16(0)), schema.Uint16Kind},
+	reflect.Uint32:  {reflect.TypeOf(uint32(0)), schema.Uint32Kind},
+	reflect.Uint64:  {reflect.TypeOf(uint64(0)), schema.Uint64Kind},
+	reflect.Float32: {reflect.TypeOf(float32(0)), schema.Float32Kind},
+	reflect.Float64: {reflect.TypeOf(float64(0)), schema.Float64Kind},
+}
+
+func underlyingKind(typ reflect.Type) (reflect.Type, schema.Kind) {
+	if typ.Kind() == reflect.Slice && typ.Elem().Kind() == reflect.Uint8 {
+		return reflect.TypeOf([]byte(nil)), schema.BytesKind
+	}
+
+	if k, ok := underlyingKinds[typ.Kind()]; ok {
+		return k.typ, k.kind
+	}
+
+	return nil, schema.InvalidKind
+}
+
+// reflectField returns the unnamed schema field representing values of the go type and
+// a function converting such values to schema values. visiting contains the struct
+// types being visited to reject recursive types.
+func reflectField(typ reflect.Type, visiting map[reflect.Type]bool) (schema.Field, func(reflect.Value) interface{}, bool) {
+	switch typ {
+	case timeType, durationType, rawMessageType:
+		return schema.Field{Kind: schema.KindForGoValue(reflect.Zero(typ).Interface())}, reflect.Value.Interface, true
+	}
+
+	if target, kind := underlyingKind(typ); kind != schema.InvalidKind {
+		return schema.Field{Kind: kind}, func(v reflect.Value) interface{} {
+			return v.Convert(target).Interface()
+		}, true
+	}
+
+	switch typ.Kind() {
+	case reflect.Slice:
+		elem, convertElem, ok := reflectField(typ.Elem(), visiting)
+		if !ok || elem.Nullable || !scalarFields([]schema.Field{elem}) {
+			return schema.Field{}, nil, false
+		}
+
+		return schema.Field{Kind: schema.ListKind, ElementKind: elem.Kind}, func(v reflect.Value) interface{} {
+			res := make([]interface{}, v.Len())
+			for i := range res {
+				res[i] = convertElem(v.Index(i))
+			}
+			return res
+		}, true
+
+	case reflect.Struct:
+		fields, convert, ok := reflectObjectFields(typ, visiting)
+		return schema.Field{Kind: schema.ObjectKind, ObjectFields: fields}, convert, ok
+
+	case reflect.Ptr:
+		field, convertElem, ok := reflectField(typ.Elem(), visiting)
+		if !ok || field.Nullable {
+			return schema.Field{}, nil, false
+		}
+
+		field.Nullable = true
+		return field, func(v reflect.Value) interface{} {
+			if v.IsNil() {
+				return nil
+			}
+			return convertElem(v.Elem())
+		}, true
+
+	default:
+		return schema.Field{}, nil, false
+	}
+}
+
+// reflectObjectFields returns the schema fields representing the exported fields of the
+// struct type and a function converting such structs to a []interface{} with one value
+// per field. Fields are named after their json tag if they have one, and fields ignored
+// by encoding/json or generated by protobuf (XXX_ prefix) are skipped.
+func reflectObjectFields(typ reflect.Type, visiting map[reflect.Type]bool) ([]schema.Field, func(reflect.Value) interface{}, bool) {
+	if visiting[typ] {
+		return nil, nil, false
+	}
+	visiting[typ] = true
+	defer delete(visiting, typ)
+
+	var (
+		fields   []schema.Field
+		indexes  []int
+		converts []func(reflect.Value) interface{}
+	)
+	for i := 0; i < typ.NumField(); i++ {
+		structField := typ.Field(i)
+		if !structField.IsExported() || strings.HasPrefix(structField.Name, "XXX_") {
+			continue
+		}
+
+		name := structField.Name
+		if tag, ok := structField.Tag.Lookup("json"); ok {
+			tagName, _, _ := strings.Cut(tag, ",")
+			if tagName == "-" {
+				continue
+			}
+			if tagName != "" {
+				name = tagName
+			}
+		}
+		if !schema.ValidateName(name) {
+			return nil, nil, false
+		}
+
+		field, convert, ok := reflectField(structField.Type, visiting)
+		if !ok {
+			return nil, nil, false
+		}
+		field.Name = name
+
+		fields = append(fields, field)
+		indexes = append(indexes, i)
+		converts = append(converts, convert)
+	}
+
+	if len(fields) == 0 {
+		return nil, nil, false
+	}
+
+	return fields, func(v reflect.Value) interface{} {
+		res := make([]interface{}, len(indexes))
+		for i, idx := range indexes {
+			res[i] = converts[i](v.Field(idx))
+		}
+		return res
+	}, true
+}
+
+// scalarFields returns true if none of the fields is a nested object or list.
+func scalarFields(fields []schema.Field) bool {
+	for _, field := range fields {
+		if field.Kind == schema.ObjectKind || field.Kind == schema.ListKind {
+			return false
+		}
+	}
+	return true
+}
+
+// SchemaCodec implements HasSchemaCodec by using the schema codec of the wrapped KeyCodec.
+func (k keyToValueCodec[K]) SchemaCodec() (SchemaCodec[K], error) {
+	return KeySchemaCodec(k.kc)
+}
+
+// SchemaCodec implements HasSchemaCodec by using the schema codec of the canonical ValueCodec.
+func (a AltValueCodec[V]) SchemaCodec() (SchemaCodec[V], error) {
+	return ValueSchemaCodec(a.canonicalValueCodec)
+}
@@ -0,0 +1,121 @@
+package codec
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/schema"
+)
+
+type namedUint64 uint64
+
+// jsonValue can't be represented with schema fields because of its map field.
+type jsonValue struct {
+	A map[string]string `json:"a"`
+}
+
+type jsonValueCodec[T any] struct{ ValueCodec[T] }
+
+func (jsonValueCodec[T]) EncodeJSON(value T) ([]byte, error) { return json.Marshal(value) }
+
+type stringsKeyCodec struct{ KeyCodec[[]string] }
+
+func (stringsKeyCodec) Stringify(key []string) string { return strings.Join(key, ",") }
+
+func TestKeySchemaCodec(t *testing.T) {
+	res, err := KeySchemaCodec(NewUint64Key[namedUint64]())
+	require.NoError(t, err)
+	require.Equal(t, []schema.Field{{Kind: schema.Uint64Kind}}, res.Fields)
+	v, err := res.ToSchemaType(namedUint64(7))
+	require.NoError(t, err)
+	require.Equal(t, uint64(7), v)
+
+	res2, err := KeySchemaCodec(NewBytesKey[[]byte]())
+	require.NoError(t, err)
+	require.Equal(t, []schema.Field{{Kind: schema.BytesKind}}, res2.Fields)
+}
+
+func TestValueSchemaCodec(t *testing.T) {
+	res, err := ValueSchemaCodec(KeyToValueCodec(NewStringKeyCodec[string]()))
+	require.NoError(t, err)
+	require.Equal(t, []schema.Field{{Kind: schema.StringKind}}, res.Fields)
+
+	res2, err := ValueSchemaCodec[jsonValue](jsonValueCodec[jsonValue]{})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Field{{Kind: schema.JSONKind}}, res2.Fields)
+	v, err := res2.ToSchemaType(jsonValue{A: map[string]string{"b": "c"}})
+	require.NoError(t, err)
+	require.Equal(t, json.RawMessage(`{"a":{"b":"c"}}`), v)
+}
+
+type testCoin struct {
+	Denom  string `json:"denom,omitempty"`
+	Amount uint64 `json:"amount,omitempty"`
+}
+
+type testAccount struct {
+	Address       []byte    `json:"address,omitempty"`
+	Coin          testCoin  `json:"coin"`
+	Vesting       *testCoin `json:"vesting,omitempty"`
+	Tags          []string  `json:"tags,omitempty"`
+	Created       time.Time `json:"created"`
+	Ignored       string    `json:"-"`
+	Untagged      int32
+	unexposed     bool
+	XXX_sizecache int32
+}
+
+type testRecursive struct {
+	Name string         `json:"name"`
+	Next *testRecursive `json:"next"`
+}
+
+func TestValueSchemaCodecStruct(t *testing.T) {
+	res, err := ValueSchemaCodec[testAccount](jsonValueCodec[testAccount]{})
+	require.NoError(t, err)
+	coinFields := []schema.Field{{Name: "denom", Kind: schema.StringKind}, {Name: "amount", Kind: schema.Uint64Kind}}
+	require.Equal(t, []schema.Field{
+		{Name: "address", Kind: schema.BytesKind},
+		{Name: "coin", Kind: schema.ObjectKind, ObjectFields: coinFields},
+		{Name: "vesting", Kind: schema.ObjectKind, ObjectFields: coinFields, Nullable: true},
+		{Name: "tags", Kind: schema.ListKind, ElementKind: schema.StringKind},
+		{Name: "created", Kind: schema.TimeKind},
+		{Name: "Untagged", Kind: schema.Int32Kind},
+	}, res.Fields)
+
+	created := time.Unix(10, 0)
+	v, err := res.ToSchemaType(testAccount{
+		Address:  []byte("addr"),
+		Coin:     testCoin{Denom: "atom", Amount: 10},
+		Tags:     []string{"a", "b"},
+		Created:  created,
+		Untagged: 5,
+	})
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{
+		[]byte("addr"),
+		[]interface{}{"atom", uint64(10)},
+		nil,
+		[]interface{}{"a", "b"},
+		created,
+		int32(5),
+	}, v)
+
+	objectType := schema.ObjectType{Name: "accounts", KeyFields: []schema.Field{{Name: "key", Kind: schema.StringKind}}, ValueFields: res.Fields}
+	require.NoError(t, objectType.Validate())
+	require.NoError(t, objectType.ValidateObjectUpdate(schema.ObjectUpdate{TypeName: "accounts", Key: "a", Value: v}))
+
+	// recursive types can't be represented
+	res2, err := ValueSchemaCodec[testRecursive](jsonValueCodec[testRecursive]{})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Field{{Kind: schema.JSONKind}}, res2.Fields)
+
+	// keys are never represented with nested fields
+	res3, err := KeySchemaCodec[[]string](stringsKeyCodec{})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Field{{Kind: schema.StringKind}}, res3.Fields)
+}
@@ -90,6 +90,13 @@ type Collection interface {
 	ValueCodec() codec.UntypedValueCodec
 
 	genesisHandler
+
+	// isSecondaryIndex indicates that this collection represents a secondary index
+	// in the schema and should be excluded from the module's user facing schema.
+	isSecondaryIndex() bool
+
+	// schemaCodec returns the schema codec for this collection.
+	schemaCodec() (collectionSchemaCodec, error)
 }
 
 // Prefix defines a segregation bytes namespace for specific collections objects.
@@ -146,6 +153,8 @@ func (c collectionImpl[K, V]) GetName() string { return c.m.name }
 
 func (c collectionImpl[K, V]) GetPrefix() []byte { return NewPrefix(c.m.prefix) }
 
+func (c collectionImpl[K, V]) isSecondaryIndex() bool { return c.m.isSecondaryIndex }
+
 func (c collectionImpl[K, V]) validateGenesis(r io.Reader) error { return c.m.validateGenesis(r) }
 
 func (c collectionImpl[K, V]) importGenesis(ctx context.Context, r io.Reader) error {
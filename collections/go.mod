@@ -5,6 +5,7 @@ go 1.21
 require (
 	cosmossdk.io/core v0.12.0
 	cosmossdk.io/core/testing v0.0.0-00010101000000-000000000000
+	cosmossdk.io/schema v0.0.0-00010101000000-000000000000
 	github.com/stretchr/testify v1.9.0
 	pgregory.net/rapid v1.1.0
 )
@@ -30,4 +31,5 @@ require (
 replace (
 	cosmossdk.io/core => ../core
 	cosmossdk.io/core/testing => ../core/testing
+	cosmossdk.io/schema => ../schema
 )
@@ -27,7 +27,7 @@ func NewUnique[ReferenceKey, PrimaryKey, Value any](
 ) *Unique[ReferenceKey, PrimaryKey, Value] {
 	return &Unique[ReferenceKey, PrimaryKey, Value]{
 		getRefKey: getRefKeyFunc,
-		refKeys:   collections.NewMap(schema, prefix, name, refCodec, codec.KeyToValueCodec(pkCodec)),
+		refKeys:   collections.NewMap(schema, prefix, name, refCodec, codec.KeyToValueCodec(pkCodec), collections.WithMapSecondaryIndex()),
 	}
 }
 
@@ -0,0 +1,207 @@
+package collections
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+
+	"cosmossdk.io/collections/codec"
+	"cosmossdk.io/schema"
+)
+
+// IndexingOptions are indexing options for the collections schema.
+type IndexingOptions struct {
+	// RetainDeletionsFor is the list of collections to retain deletions for.
+	RetainDeletionsFor []string
+}
+
+// ModuleCodec returns the ModuleCodec for this schema for the provided options.
+// Every collection which is not a secondary index is represented as an object type
+// with the collection's name, its key codec mapped to key fields and its value
+// codec mapped to value fields.
+func (s Schema) ModuleCodec(opts IndexingOptions) (schema.ModuleCodec, error) {
+	retainDeletions := make(map[string]bool, len(opts.RetainDeletionsFor))
+	for _, name := range opts.RetainDeletionsFor {
+		if _, ok := s.collectionsByName[name]; !ok {
+			return schema.ModuleCodec{}, fmt.Errorf("collection %s not found in schema", name)
+		}
+		retainDeletions[name] = true
+	}
+
+	var (
+		objectTypes []schema.ObjectType
+		decoder     moduleDecoder
+	)
+	for _, name := range s.collectionsOrdered {
+		coll := s.collectionsByName[name]
+		if coll.isSecondaryIndex() {
+			continue
+		}
+
+		cdc, err := coll.schemaCodec()
+		if err != nil {
+			return schema.ModuleCodec{}, fmt.Errorf("collection %s: %w", name, err)
+		}
+
+		cdc.objectType.RetainDeletions = retainDeletions[name]
+		objectTypes = append(objectTypes, cdc.objectType)
+		decoder.collections = append(decoder.collections, cdc)
+	}
+
+	sort.Slice(decoder.collections, func(i, j int) bool {
+		return bytes.Compare(decoder.collections[i].prefix, decoder.collections[j].prefix) < 0
+	})
+
+	moduleSchema := schema.ModuleSchema{ObjectTypes: objectTypes}
+	if err := moduleSchema.Validate(); err != nil {
+		return schema.ModuleCodec{}, err
+	}
+
+	return schema.ModuleCodec{
+		Schema:    moduleSchema,
+		KVDecoder: decoder.decodeKV,
+	}, nil
+}
+
+// moduleDecoder decodes the KV-pairs of all the collections in a schema.
+type moduleDecoder struct {
+	// collections are sorted by prefix.
+	collections []collectionSchemaCodec
+}
+
+func (m moduleDecoder) decodeKV(key, value []byte) (schema.ObjectUpdate, bool, error) {
+	// the collection owning the key is the one with the greatest prefix that is less than or
+	// equal to the key, because the prefixes in a schema never overlap.
+	i := sort.Search(len(m.collections), func(i int) bool {
+		return bytes.Compare(m.collections[i].prefix, key) > 0
+	}) - 1
+	if i < 0 || !bytes.HasPrefix(key, m.collections[i].prefix) {
+		return schema.ObjectUpdate{}, false, nil
+	}
+
+	return m.collections[i].decodeKV(key, value)
+}
+
+// collectionSchemaCodec maps the KV-pairs of a collection to object updates.
+type collectionSchemaCodec struct {
+	prefix       []byte
+	objectType   schema.ObjectType
+	keyDecoder   func([]byte) (interface{}, error)
+	valueDecoder func([]byte) (interface{}, error)
+}
+
+func (c collectionSchemaCodec) decodeKV(key, value []byte) (schema.ObjectUpdate, bool, error) {
+	k, err := c.keyDecoder(key[len(c.prefix):])
+	if err != nil {
+		return schema.ObjectUpdate{}, false, fmt.Errorf("failed to decode key for %s: %w", c.objectType.Name, err)
+	}
+
+	// a nil value means that the key was deleted
+	if value == nil {
+		return schema.ObjectUpdate{TypeName: c.objectType.Name, Key: k, Delete: true}, true, nil
+	}
+
+	v, err := c.valueDecoder(value)
+	if err != nil {
+		return schema.ObjectUpdate{}, false, fmt.Errorf("failed to decode value for %s: %w", c.objectType.Name, err)
+	}
+
+	return schema.ObjectUpdate{TypeName: c.objectType.Name, Key: k, Value: v}, true, nil
+}
+
+func (c collectionImpl[K, V]) schemaCodec() (collectionSchemaCodec, error) {
+	res := collectionSchemaCodec{
+		prefix:     c.GetPrefix(),
+		objectType: schema.ObjectType{Name: c.GetName()},
+	}
+
+	keyCodec, err := codec.KeySchemaCodec(c.m.kc)
+	if err != nil {
+		return collectionSchemaCodec{}, err
+	}
+	res.objectType.KeyFields = namedFields(keyCodec.Fields, "key")
+	res.keyDecoder = func(bz []byte) (interface{}, error) {
+		_, k, err := c.m.kc.Decode(bz)
+		if err != nil {
+			return nil, err
+		}
+		return keyCodec.ToSchemaType(k)
+	}
+
+	valueCodec, err := codec.ValueSchemaCodec(c.m.vc)
+	if err != nil {
+		return collectionSchemaCodec{}, err
+	}
+	res.objectType.ValueFields = namedFields(valueCodec.Fields, "value")
+
+	// value fields derived from struct fields may collide with the key field names
+	keyNames := make(map[string]bool, len(res.objectType.KeyFields))
+	for _, field := range res.objectType.KeyFields {
+		keyNames[field.Name] = true
+	}
+	for i, field := range res.objectType.ValueFields {
+		if keyNames[field.Name] {
+			res.objectType.ValueFields[i].Name = "value_" + field.Name
+		}
+	}
+	res.valueDecoder = func(bz []byte) (interface{}, error) {
+		v, err := c.m.vc.Decode(bz)
+		if err != nil {
+			return nil, err
+		}
+		return valueCodec.ToSchemaType(v)
+	}
+
+	return res, nil
+}
+
+// namedFields returns a copy of the fields where fields without a name are named after
+// the provided default name, suffixed with their position if there is more than one field.
+func namedFields(fields []schema.Field, name string) []schema.Field {
+	res := make([]schema.Field, len(fields))
+	copy(res, fields)
+	for i := range res {
+		if res[i].Name != "" {
+			continue
+		}
+		if len(res) == 1 {
+			res[i].Name = name
+		} else {
+			res[i].Name = fmt.Sprintf("%s%d", name, i+1)
+		}
+	}
+	return res
+}
+
+// keyPartSchemaCodec returns the schema field and conversion function for one part of a multipart
+// key. Each part is required to be represented by exactly one field which is named after the part.
+func keyPartSchemaCodec[K any](cdc codec.KeyCodec[K], name string) (schema.Field, func(K) (interface{}, error), error) {
+	res, err := codec.KeySchemaCodec(cdc)
+	if err != nil {
+		return schema.Field{}, nil, err
+	}
+
+	if len(res.Fields) != 1 {
+		return schema.Field{}, nil, fmt.Errorf("expected exactly one field for %s, got %d", name, len(res.Fields))
+	}
+
+	field := res.Fields[0]
+	if field.Name == "" {
+		field.Name = name
+	}
+	return field, res.ToSchemaType, nil
+}
+
+// SchemaCodec implements codec.HasSchemaCodec for item keys which have no fields.
+func (noKey) SchemaCodec() (codec.SchemaCodec[noKey], error) {
+	return codec.SchemaCodec[noKey]{
+		ToSchemaType: func(noKey) (interface{}, error) { return nil, nil },
+	}, nil
+}
+
+// SchemaCodec implements codec.HasSchemaCodec for key set values which have no fields.
+func (NoValue) SchemaCodec() (codec.SchemaCodec[NoValue], error) {
+	return codec.SchemaCodec[NoValue]{
+		ToSchemaType: func(NoValue) (interface{}, error) { return nil, nil },
+	}, nil
+}
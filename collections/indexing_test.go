@@ -0,0 +1,131 @@
+package collections
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/collections/codec"
+	"cosmossdk.io/schema"
+)
+
+type testAddress []byte
+
+func TestModuleCodec(t *testing.T) {
+	sk, ctx := deps()
+	schemaBuilder := NewSchemaBuilder(sk)
+	balances := NewMap(schemaBuilder, NewPrefix(1), "balances", PairKeyCodec(codec.NewBytesKey[testAddress](), StringKey), Uint64Value)
+	params := NewItem(schemaBuilder, NewPrefix(2), "params", BoolValue)
+	allowed := NewKeySet(schemaBuilder, NewPrefix(3), "allowed", StringKey)
+	NewKeySet(schemaBuilder, NewPrefix(4), "index", StringKey, WithKeySetSecondaryIndex())
+	s, err := schemaBuilder.Build()
+	require.NoError(t, err)
+
+	moduleCodec, err := s.ModuleCodec(IndexingOptions{RetainDeletionsFor: []string{"allowed"}})
+	require.NoError(t, err)
+	require.Equal(t, schema.ModuleSchema{ObjectTypes: []schema.ObjectType{
+		{
+			Name:            "allowed",
+			KeyFields:       []schema.Field{{Name: "key", Kind: schema.StringKind}},
+			ValueFields:     []schema.Field{},
+			RetainDeletions: true,
+		},
+		{
+			Name: "balances",
+			KeyFields: []schema.Field{
+				{Name: "key1", Kind: schema.BytesKind},
+				{Name: "key2", Kind: schema.StringKind},
+			},
+			ValueFields: []schema.Field{{Name: "value", Kind: schema.Uint64Kind}},
+		},
+		{
+			Name:        "params",
+			KeyFields:   []schema.Field{},
+			ValueFields: []schema.Field{{Name: "value", Kind: schema.BoolKind}},
+		},
+	}}, moduleCodec.Schema)
+
+	require.NoError(t, balances.Set(ctx, Join(testAddress("addr"), "atom"), 10))
+	require.NoError(t, params.Set(ctx, true))
+	require.NoError(t, allowed.Set(ctx, "foo"))
+
+	decode := func(key, value []byte) schema.ObjectUpdate {
+		t.Helper()
+		update, ok, err := moduleCodec.KVDecoder(key, value)
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.NoError(t, moduleCodec.Schema.ValidateObjectUpdate(update))
+		return update
+	}
+
+	key, err := EncodeKeyWithPrefix(balances.GetPrefix(), balances.KeyCodec(), Join(testAddress("addr"), "atom"))
+	require.NoError(t, err)
+	value, err := balances.ValueCodec().Encode(10)
+	require.NoError(t, err)
+	require.Equal(t, schema.ObjectUpdate{
+		TypeName: "balances",
+		Key:      []interface{}{[]byte("addr"), "atom"},
+		Value:    uint64(10),
+	}, decode(key, value))
+	require.Equal(t, schema.ObjectUpdate{
+		TypeName: "balances",
+		Key:      []interface{}{[]byte("addr"), "atom"},
+		Delete:   true,
+	}, decode(key, nil))
+
+	require.Equal(t, schema.ObjectUpdate{TypeName: "params", Value: true}, decode([]byte{2}, []byte{1}))
+	require.Equal(t, schema.ObjectUpdate{TypeName: "allowed", Key: "foo"}, decode([]byte{3, 'f', 'o', 'o'}, []byte{}))
+
+	// secondary indexes and unknown prefixes are not decoded
+	for _, key := range [][]byte{{4, 'f', 'o', 'o'}, {5}, {0}} {
+		_, ok, err := moduleCodec.KVDecoder(key, []byte{})
+		require.NoError(t, err)
+		require.False(t, ok)
+	}
+
+	_, err = s.ModuleCodec(IndexingOptions{RetainDeletionsFor: []string{"unknown"}})
+	require.ErrorContains(t, err, "collection unknown not found")
+}
+
+type testValidator struct {
+	Key    string `json:"key"`
+	Power  int64  `json:"power"`
+	Jailed bool   `json:"jailed"`
+}
+
+type testValidatorValueCodec struct {
+	codec.ValueCodec[testValidator]
+}
+
+func (testValidatorValueCodec) Decode(b []byte) (testValidator, error) {
+	var v testValidator
+	err := json.Unmarshal(b, &v)
+	return v, err
+}
+
+func TestModuleCodecStructValue(t *testing.T) {
+	sk, _ := deps()
+	schemaBuilder := NewSchemaBuilder(sk)
+	NewMap(schemaBuilder, NewPrefix(1), "validators", StringKey, testValidatorValueCodec{})
+	s, err := schemaBuilder.Build()
+	require.NoError(t, err)
+
+	moduleCodec, err := s.ModuleCodec(IndexingOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []schema.Field{
+		{Name: "value_key", Kind: schema.StringKind},
+		{Name: "power", Kind: schema.Int64Kind},
+		{Name: "jailed", Kind: schema.BoolKind},
+	}, moduleCodec.Schema.ObjectTypes[0].ValueFields)
+
+	update, ok, err := moduleCodec.KVDecoder([]byte{1, 'v', 'a', 'l'}, []byte(`{"key":"pk","power":10,"jailed":true}`))
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, schema.ObjectUpdate{
+		TypeName: "validators",
+		Key:      "val",
+		Value:    []interface{}{"pk", int64(10), true},
+	}, update)
+	require.NoError(t, moduleCodec.Schema.ValidateObjectUpdate(update))
+}
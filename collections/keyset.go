@@ -21,7 +21,18 @@ func WithKeySetUncheckedValue() func(opt *keySetOptions) {
 	}
 }
 
-type keySetOptions struct{ uncheckedValue bool }
+// WithKeySetSecondaryIndex changes the behavior of the KeySet to be a secondary index.
+// Secondary indexes are skipped when generating the module's logical schema.
+func WithKeySetSecondaryIndex() func(opt *keySetOptions) {
+	return func(opt *keySetOptions) {
+		opt.isSecondaryIndex = true
+	}
+}
+
+type keySetOptions struct {
+	uncheckedValue   bool
+	isSecondaryIndex bool
+}
 
 // KeySet builds on top of a Map and represents a collection retaining only a set
 // of keys and no value. It can be used, for example, in an allow list.
@@ -44,6 +55,9 @@ func NewKeySet[K any](
 	if o.uncheckedValue {
 		vc = codec.NewAltValueCodec(vc, func(_ []byte) (NoValue, error) { return NoValue{}, nil })
 	}
+	if o.isSecondaryIndex {
+		return (KeySet[K])(NewMap(schema, prefix, name, keyCodec, vc, WithMapSecondaryIndex()))
+	}
 	return (KeySet[K])(NewMap(schema, prefix, name, keyCodec, vc))
 }
 
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"cosmossdk.io/collections/codec"
+	"cosmossdk.io/schema"
 )
 
 // Pair defines a key composed of two keys.
@@ -135,6 +136,35 @@ func (p pairKeyCodec[K1, K2]) KeyType() string {
 	return fmt.Sprintf("Pair[%s, %s]", p.keyCodec1.KeyType(), p.keyCodec2.KeyType())
 }
 
+// SchemaCodec implements codec.HasSchemaCodec by representing each part of the pair
+// as its own key field.
+func (p pairKeyCodec[K1, K2]) SchemaCodec() (codec.SchemaCodec[Pair[K1, K2]], error) {
+	field1, toSchemaType1, err := keyPartSchemaCodec(p.keyCodec1, "key1")
+	if err != nil {
+		return codec.SchemaCodec[Pair[K1, K2]]{}, err
+	}
+
+	field2, toSchemaType2, err := keyPartSchemaCodec(p.keyCodec2, "key2")
+	if err != nil {
+		return codec.SchemaCodec[Pair[K1, K2]]{}, err
+	}
+
+	return codec.SchemaCodec[Pair[K1, K2]]{
+		Fields: []schema.Field{field1, field2},
+		ToSchemaType: func(pair Pair[K1, K2]) (interface{}, error) {
+			k1, err := toSchemaType1(pair.K1())
+			if err != nil {
+				return nil, err
+			}
+			k2, err := toSchemaType2(pair.K2())
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{k1, k2}, nil
+		},
+	}, nil
+}
+
 func (p pairKeyCodec[K1, K2]) EncodeNonTerminal(buffer []byte, pair Pair[K1, K2]) (int, error) {
 	writtenTotal := 0
 	if pair.key1 != nil {
@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"cosmossdk.io/collections/codec"
+	"cosmossdk.io/schema"
 )
 
 // Triple defines a multipart key composed of three keys.
@@ -153,6 +154,44 @@ func (t tripleKeyCodec[K1, K2, K3]) KeyType() string {
 	return fmt.Sprintf("Triple[%s,%s,%s]", t.keyCodec1.KeyType(), t.keyCodec2.KeyType(), t.keyCodec3.KeyType())
 }
 
+// SchemaCodec implements codec.HasSchemaCodec by representing each part of the triple
+// as its own key field.
+func (t tripleKeyCodec[K1, K2, K3]) SchemaCodec() (codec.SchemaCodec[Triple[K1, K2, K3]], error) {
+	field1, toSchemaType1, err := keyPartSchemaCodec(t.keyCodec1, "key1")
+	if err != nil {
+		return codec.SchemaCodec[Triple[K1, K2, K3]]{}, err
+	}
+
+	field2, toSchemaType2, err := keyPartSchemaCodec(t.keyCodec2, "key2")
+	if err != nil {
+		return codec.SchemaCodec[Triple[K1, K2, K3]]{}, err
+	}
+
+	field3, toSchemaType3, err := keyPartSchemaCodec(t.keyCodec3, "key3")
+	if err != nil {
+		return codec.SchemaCodec[Triple[K1, K2, K3]]{}, err
+	}
+
+	return codec.SchemaCodec[Triple[K1, K2, K3]]{
+		Fields: []schema.Field{field1, field2, field3},
+		ToSchemaType: func(key Triple[K1, K2, K3]) (interface{}, error) {
+			k1, err := toSchemaType1(key.K1())
+			if err != nil {
+				return nil, err
+			}
+			k2, err := toSchemaType2(key.K2())
+			if err != nil {
+				return nil, err
+			}
+			k3, err := toSchemaType3(key.K3())
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{k1, k2, k3}, nil
+		},
+	}, nil
+}
+
 func (t tripleKeyCodec[K1, K2, K3]) Encode(buffer []byte, key Triple[K1, K2, K3]) (int, error) {
 	writtenTotal := 0
 	if key.k1 != nil {
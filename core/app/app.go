@@ -6,6 +6,7 @@ import (
 
 	appmodulev2 "cosmossdk.io/core/appmodule/v2"
 	"cosmossdk.io/core/event"
+	"cosmossdk.io/core/gas"
 	"cosmossdk.io/core/transaction"
 )
 
@@ -41,6 +42,9 @@ type BlockResponse struct {
 	BeginBlockEvents          []event.Event
 	TxResults                 []TxResult
 	EndBlockEvents            []event.Event
+	// GasSchedule is the gas schedule the block was executed with, if the state
+	// transition function was configured with one.
+	GasSchedule *gas.Schedule
 }
 
 type RequestInitChain struct {
@@ -67,6 +71,27 @@ type TxResult struct {
 	GasWanted uint64
 	GasUsed   uint64
 	Codespace string
+	// Trace is the trace of the keys accessed by the transaction, if tracing is enabled.
+	Trace *TxTrace
+}
+
+// TxTrace is the trace of the keys of the state accessed by a transaction.
+type TxTrace struct {
+	// Reads are the keys the transaction read from the state it was executed on,
+	// ignoring the keys it read after writing them.
+	Reads []KeyAccess
+	// Writes are the keys the transaction wrote.
+	Writes []KeyAccess
+}
+
+// KeyAccess is the access to a key of the state of an actor.
+type KeyAccess struct {
+	Actor []byte
+	Key   []byte
+	// Value is the value read or written, if values are traced.
+	Value []byte
+	// Remove is true when the key is removed by a write.
+	Remove bool
 }
 
 // VersionModifier defines the interface fulfilled by BaseApp
@@ -0,0 +1,50 @@
+package gas
+
+import "context"
+
+// Schedule holds the gas costs of the stores of an app. The costs can be set per
+// store key and overridden per message type URL, so that the messages of the
+// expensive modules can be priced differently.
+type Schedule struct {
+	// Default is the config of the stores without a config of their own.
+	Default GasConfig `json:"default"`
+	// Stores are the configs of the stores, by store key.
+	Stores map[string]GasConfig `json:"stores,omitempty"`
+	// Messages are the overrides of the configs when executing a message, by
+	// message type URL.
+	Messages map[string]MessageSchedule `json:"messages,omitempty"`
+}
+
+// ScheduleProvider returns the gas schedule held by the state of the context, or
+// nil if the state holds none, the default gas costs applying then.
+type ScheduleProvider func(ctx context.Context) (*Schedule, error)
+
+// MessageSchedule overrides the gas costs of the stores of a Schedule when
+// executing a message.
+type MessageSchedule struct {
+	// Default, if set, overrides the configs of the stores without a config of
+	// their own for the message.
+	Default *GasConfig `json:"default,omitempty"`
+	// Stores are the configs of the stores for the message, by store key.
+	Stores map[string]GasConfig `json:"stores,omitempty"`
+}
+
+// Config returns the gas config of the store of the given key when executing a
+// message of the given type URL, which is empty outside of message execution.
+// The most specific config applies: the config of the store for the message,
+// else the default config for the message, else the config of the store, else
+// the default config.
+func (s Schedule) Config(msgTypeURL, storeKey string) GasConfig {
+	if msg, ok := s.Messages[msgTypeURL]; ok {
+		if config, ok := msg.Stores[storeKey]; ok {
+			return config
+		}
+		if msg.Default != nil {
+			return *msg.Default
+		}
+	}
+	if config, ok := s.Stores[storeKey]; ok {
+		return config
+	}
+	return s.Default
+}
@@ -0,0 +1,113 @@
+package sqlite
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"cosmossdk.io/schema"
+)
+
+// columnType returns the SQLite column type used to store values of the given kind.
+func columnType(kind schema.Kind) (string, error) {
+	switch kind {
+	case schema.StringKind, schema.IntegerKind, schema.DecimalKind, schema.EnumKind, schema.JSONKind,
+		schema.ObjectKind, schema.ListKind:
+		return "TEXT", nil
+	case schema.BytesKind, schema.Bech32AddressKind:
+		return "BLOB", nil
+	case schema.Int8Kind, schema.Uint8Kind, schema.Int16Kind, schema.Uint16Kind,
+		schema.Int32Kind, schema.Uint32Kind, schema.Int64Kind, schema.Uint64Kind,
+		schema.BoolKind, schema.TimeKind, schema.DurationKind:
+		return "INTEGER", nil
+	case schema.Float32Kind, schema.Float64Kind:
+		return "REAL", nil
+	default:
+		return "", fmt.Errorf("unsupported kind %s", kind)
+	}
+}
+
+// columnDefinition returns the column definition used in a CREATE TABLE statement for the field.
+func columnDefinition(field schema.Field) (string, error) {
+	typ, err := columnType(field.Kind)
+	if err != nil {
+		return "", fmt.Errorf("field %q: %w", field.Name, err)
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "%s %s", quoteIdentifier(field.Name), typ)
+	if !field.Nullable {
+		sb.WriteString(" NOT NULL")
+	}
+
+	if field.Kind == schema.EnumKind {
+		values := make([]string, len(field.EnumDefinition.Values))
+		for i, v := range field.EnumDefinition.Values {
+			// enum values conform to schema.NameFormat so they never need escaping
+			values[i] = fmt.Sprintf("'%s'", v)
+		}
+		fmt.Fprintf(&sb, " CHECK (%s IN (%s))", quoteIdentifier(field.Name), strings.Join(values, ", "))
+	}
+
+	return sb.String(), nil
+}
+
+// bindValue converts a value conforming to the field's kind into a value which can be bound
+// as a parameter of a SQLite statement.
+func bindValue(field schema.Field, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	switch field.Kind {
+	case schema.BoolKind:
+		if value.(bool) {
+			return int64(1), nil
+		}
+		return int64(0), nil
+	case schema.TimeKind:
+		return value.(time.Time).UnixNano(), nil
+	case schema.DurationKind:
+		return int64(value.(time.Duration)), nil
+	case schema.JSONKind:
+		return string(value.(json.RawMessage)), nil
+	case schema.ObjectKind, schema.ListKind:
+		// nested objects and lists are stored as JSON objects and arrays respectively
+		bz, err := json.Marshal(nestedJSONValue(field, value))
+		if err != nil {
+			return nil, fmt.Errorf("field %q: %w", field.Name, err)
+		}
+		return string(bz), nil
+	case schema.Float32Kind:
+		return float64(value.(float32)), nil
+	case schema.Uint64Kind:
+		// database/sql rejects uint64 values with the high bit set, so surface a descriptive error instead
+		v := value.(uint64)
+		if v > 1<<63-1 {
+			return nil, fmt.Errorf("field %q: uint64 value %d overflows SQLite INTEGER", field.Name, v)
+		}
+		return int64(v), nil
+	default:
+		return value, nil
+	}
+}
+
+// nestedJSONValue converts the value of an object field into a map keyed by the names of the
+// object fields so that it is encoded as a JSON object. Other values are encoded as is.
+func nestedJSONValue(field schema.Field, value interface{}) interface{} {
+	if field.Kind != schema.ObjectKind || value == nil {
+		return value
+	}
+
+	values := value.([]interface{})
+	res := make(map[string]interface{}, len(field.ObjectFields))
+	for i, objectField := range field.ObjectFields {
+		res[objectField.Name] = nestedJSONValue(objectField, values[i])
+	}
+	return res
+}
+
+func quoteIdentifier(name string) string {
+	return `"` + name + `"`
+}
@@ -0,0 +1,10 @@
+module cosmossdk.io/indexer/sqlite
+
+go 1.22
+
+require (
+	cosmossdk.io/schema v0.0.0-00010101000000-000000000000
+	github.com/mattn/go-sqlite3 v1.14.22
+)
+
+replace cosmossdk.io/schema => ../../schema
@@ -0,0 +1,192 @@
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	_ "github.com/mattn/go-sqlite3"
+
+	"cosmossdk.io/schema"
+	"cosmossdk.io/schema/appdata"
+)
+
+const (
+	// DriverName is the database/sql driver name which the indexer expects to be used to open the database.
+	DriverName = "sqlite3"
+
+	metaTableName = "_indexer_meta"
+
+	createMetaTableStmt = `
+	CREATE TABLE IF NOT EXISTS _indexer_meta (
+		id INTEGER NOT NULL PRIMARY KEY CHECK (id = 1),
+		last_block INTEGER NOT NULL
+	);
+	`
+	selectLastBlockStmt = `SELECT last_block FROM _indexer_meta WHERE id = 1;`
+	upsertLastBlockStmt = `
+	INSERT INTO _indexer_meta (id, last_block) VALUES (1, ?)
+	ON CONFLICT (id) DO UPDATE SET last_block = excluded.last_block;
+	`
+)
+
+// execer is the subset of *sql.DB and *sql.Tx used to apply updates.
+type execer interface {
+	Exec(query string, args ...any) (sql.Result, error)
+}
+
+// Options are the options for creating an Indexer.
+type Options struct {
+	// DisableObjectValidation disables validation of object updates against the module schema
+	// before they are written. Validation is enabled by default.
+	DisableObjectValidation bool
+}
+
+// Indexer is an appdata listener which indexes logical state updates into a SQLite database.
+// Each object type of every initialized module is stored in a table named <module>_<object_type>
+// and all updates that are part of a block are committed in a single database transaction.
+type Indexer struct {
+	db      *sql.DB
+	options Options
+
+	tx      *sql.Tx
+	height  uint64
+	modules map[string]*moduleIndexer
+}
+
+// NewIndexer returns a new Indexer writing to the provided database, which should have
+// been opened with the sqlite3 driver.
+func NewIndexer(db *sql.DB, options Options) (*Indexer, error) {
+	if db == nil {
+		return nil, errors.New("database is required")
+	}
+
+	return &Indexer{
+		db:      db,
+		options: options,
+		modules: map[string]*moduleIndexer{},
+	}, nil
+}
+
+// Listener returns the appdata.Listener for the indexer.
+func (i *Indexer) Listener() appdata.Listener {
+	return appdata.Listener{
+		Initialize:             i.initialize,
+		StartBlock:             i.startBlock,
+		InitializeModuleSchema: i.initializeModuleSchema,
+		OnObjectUpdate:         i.onObjectUpdate,
+		Commit:                 i.commit,
+	}
+}
+
+// Close rolls back any uncommitted block.
+func (i *Indexer) Close() error {
+	if i.tx == nil {
+		return nil
+	}
+
+	err := i.tx.Rollback()
+	i.tx = nil
+	return err
+}
+
+func (i *Indexer) initialize(appdata.InitializationData) (int64, error) {
+	if _, err := i.db.Exec(createMetaTableStmt); err != nil {
+		return 0, fmt.Errorf("failed to create %s table: %w", metaTableName, err)
+	}
+
+	var lastBlock int64
+	err := i.db.QueryRow(selectLastBlockStmt).Scan(&lastBlock)
+	if errors.Is(err, sql.ErrNoRows) {
+		return -1, nil
+	} else if err != nil {
+		return 0, fmt.Errorf("failed to query last block: %w", err)
+	}
+
+	return lastBlock, nil
+}
+
+func (i *Indexer) startBlock(height uint64) error {
+	i.height = height
+	_, err := i.begin()
+	return err
+}
+
+func (i *Indexer) initializeModuleSchema(moduleName string, moduleSchema schema.ModuleSchema) error {
+	if _, ok := i.modules[moduleName]; ok {
+		return fmt.Errorf("module %q already initialized", moduleName)
+	}
+
+	mi, err := newModuleIndexer(moduleName, moduleSchema)
+	if err != nil {
+		return err
+	}
+
+	// tables are created as part of the current block if there is one, otherwise immediately
+	var db execer = i.db
+	if i.tx != nil {
+		db = i.tx
+	}
+
+	if err := mi.createTables(db); err != nil {
+		return err
+	}
+
+	i.modules[moduleName] = mi
+	return nil
+}
+
+func (i *Indexer) onObjectUpdate(moduleName string, update schema.ObjectUpdate) error {
+	mi, ok := i.modules[moduleName]
+	if !ok {
+		return fmt.Errorf("module %q not initialized", moduleName)
+	}
+
+	if !i.options.DisableObjectValidation {
+		if err := mi.schema.ValidateObjectUpdate(update); err != nil {
+			return err
+		}
+	}
+
+	tx, err := i.begin()
+	if err != nil {
+		return err
+	}
+
+	return mi.update(tx, update)
+}
+
+func (i *Indexer) commit() error {
+	tx, err := i.begin()
+	if err != nil {
+		return err
+	}
+
+	if i.height != 0 {
+		if _, err := tx.Exec(upsertLastBlockStmt, int64(i.height)); err != nil {
+			return fmt.Errorf("failed to update last block: %w", err)
+		}
+	}
+
+	i.tx = nil
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit SQL transaction: %w", err)
+	}
+
+	return nil
+}
+
+// begin returns the transaction for the current block, starting one if needed.
+func (i *Indexer) begin() (*sql.Tx, error) {
+	if i.tx != nil {
+		return i.tx, nil
+	}
+
+	tx, err := i.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SQL transaction: %w", err)
+	}
+
+	i.tx = tx
+	return tx, nil
+}
@@ -0,0 +1,230 @@
+package sqlite
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"cosmossdk.io/schema"
+	"cosmossdk.io/schema/appdata"
+)
+
+var testModuleSchema = schema.ModuleSchema{
+	ObjectTypes: []schema.ObjectType{
+		{
+			Name: "balances",
+			KeyFields: []schema.Field{
+				{Name: "address", Kind: schema.BytesKind},
+				{Name: "denom", Kind: schema.StringKind},
+			},
+			ValueFields: []schema.Field{
+				{Name: "amount", Kind: schema.IntegerKind},
+			},
+		},
+		{
+			Name: "params",
+			ValueFields: []schema.Field{
+				{Name: "enabled", Kind: schema.BoolKind},
+				{Name: "updated", Kind: schema.TimeKind, Nullable: true},
+			},
+		},
+		{
+			Name: "proposals",
+			KeyFields: []schema.Field{
+				{Name: "id", Kind: schema.Uint64Kind},
+			},
+			ValueFields: []schema.Field{
+				{
+					Name:           "status",
+					Kind:           schema.EnumKind,
+					EnumDefinition: schema.EnumDefinition{Name: "status", Values: []string{"open", "passed", "rejected"}},
+				},
+				{Name: "title", Kind: schema.StringKind},
+			},
+			RetainDeletions: true,
+		},
+	},
+}
+
+func openTestDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := sql.Open(DriverName, filepath.Join(t.TempDir(), "index.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+func newTestListener(t *testing.T, db *sql.DB) (appdata.Listener, int64) {
+	t.Helper()
+	indexer, err := NewIndexer(db, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener := indexer.Listener()
+	lastBlock, err := listener.Initialize(appdata.InitializationData{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := listener.InitializeModuleSchema("bank", testModuleSchema); err != nil {
+		t.Fatal(err)
+	}
+	return listener, lastBlock
+}
+
+func TestIndexer(t *testing.T) {
+	db := openTestDB(t)
+	listener, lastBlock := newTestListener(t, db)
+	if lastBlock != -1 {
+		t.Fatalf("expected last block -1, got %d", lastBlock)
+	}
+
+	mustUpdate := func(update schema.ObjectUpdate) {
+		t.Helper()
+		if err := listener.OnObjectUpdate("bank", update); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := listener.StartBlock(1); err != nil {
+		t.Fatal(err)
+	}
+	mustUpdate(schema.ObjectUpdate{TypeName: "balances", Key: []interface{}{[]byte("addr1"), "atom"}, Value: "100"})
+	mustUpdate(schema.ObjectUpdate{TypeName: "balances", Key: []interface{}{[]byte("addr2"), "atom"}, Value: "5"})
+	mustUpdate(schema.ObjectUpdate{TypeName: "params", Value: []interface{}{true, time.Unix(10, 0)}})
+	mustUpdate(schema.ObjectUpdate{TypeName: "proposals", Key: uint64(1), Value: []interface{}{"open", "first"}})
+
+	// uncommitted data must not be visible outside of the block transaction
+	assertCount(t, db, `SELECT COUNT(*) FROM "bank_balances"`, 0)
+
+	if err := listener.Commit(); err != nil {
+		t.Fatal(err)
+	}
+	assertCount(t, db, `SELECT COUNT(*) FROM "bank_balances"`, 2)
+
+	if err := listener.StartBlock(2); err != nil {
+		t.Fatal(err)
+	}
+	mustUpdate(schema.ObjectUpdate{TypeName: "balances", Key: []interface{}{[]byte("addr1"), "atom"}, Value: "150"})
+	mustUpdate(schema.ObjectUpdate{TypeName: "balances", Key: []interface{}{[]byte("addr2"), "atom"}, Delete: true})
+	mustUpdate(schema.ObjectUpdate{TypeName: "params", Value: schema.MapValueUpdates{"enabled": false}})
+	mustUpdate(schema.ObjectUpdate{TypeName: "proposals", Key: uint64(1), Delete: true})
+	if err := listener.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	var amount string
+	if err := db.QueryRow(`SELECT amount FROM "bank_balances" WHERE address = ? AND denom = ?`, []byte("addr1"), "atom").Scan(&amount); err != nil {
+		t.Fatal(err)
+	}
+	if amount != "150" {
+		t.Fatalf("expected amount 150, got %s", amount)
+	}
+	assertCount(t, db, `SELECT COUNT(*) FROM "bank_balances"`, 1)
+
+	var enabled, updated int64
+	if err := db.QueryRow(`SELECT enabled, updated FROM "bank_params"`).Scan(&enabled, &updated); err != nil {
+		t.Fatal(err)
+	}
+	if enabled != 0 || updated != time.Unix(10, 0).UnixNano() {
+		t.Fatalf("unexpected params row: enabled=%d updated=%d", enabled, updated)
+	}
+
+	// deletions are retained for proposals
+	assertCount(t, db, `SELECT COUNT(*) FROM "bank_proposals" WHERE _deleted = 1`, 1)
+
+	// a restarted indexer reports the last committed block
+	_, lastBlock = newTestListener(t, db)
+	if lastBlock != 2 {
+		t.Fatalf("expected last block 2, got %d", lastBlock)
+	}
+}
+
+func TestIndexerRejectsInvalidUpdates(t *testing.T) {
+	listener, _ := newTestListener(t, openTestDB(t))
+	if err := listener.StartBlock(1); err != nil {
+		t.Fatal(err)
+	}
+
+	err := listener.OnObjectUpdate("bank", schema.ObjectUpdate{TypeName: "proposals", Key: uint64(1), Value: []interface{}{"unknown", "title"}})
+	if err == nil {
+		t.Fatal("expected error for invalid enum value")
+	}
+
+	err = listener.OnObjectUpdate("staking", schema.ObjectUpdate{TypeName: "validators", Key: "val"})
+	if err == nil {
+		t.Fatal("expected error for uninitialized module")
+	}
+}
+
+func assertCount(t *testing.T, db *sql.DB, query string, expected int) {
+	t.Helper()
+	var count int
+	if err := db.QueryRow(query).Scan(&count); err != nil {
+		t.Fatal(err)
+	}
+	if count != expected {
+		t.Fatalf("expected %d rows for %q, got %d", expected, query, count)
+	}
+}
+
+func TestIndexerNestedValues(t *testing.T) {
+	db := openTestDB(t)
+	indexer, err := NewIndexer(db, Options{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	listener := indexer.Listener()
+	if _, err := listener.Initialize(appdata.InitializationData{}); err != nil {
+		t.Fatal(err)
+	}
+	err = listener.InitializeModuleSchema("gov", schema.ModuleSchema{ObjectTypes: []schema.ObjectType{{
+		Name:      "proposals",
+		KeyFields: []schema.Field{{Name: "id", Kind: schema.Uint64Kind}},
+		ValueFields: []schema.Field{
+			{
+				Name: "metadata",
+				Kind: schema.ObjectKind,
+				ObjectFields: []schema.Field{
+					{Name: "title", Kind: schema.StringKind},
+					{Name: "deposit", Kind: schema.ObjectKind, Nullable: true, ObjectFields: []schema.Field{{Name: "amount", Kind: schema.IntegerKind}}},
+				},
+			},
+			{Name: "messages", Kind: schema.ListKind, ElementKind: schema.StringKind},
+		},
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := listener.StartBlock(1); err != nil {
+		t.Fatal(err)
+	}
+	err = listener.OnObjectUpdate("gov", schema.ObjectUpdate{
+		TypeName: "proposals",
+		Key:      uint64(1),
+		Value: []interface{}{
+			[]interface{}{"first", []interface{}{"10"}},
+			[]interface{}{"/cosmos.bank.v1beta1.MsgSend"},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := listener.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	var metadata, messages string
+	if err := db.QueryRow(`SELECT metadata, messages FROM "gov_proposals" WHERE id = 1`).Scan(&metadata, &messages); err != nil {
+		t.Fatal(err)
+	}
+	if metadata != `{"deposit":{"amount":"10"},"title":"first"}` {
+		t.Fatalf("unexpected metadata %s", metadata)
+	}
+	if messages != `["/cosmos.bank.v1beta1.MsgSend"]` {
+		t.Fatalf("unexpected messages %s", messages)
+	}
+}
@@ -0,0 +1,60 @@
+package sqlite
+
+import (
+	"fmt"
+
+	"cosmossdk.io/schema"
+)
+
+// moduleIndexer manages the tables for all the object types of a single module.
+type moduleIndexer struct {
+	moduleName string
+	schema     schema.ModuleSchema
+	tables     map[string]*tableIndexer
+}
+
+func newModuleIndexer(moduleName string, moduleSchema schema.ModuleSchema) (*moduleIndexer, error) {
+	if !schema.ValidateName(moduleName) {
+		return nil, fmt.Errorf("invalid module name %q", moduleName)
+	}
+
+	if err := moduleSchema.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid schema for module %q: %w", moduleName, err)
+	}
+
+	tables := make(map[string]*tableIndexer, len(moduleSchema.ObjectTypes))
+	for _, objectType := range moduleSchema.ObjectTypes {
+		tables[objectType.Name] = newTableIndexer(moduleName, objectType)
+	}
+
+	return &moduleIndexer{
+		moduleName: moduleName,
+		schema:     moduleSchema,
+		tables:     tables,
+	}, nil
+}
+
+// createTables creates the tables for all the object types of the module if they don't exist.
+func (m *moduleIndexer) createTables(db execer) error {
+	for _, objectType := range m.schema.ObjectTypes {
+		stmt, err := m.tables[objectType.Name].createTableSQL()
+		if err != nil {
+			return fmt.Errorf("module %q: %w", m.moduleName, err)
+		}
+
+		if _, err := db.Exec(stmt); err != nil {
+			return fmt.Errorf("failed to create table for %s.%s: %w", m.moduleName, objectType.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (m *moduleIndexer) update(db execer, update schema.ObjectUpdate) error {
+	table, ok := m.tables[update.TypeName]
+	if !ok {
+		return fmt.Errorf("object type %q not found in module %q", update.TypeName, m.moduleName)
+	}
+
+	return table.update(db, update)
+}
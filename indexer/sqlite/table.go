@@ -0,0 +1,246 @@
+package sqlite
+
+import (
+	"fmt"
+	"strings"
+
+	"cosmossdk.io/schema"
+)
+
+const (
+	// singletonColumn is the primary key column used for object types without key fields.
+	singletonColumn = "_id"
+
+	// deletedColumn is the column used to flag deleted rows for object types with RetainDeletions set.
+	deletedColumn = "_deleted"
+)
+
+// tableIndexer manages the table storing objects of a single object type.
+type tableIndexer struct {
+	tableName  string
+	objectType schema.ObjectType
+}
+
+func newTableIndexer(moduleName string, objectType schema.ObjectType) *tableIndexer {
+	return &tableIndexer{
+		tableName:  fmt.Sprintf("%s_%s", moduleName, objectType.Name),
+		objectType: objectType,
+	}
+}
+
+// createTableSQL returns the CREATE TABLE statement for the object type.
+func (t *tableIndexer) createTableSQL() (string, error) {
+	var defs []string
+	if len(t.objectType.KeyFields) == 0 {
+		defs = append(defs, fmt.Sprintf("%s INTEGER NOT NULL CHECK (%s = 1)",
+			quoteIdentifier(singletonColumn), quoteIdentifier(singletonColumn)))
+	}
+
+	for _, field := range t.objectType.KeyFields {
+		def, err := columnDefinition(field)
+		if err != nil {
+			return "", err
+		}
+		defs = append(defs, def)
+	}
+
+	for _, field := range t.objectType.ValueFields {
+		def, err := columnDefinition(field)
+		if err != nil {
+			return "", err
+		}
+		defs = append(defs, def)
+	}
+
+	if t.objectType.RetainDeletions {
+		defs = append(defs, fmt.Sprintf("%s INTEGER NOT NULL DEFAULT 0", quoteIdentifier(deletedColumn)))
+	}
+
+	defs = append(defs, fmt.Sprintf("PRIMARY KEY (%s)", strings.Join(t.keyColumns(), ", ")))
+
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (\n\t%s\n);",
+		quoteIdentifier(t.tableName), strings.Join(defs, ",\n\t")), nil
+}
+
+// keyColumns returns the quoted names of the primary key columns.
+func (t *tableIndexer) keyColumns() []string {
+	if len(t.objectType.KeyFields) == 0 {
+		return []string{quoteIdentifier(singletonColumn)}
+	}
+
+	cols := make([]string, len(t.objectType.KeyFields))
+	for i, field := range t.objectType.KeyFields {
+		cols[i] = quoteIdentifier(field.Name)
+	}
+	return cols
+}
+
+// keyParams returns the bound parameters for the primary key columns of an object key.
+func (t *tableIndexer) keyParams(key interface{}) ([]interface{}, error) {
+	fields := t.objectType.KeyFields
+	switch len(fields) {
+	case 0:
+		return []interface{}{int64(1)}, nil
+	case 1:
+		v, err := bindValue(fields[0], key)
+		if err != nil {
+			return nil, err
+		}
+		return []interface{}{v}, nil
+	default:
+		values, ok := key.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("expected slice of values for key fields, got %T", key)
+		}
+		if len(values) != len(fields) {
+			return nil, fmt.Errorf("expected %d key fields, got %d values", len(fields), len(values))
+		}
+		params := make([]interface{}, len(fields))
+		for i, field := range fields {
+			v, err := bindValue(field, values[i])
+			if err != nil {
+				return nil, err
+			}
+			params[i] = v
+		}
+		return params, nil
+	}
+}
+
+// valueParams returns the columns and bound parameters for the value fields present in an object value.
+// If the value is a schema.ValueUpdates, only the fields it contains are returned.
+func (t *tableIndexer) valueParams(value interface{}) (cols []string, params []interface{}, err error) {
+	fields := t.objectType.ValueFields
+
+	if updates, ok := value.(schema.ValueUpdates); ok {
+		byName := make(map[string]schema.Field, len(fields))
+		for _, field := range fields {
+			byName[field.Name] = field
+		}
+
+		var bindErr error
+		err = updates.Iterate(func(name string, v interface{}) bool {
+			field, ok := byName[name]
+			if !ok {
+				bindErr = fmt.Errorf("unknown value field %q for object type %q", name, t.objectType.Name)
+				return false
+			}
+			var p interface{}
+			p, bindErr = bindValue(field, v)
+			if bindErr != nil {
+				return false
+			}
+			cols = append(cols, quoteIdentifier(name))
+			params = append(params, p)
+			return true
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+		return cols, params, bindErr
+	}
+
+	switch len(fields) {
+	case 0:
+		return nil, nil, nil
+	case 1:
+		p, err := bindValue(fields[0], value)
+		if err != nil {
+			return nil, nil, err
+		}
+		return []string{quoteIdentifier(fields[0].Name)}, []interface{}{p}, nil
+	default:
+		values, ok := value.([]interface{})
+		if !ok {
+			return nil, nil, fmt.Errorf("expected slice of values for value fields, got %T", value)
+		}
+		if len(values) != len(fields) {
+			return nil, nil, fmt.Errorf("expected %d value fields, got %d values", len(fields), len(values))
+		}
+		for i, field := range fields {
+			p, err := bindValue(field, values[i])
+			if err != nil {
+				return nil, nil, err
+			}
+			cols = append(cols, quoteIdentifier(field.Name))
+			params = append(params, p)
+		}
+		return cols, params, nil
+	}
+}
+
+// update applies an object update to the table.
+func (t *tableIndexer) update(db execer, update schema.ObjectUpdate) error {
+	keyParams, err := t.keyParams(update.Key)
+	if err != nil {
+		return err
+	}
+
+	if update.Delete {
+		return t.delete(db, keyParams)
+	}
+
+	cols, valueParams, err := t.valueParams(update.Value)
+	if err != nil {
+		return err
+	}
+
+	if t.objectType.RetainDeletions {
+		cols = append(cols, quoteIdentifier(deletedColumn))
+		valueParams = append(valueParams, int64(0))
+	}
+
+	// try updating an existing row first so that partial value updates leave other columns untouched
+	if len(cols) > 0 {
+		sets := make([]string, len(cols))
+		for i, col := range cols {
+			sets[i] = col + " = ?"
+		}
+		stmt := fmt.Sprintf("UPDATE %s SET %s WHERE %s;",
+			quoteIdentifier(t.tableName), strings.Join(sets, ", "), t.whereKey())
+		res, err := db.Exec(stmt, append(valueParams, keyParams...)...)
+		if err != nil {
+			return fmt.Errorf("failed to update %s: %w", t.tableName, err)
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if n > 0 {
+			return nil
+		}
+	}
+
+	insertCols := append(t.keyColumns(), cols...)
+	placeholders := strings.TrimSuffix(strings.Repeat("?, ", len(insertCols)), ", ")
+	stmt := fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s) ON CONFLICT DO NOTHING;",
+		quoteIdentifier(t.tableName), strings.Join(insertCols, ", "), placeholders)
+	if _, err := db.Exec(stmt, append(keyParams, valueParams...)...); err != nil {
+		return fmt.Errorf("failed to insert into %s: %w", t.tableName, err)
+	}
+	return nil
+}
+
+func (t *tableIndexer) delete(db execer, keyParams []interface{}) error {
+	var stmt string
+	if t.objectType.RetainDeletions {
+		stmt = fmt.Sprintf("UPDATE %s SET %s = 1 WHERE %s;",
+			quoteIdentifier(t.tableName), quoteIdentifier(deletedColumn), t.whereKey())
+	} else {
+		stmt = fmt.Sprintf("DELETE FROM %s WHERE %s;", quoteIdentifier(t.tableName), t.whereKey())
+	}
+
+	if _, err := db.Exec(stmt, keyParams...); err != nil {
+		return fmt.Errorf("failed to delete from %s: %w", t.tableName, err)
+	}
+	return nil
+}
+
+func (t *tableIndexer) whereKey() string {
+	cols := t.keyColumns()
+	conds := make([]string, len(cols))
+	for i, col := range cols {
+		conds[i] = col + " = ?"
+	}
+	return strings.Join(conds, " AND ")
+}
@@ -8,6 +8,7 @@ import (
 
 	"cosmossdk.io/core/appmodule"
 	appmodulev2 "cosmossdk.io/core/appmodule/v2"
+	"cosmossdk.io/core/gas"
 	"cosmossdk.io/core/store"
 	"cosmossdk.io/core/transaction"
 	"cosmossdk.io/server/v2/appmanager"
@@ -27,6 +28,16 @@ type AppBuilder[T transaction.Tx] struct {
 	branch      func(state store.ReaderMap) store.WriterMap
 	txValidator func(ctx context.Context, tx T) error
 	postTxExec  func(ctx context.Context, tx T, success bool) error
+
+	// gasScheduleProvider returns the gas schedule the stores are metered with,
+	// if set.
+	gasScheduleProvider gas.ScheduleProvider
+	// parallelWorkers is the number of txs of a block executed concurrently, if
+	// above 1.
+	parallelWorkers int
+	// traceAccess enables the tracing of the keys read and written by the txs,
+	// traceValues the tracing of their values.
+	traceAccess, traceValues bool
 }
 
 // DefaultGenesis returns a default genesis from the registered AppModule's.
@@ -113,6 +124,11 @@ func (a *AppBuilder[T]) Build(opts ...AppBuilderOption[T]) (*App[T], error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create STF: %w", err)
 	}
+	if a.gasScheduleProvider != nil {
+		stf.SetGasScheduleProvider(a.gasScheduleProvider)
+	}
+	stf.SetParallelExecution(a.parallelWorkers)
+	stf.SetAccessTracing(a.traceAccess, a.traceValues)
 
 	a.app.stf = stf
 
@@ -185,3 +201,32 @@ func AppBuilderWithPostTxExec[T transaction.Tx](
 		a.postTxExec = postTxExec
 	}
 }
+
+// AppBuilderWithGasScheduleProvider sets the provider of the gas schedule the
+// stores are metered with. It overrides the provider of x/consensus, which
+// reads the gas schedule kept next to the consensus params.
+func AppBuilderWithGasScheduleProvider[T transaction.Tx](provider gas.ScheduleProvider) AppBuilderOption[T] {
+	return func(a *AppBuilder[T]) {
+		a.gasScheduleProvider = provider
+	}
+}
+
+// AppBuilderWithParallelExecution sets the number of txs of a block executed
+// concurrently by the STF, the results being the same as the ones of their
+// sequential execution. The txs are executed sequentially if workers is 1 or
+// less, which is the default.
+func AppBuilderWithParallelExecution[T transaction.Tx](workers int) AppBuilderOption[T] {
+	return func(a *AppBuilder[T]) {
+		a.parallelWorkers = workers
+	}
+}
+
+// AppBuilderWithAccessTracing enables or disables the tracing of the keys read
+// and written by each tx of a block, exposed on the Trace of its result, and of
+// their values if traceValues is true. Tracing is disabled by default.
+func AppBuilderWithAccessTracing[T transaction.Tx](enabled, traceValues bool) AppBuilderOption[T] {
+	return func(a *AppBuilder[T]) {
+		a.traceAccess = enabled
+		a.traceValues = traceValues
+	}
+}
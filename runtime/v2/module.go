@@ -18,6 +18,7 @@ import (
 	"cosmossdk.io/core/appmodule"
 	appmodulev2 "cosmossdk.io/core/appmodule/v2"
 	"cosmossdk.io/core/comet"
+	"cosmossdk.io/core/gas"
 	"cosmossdk.io/core/genesis"
 	"cosmossdk.io/core/legacy"
 	"cosmossdk.io/core/log"
@@ -152,6 +153,9 @@ type AppInputs struct {
 	LegacyAmino        legacy.Amino
 	Logger             log.Logger
 	StoreOptions       *rootstorev2.FactoryOptions `optional:"true"`
+	// GasScheduleProvider is provided by x/consensus, reading the gas schedule
+	// kept next to the consensus params.
+	GasScheduleProvider gas.ScheduleProvider `optional:"true"`
 }
 
 func SetupAppBuilder(inputs AppInputs) {
@@ -162,6 +166,7 @@ func SetupAppBuilder(inputs AppInputs) {
 	app.moduleManager = inputs.ModuleManager
 	app.moduleManager.RegisterInterfaces(inputs.InterfaceRegistrar)
 	app.moduleManager.RegisterLegacyAminoCodec(inputs.LegacyAmino)
+	inputs.AppBuilder.gasScheduleProvider = inputs.GasScheduleProvider
 
 	if inputs.StoreOptions != nil {
 		inputs.AppBuilder.storeOptions = inputs.StoreOptions
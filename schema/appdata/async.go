@@ -0,0 +1,329 @@
+package appdata
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"cosmossdk.io/schema"
+)
+
+// BufferPolicy defines what an async listener does when the buffer of one of
+// its listeners is full.
+type BufferPolicy int
+
+const (
+	// BlockWhenFull blocks the caller until the listener has processed enough
+	// callbacks to free some space in its buffer.
+	BlockWhenFull BufferPolicy = iota
+
+	// DropWhenFull drops the callback for the listener whose buffer is full and
+	// reports it with AsyncListenerOptions.OnDrop. Barrier callbacks (Initialize,
+	// InitializeModuleSchema and Commit) are never dropped.
+	DropWhenFull
+)
+
+// AsyncListenerOptions are options for AsyncListenerMux.
+type AsyncListenerOptions struct {
+	// BufferSize is the number of callbacks which can be buffered for each listener
+	// before the BufferPolicy applies. If it is zero, callbacks are unbuffered.
+	BufferSize int
+
+	// BufferPolicy is the policy applied when the buffer of a listener is full.
+	BufferPolicy BufferPolicy
+
+	// OnDrop is called with the index of the listener when a callback is dropped
+	// because of the DropWhenFull policy. It is called synchronously by the caller
+	// of the callback, so it should not block.
+	OnDrop func(listenerIndex int)
+
+	// DoneChan, when closed, stops the goroutines of the listeners. Any callback
+	// called after the channel is closed is discarded and barriers return ErrListenerDone.
+	// If it is nil, the goroutines run for the lifetime of the process.
+	DoneChan <-chan struct{}
+}
+
+// ErrListenerDone is returned by barrier callbacks of an async listener which
+// has been stopped by closing AsyncListenerOptions.DoneChan.
+var ErrListenerDone = errors.New("async listener is done")
+
+// AsyncListenerMux returns a listener that forwards its callbacks to each of the
+// provided listeners on their own goroutine, in the order in which they were called.
+// Each listener has a bounded buffer of pending callbacks, see AsyncListenerOptions.
+//
+// Initialize, InitializeModuleSchema and Commit are barriers: they wait for every
+// listener to process all the callbacks buffered before them and return the errors
+// of the listeners. Errors returned by other callbacks are reported by the next
+// barrier, and a listener which returned an error does not receive any further
+// callbacks.
+//
+// Initialize returns the lowest non-zero lastBlockPersisted of the listeners, so that
+// the source starts from the first block that any listener is missing.
+func AsyncListenerMux(opts AsyncListenerOptions, listeners ...Listener) Listener {
+	asyncListeners := make([]*asyncListener, len(listeners))
+	for i, listener := range listeners {
+		asyncListeners[i] = &asyncListener{
+			index:    i,
+			listener: listener,
+			packets:  make(chan packet, opts.BufferSize),
+			opts:     opts,
+		}
+		go asyncListeners[i].run()
+	}
+
+	mux := asyncMux{listeners: asyncListeners}
+	res := Listener{}
+
+	for _, l := range listeners {
+		if l.Initialize != nil {
+			res.Initialize = mux.initialize
+			break
+		}
+	}
+
+	for _, l := range listeners {
+		if l.InitializeModuleSchema != nil {
+			res.InitializeModuleSchema = func(moduleName string, moduleSchema schema.ModuleSchema) error {
+				return mux.barrier(func(l Listener) error {
+					if l.InitializeModuleSchema == nil {
+						return nil
+					}
+					return l.InitializeModuleSchema(moduleName, moduleSchema)
+				})
+			}
+			break
+		}
+	}
+
+	for _, l := range listeners {
+		if l.StartBlock != nil {
+			res.StartBlock = func(height uint64) error {
+				mux.send(func(l Listener) error {
+					if l.StartBlock == nil {
+						return nil
+					}
+					return l.StartBlock(height)
+				})
+				return nil
+			}
+			break
+		}
+	}
+
+	for _, l := range listeners {
+		if l.OnBlockHeader != nil {
+			res.OnBlockHeader = func(data BlockHeaderData) error {
+				mux.send(func(l Listener) error {
+					if l.OnBlockHeader == nil {
+						return nil
+					}
+					return l.OnBlockHeader(data)
+				})
+				return nil
+			}
+			break
+		}
+	}
+
+	for _, l := range listeners {
+		if l.OnTx != nil {
+			res.OnTx = func(data TxData) error {
+				mux.send(func(l Listener) error {
+					if l.OnTx == nil {
+						return nil
+					}
+					return l.OnTx(data)
+				})
+				return nil
+			}
+			break
+		}
+	}
+
+	for _, l := range listeners {
+		if l.OnEvent != nil {
+			res.OnEvent = func(data EventData) error {
+				mux.send(func(l Listener) error {
+					if l.OnEvent == nil {
+						return nil
+					}
+					return l.OnEvent(data)
+				})
+				return nil
+			}
+			break
+		}
+	}
+
+	for _, l := range listeners {
+		if l.OnKVPair != nil {
+			res.OnKVPair = func(moduleName string, key, value []byte, delete bool) error {
+				mux.send(func(l Listener) error {
+					if l.OnKVPair == nil {
+						return nil
+					}
+					return l.OnKVPair(moduleName, key, value, delete)
+				})
+				return nil
+			}
+			break
+		}
+	}
+
+	for _, l := range listeners {
+		if l.OnObjectUpdate != nil {
+			res.OnObjectUpdate = func(moduleName string, update schema.ObjectUpdate) error {
+				mux.send(func(l Listener) error {
+					if l.OnObjectUpdate == nil {
+						return nil
+					}
+					return l.OnObjectUpdate(moduleName, update)
+				})
+				return nil
+			}
+			break
+		}
+	}
+
+	// Commit is always set so that errors of the other callbacks are reported
+	res.Commit = func() error {
+		return mux.barrier(func(l Listener) error {
+			if l.Commit == nil {
+				return nil
+			}
+			return l.Commit()
+		})
+	}
+
+	return res
+}
+
+// packet is a callback queued for a listener.
+type packet struct {
+	apply func(Listener) error
+	// done is non-nil for barriers, the result of the callback is sent to it.
+	done chan error
+}
+
+type asyncMux struct {
+	listeners []*asyncListener
+}
+
+func (m asyncMux) send(apply func(Listener) error) {
+	for _, l := range m.listeners {
+		l.send(packet{apply: apply})
+	}
+}
+
+func (m asyncMux) barrier(apply func(Listener) error) error {
+	dones := make([]chan error, len(m.listeners))
+	for i, l := range m.listeners {
+		dones[i] = make(chan error, 1)
+		l.send(packet{apply: apply, done: dones[i]})
+	}
+
+	var errs []error
+	for i, l := range m.listeners {
+		if err := l.wait(dones[i]); err != nil {
+			errs = append(errs, fmt.Errorf("listener %d: %w", i, err))
+		}
+	}
+
+	switch len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return fmt.Errorf("%d listeners failed, first error: %w", len(errs), errs[0])
+	}
+}
+
+func (m asyncMux) initialize(data InitializationData) (int64, error) {
+	var (
+		mu                 sync.Mutex
+		lastBlockPersisted int64
+	)
+	err := m.barrier(func(l Listener) error {
+		if l.Initialize == nil {
+			return nil
+		}
+
+		last, err := l.Initialize(data)
+		if err != nil {
+			return err
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if last != 0 && (lastBlockPersisted == 0 || last < lastBlockPersisted) {
+			lastBlockPersisted = last
+		}
+		return nil
+	})
+	return lastBlockPersisted, err
+}
+
+type asyncListener struct {
+	index    int
+	listener Listener
+	packets  chan packet
+	opts     AsyncListenerOptions
+}
+
+func (a *asyncListener) send(p packet) {
+	if p.done == nil && a.opts.BufferPolicy == DropWhenFull {
+		select {
+		case a.packets <- p:
+		case <-a.opts.DoneChan:
+		default:
+			if a.opts.OnDrop != nil {
+				a.opts.OnDrop(a.index)
+			}
+		}
+		return
+	}
+
+	select {
+	case a.packets <- p:
+	case <-a.opts.DoneChan:
+		if p.done != nil {
+			p.done <- ErrListenerDone
+		}
+	}
+}
+
+func (a *asyncListener) wait(done chan error) error {
+	select {
+	case err := <-done:
+		return err
+	case <-a.opts.DoneChan:
+		// the packet may have been processed right before the listener was stopped
+		select {
+		case err := <-done:
+			return err
+		default:
+			return ErrListenerDone
+		}
+	}
+}
+
+// run processes the packets of the listener in order until DoneChan is closed.
+// Once a callback returns an error, the following callbacks are skipped and the
+// error is returned to every following barrier.
+func (a *asyncListener) run() {
+	var err error
+	for {
+		select {
+		case p := <-a.packets:
+			if err == nil {
+				err = p.apply(a.listener)
+			}
+			if p.done != nil {
+				p.done <- err
+			}
+		case <-a.opts.DoneChan:
+			return
+		}
+	}
+}
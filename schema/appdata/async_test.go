@@ -0,0 +1,187 @@
+package appdata
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+
+	"cosmossdk.io/schema"
+)
+
+func TestAsyncListenerMux(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	var calls1, calls2 []string
+	listener1 := callCollector(3, &calls1)
+	listener2 := callCollector(0, &calls2)
+	listener2.Initialize = func(InitializationData) (int64, error) { return 5, nil }
+
+	mux := AsyncListenerMux(AsyncListenerOptions{BufferSize: 2, DoneChan: done}, listener1, listener2)
+	lastBlock, err := mux.Initialize(InitializationData{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lastBlock != 3 {
+		t.Fatalf("expected lowest non-zero last block persisted 3, got %d", lastBlock)
+	}
+
+	callAllCallbacksOnce(t, mux)
+
+	expected := []string{
+		"Initialize", "InitializeModuleSchema", "StartBlock", "OnBlockHeader", "OnTx",
+		"OnEvent", "OnKVPair", "OnObjectUpdate", "Commit",
+	}
+	if !reflect.DeepEqual(calls1, expected) {
+		t.Fatalf("expected %v, got %v", expected, calls1)
+	}
+	// listener2 overrides Initialize
+	if !reflect.DeepEqual(calls2, expected[1:]) {
+		t.Fatalf("expected %v, got %v", expected[1:], calls2)
+	}
+}
+
+func TestAsyncListenerMuxErrors(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	var calls []string
+	listener1 := callCollector(0, &calls)
+	listener2 := Listener{
+		OnKVPair: func(string, []byte, []byte, bool) error { return errors.New("kv error") },
+	}
+
+	mux := AsyncListenerMux(AsyncListenerOptions{DoneChan: done}, listener1, listener2)
+	if err := mux.OnKVPair("test", []byte{1}, []byte{2}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	err := mux.Commit()
+	if err == nil || err.Error() != "listener 1: kv error" {
+		t.Fatalf("expected error from listener 1, got %v", err)
+	}
+	// the error is sticky
+	if err := mux.Commit(); err == nil {
+		t.Fatal("expected error")
+	}
+	// the other listener is not affected
+	if !reflect.DeepEqual(calls, []string{"OnKVPair", "Commit", "Commit"}) {
+		t.Fatalf("unexpected calls %v", calls)
+	}
+}
+
+func TestAsyncListenerMuxDropWhenFull(t *testing.T) {
+	done := make(chan struct{})
+	defer close(done)
+
+	block := make(chan struct{})
+	var kvPairs int
+	listener := Listener{
+		StartBlock: func(uint64) error {
+			<-block
+			return nil
+		},
+		OnKVPair: func(string, []byte, []byte, bool) error {
+			kvPairs++
+			return nil
+		},
+	}
+
+	var (
+		mu      sync.Mutex
+		dropped int
+	)
+	mux := AsyncListenerMux(AsyncListenerOptions{
+		BufferSize:   1,
+		BufferPolicy: DropWhenFull,
+		OnDrop: func(listenerIndex int) {
+			mu.Lock()
+			defer mu.Unlock()
+			if listenerIndex != 0 {
+				t.Errorf("unexpected listener index %d", listenerIndex)
+			}
+			dropped++
+		},
+		DoneChan: done,
+	}, listener)
+
+	// StartBlock is picked up by the listener goroutine which then blocks, the first
+	// KV-pair fills the buffer and the following ones are dropped. Because the goroutine
+	// may not have picked up StartBlock yet, every KV-pair may be dropped.
+	if err := mux.StartBlock(1); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 5; i++ {
+		if err := mux.OnKVPair("test", []byte{byte(i)}, nil, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	close(block)
+	if err := mux.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if kvPairs+dropped != 5 || dropped < 4 {
+		t.Fatalf("expected at least 4 dropped KV-pairs out of 5, got %d processed and %d dropped", kvPairs, dropped)
+	}
+}
+
+func TestAsyncListenerMuxDone(t *testing.T) {
+	done := make(chan struct{})
+	mux := AsyncListenerMux(AsyncListenerOptions{DoneChan: done}, Listener{})
+	close(done)
+
+	if err := mux.Commit(); !errors.Is(err, ErrListenerDone) {
+		t.Fatalf("expected ErrListenerDone, got %v", err)
+	}
+}
+
+// callCollector returns a listener which records the names of its callbacks.
+// Initialize returns the provided last block persisted.
+func callCollector(lastBlockPersisted int64, calls *[]string) Listener {
+	record := func(name string) error {
+		*calls = append(*calls, name)
+		return nil
+	}
+	return Listener{
+		Initialize: func(InitializationData) (int64, error) {
+			*calls = append(*calls, "Initialize")
+			return lastBlockPersisted, nil
+		},
+		InitializeModuleSchema: func(string, schema.ModuleSchema) error { return record("InitializeModuleSchema") },
+		StartBlock:             func(uint64) error { return record("StartBlock") },
+		OnBlockHeader:          func(BlockHeaderData) error { return record("OnBlockHeader") },
+		OnTx:                   func(TxData) error { return record("OnTx") },
+		OnEvent:                func(EventData) error { return record("OnEvent") },
+		OnKVPair:               func(string, []byte, []byte, bool) error { return record("OnKVPair") },
+		OnObjectUpdate:         func(string, schema.ObjectUpdate) error { return record("OnObjectUpdate") },
+		Commit:                 func() error { return record("Commit") },
+	}
+}
+
+func callAllCallbacksOnce(t *testing.T, listener Listener) {
+	t.Helper()
+
+	calls := []struct {
+		name string
+		fn   func() error
+	}{
+		{"InitializeModuleSchema", func() error { return listener.InitializeModuleSchema("test", schema.ModuleSchema{}) }},
+		{"StartBlock", func() error { return listener.StartBlock(1) }},
+		{"OnBlockHeader", func() error { return listener.OnBlockHeader(BlockHeaderData{}) }},
+		{"OnTx", func() error { return listener.OnTx(TxData{}) }},
+		{"OnEvent", func() error { return listener.OnEvent(EventData{}) }},
+		{"OnKVPair", func() error { return listener.OnKVPair("test", nil, nil, false) }},
+		{"OnObjectUpdate", func() error { return listener.OnObjectUpdate("test", schema.ObjectUpdate{}) }},
+		{"Commit", listener.Commit},
+	}
+	for _, call := range calls {
+		if err := call.fn(); err != nil {
+			t.Fatal(fmt.Errorf("%s: %w", call.name, err))
+		}
+	}
+}
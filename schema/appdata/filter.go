@@ -0,0 +1,240 @@
+package appdata
+
+import (
+	"bytes"
+	"fmt"
+
+	"cosmossdk.io/schema"
+)
+
+// FilterModules returns a listener which only forwards the module callbacks
+// (InitializeModuleSchema, OnKVPair and OnObjectUpdate) of the modules for which
+// filter returns true. The other callbacks are forwarded unchanged.
+func FilterModules(listener Listener, filter func(moduleName string) bool) Listener {
+	if listener.InitializeModuleSchema != nil {
+		initializeModuleSchema := listener.InitializeModuleSchema
+		listener.InitializeModuleSchema = func(moduleName string, moduleSchema schema.ModuleSchema) error {
+			if !filter(moduleName) {
+				return nil
+			}
+			return initializeModuleSchema(moduleName, moduleSchema)
+		}
+	}
+
+	if listener.OnKVPair != nil {
+		onKVPair := listener.OnKVPair
+		listener.OnKVPair = func(moduleName string, key, value []byte, delete bool) error {
+			if !filter(moduleName) {
+				return nil
+			}
+			return onKVPair(moduleName, key, value, delete)
+		}
+	}
+
+	if listener.OnObjectUpdate != nil {
+		onObjectUpdate := listener.OnObjectUpdate
+		listener.OnObjectUpdate = func(moduleName string, update schema.ObjectUpdate) error {
+			if !filter(moduleName) {
+				return nil
+			}
+			return onObjectUpdate(moduleName, update)
+		}
+	}
+
+	return listener
+}
+
+// FilterObjectTypes returns a listener which only forwards the object updates of the
+// object types for which filter returns true. The object types which are filtered out
+// are also removed from the module schemas passed to InitializeModuleSchema.
+func FilterObjectTypes(listener Listener, filter func(moduleName, objectType string) bool) Listener {
+	if listener.InitializeModuleSchema != nil {
+		initializeModuleSchema := listener.InitializeModuleSchema
+		listener.InitializeModuleSchema = func(moduleName string, moduleSchema schema.ModuleSchema) error {
+			objectTypes := make([]schema.ObjectType, 0, len(moduleSchema.ObjectTypes))
+			for _, objectType := range moduleSchema.ObjectTypes {
+				if filter(moduleName, objectType.Name) {
+					objectTypes = append(objectTypes, objectType)
+				}
+			}
+			moduleSchema.ObjectTypes = objectTypes
+			return initializeModuleSchema(moduleName, moduleSchema)
+		}
+	}
+
+	if listener.OnObjectUpdate != nil {
+		onObjectUpdate := listener.OnObjectUpdate
+		listener.OnObjectUpdate = func(moduleName string, update schema.ObjectUpdate) error {
+			if !filter(moduleName, update.TypeName) {
+				return nil
+			}
+			return onObjectUpdate(moduleName, update)
+		}
+	}
+
+	return listener
+}
+
+// FilterKeyPrefixes returns a listener which only forwards the KV-pairs of the module
+// whose key starts with one of the prefixes. The KV-pairs of other modules are
+// forwarded unchanged, so that filters for several modules can be composed.
+func FilterKeyPrefixes(listener Listener, moduleName string, prefixes ...[]byte) Listener {
+	if listener.OnKVPair == nil {
+		return listener
+	}
+
+	onKVPair := listener.OnKVPair
+	listener.OnKVPair = func(module string, key, value []byte, delete bool) error {
+		if module != moduleName {
+			return onKVPair(module, key, value, delete)
+		}
+
+		for _, prefix := range prefixes {
+			if bytes.HasPrefix(key, prefix) {
+				return onKVPair(module, key, value, delete)
+			}
+		}
+		return nil
+	}
+
+	return listener
+}
+
+// ProjectValueFields returns a listener which only keeps the provided value fields
+// of an object type, both in the module schema passed to InitializeModuleSchema and
+// in the values of the object updates. Key fields are always kept because they identify
+// the object. Object updates of other object types are forwarded unchanged, so that
+// projections of several object types can be composed.
+//
+// The projection is configured when the module schema is initialized, so object updates
+// of the object type received before InitializeModuleSchema return an error, as do
+// fields which do not exist in the object type.
+func ProjectValueFields(listener Listener, moduleName, objectType string, fields ...string) Listener {
+	var projection *valueProjection
+
+	initializeModuleSchema := listener.InitializeModuleSchema
+	listener.InitializeModuleSchema = func(module string, moduleSchema schema.ModuleSchema) error {
+		if module == moduleName {
+			objectTypes := make([]schema.ObjectType, len(moduleSchema.ObjectTypes))
+			copy(objectTypes, moduleSchema.ObjectTypes)
+			for i, typ := range objectTypes {
+				if typ.Name != objectType {
+					continue
+				}
+
+				p, err := newValueProjection(typ.ValueFields, fields)
+				if err != nil {
+					return fmt.Errorf("can't project object type %q of module %q: %w", objectType, moduleName, err)
+				}
+				projection = p
+				objectTypes[i].ValueFields = p.fields
+			}
+			moduleSchema.ObjectTypes = objectTypes
+		}
+
+		if initializeModuleSchema == nil {
+			return nil
+		}
+		return initializeModuleSchema(module, moduleSchema)
+	}
+
+	if listener.OnObjectUpdate != nil {
+		onObjectUpdate := listener.OnObjectUpdate
+		listener.OnObjectUpdate = func(module string, update schema.ObjectUpdate) error {
+			if module != moduleName || update.TypeName != objectType || update.Delete {
+				return onObjectUpdate(module, update)
+			}
+
+			if projection == nil {
+				return fmt.Errorf("can't project object type %q of module %q before its schema is initialized", objectType, moduleName)
+			}
+
+			value, err := projection.project(update.Value)
+			if err != nil {
+				return err
+			}
+			update.Value = value
+			return onObjectUpdate(module, update)
+		}
+	}
+
+	return listener
+}
+
+// valueProjection keeps a subset of the value fields of an object type.
+type valueProjection struct {
+	// numFields is the number of value fields of the object type.
+	numFields int
+	// indexes are the indexes of the kept fields in the value fields of the object type.
+	indexes []int
+	// fields are the kept fields.
+	fields []schema.Field
+	// names are the names of the kept fields.
+	names map[string]bool
+}
+
+func newValueProjection(valueFields []schema.Field, fieldNames []string) (*valueProjection, error) {
+	p := &valueProjection{numFields: len(valueFields), names: make(map[string]bool, len(fieldNames))}
+	for _, name := range fieldNames {
+		p.names[name] = true
+	}
+
+	for i, field := range valueFields {
+		if p.names[field.Name] {
+			p.indexes = append(p.indexes, i)
+			p.fields = append(p.fields, field)
+		}
+	}
+
+	if len(p.fields) != len(p.names) {
+		return nil, fmt.Errorf("some of the value fields %v do not exist", fieldNames)
+	}
+
+	return p, nil
+}
+
+func (p *valueProjection) project(value interface{}) (interface{}, error) {
+	if valueUpdates, ok := value.(schema.ValueUpdates); ok {
+		return projectedValueUpdates{ValueUpdates: valueUpdates, names: p.names}, nil
+	}
+
+	var values []interface{}
+	if p.numFields == 1 {
+		values = []interface{}{value}
+	} else {
+		var ok bool
+		values, ok = value.([]interface{})
+		if !ok || len(values) != p.numFields {
+			return nil, fmt.Errorf("expected a slice of %d values, got %T", p.numFields, value)
+		}
+	}
+
+	switch len(p.indexes) {
+	case 0:
+		return nil, nil
+	case 1:
+		return values[p.indexes[0]], nil
+	default:
+		res := make([]interface{}, len(p.indexes))
+		for i, idx := range p.indexes {
+			res[i] = values[idx]
+		}
+		return res, nil
+	}
+}
+
+// projectedValueUpdates only iterates over the kept fields of the wrapped ValueUpdates.
+type projectedValueUpdates struct {
+	schema.ValueUpdates
+	names map[string]bool
+}
+
+// Iterate implements the schema.ValueUpdates interface.
+func (p projectedValueUpdates) Iterate(fn func(col string, value interface{}) bool) error {
+	return p.ValueUpdates.Iterate(func(col string, value interface{}) bool {
+		if !p.names[col] {
+			return true
+		}
+		return fn(col, value)
+	})
+}
@@ -0,0 +1,188 @@
+package appdata
+
+import (
+	"reflect"
+	"testing"
+
+	"cosmossdk.io/schema"
+)
+
+var testModuleSchema = schema.ModuleSchema{ObjectTypes: []schema.ObjectType{
+	{
+		Name:      "balances",
+		KeyFields: []schema.Field{{Name: "address", Kind: schema.StringKind}},
+		ValueFields: []schema.Field{
+			{Name: "amount", Kind: schema.Int64Kind},
+			{Name: "denom", Kind: schema.StringKind},
+			{Name: "memo", Kind: schema.StringKind},
+		},
+	},
+	{
+		Name:        "supply",
+		KeyFields:   []schema.Field{{Name: "denom", Kind: schema.StringKind}},
+		ValueFields: []schema.Field{{Name: "amount", Kind: schema.Int64Kind}},
+	},
+}}
+
+type recordedUpdate struct {
+	moduleName string
+	update     schema.ObjectUpdate
+}
+
+type recorder struct {
+	schemas map[string]schema.ModuleSchema
+	kvPairs []string
+	updates []recordedUpdate
+}
+
+func (r *recorder) listener() Listener {
+	r.schemas = map[string]schema.ModuleSchema{}
+	return Listener{
+		InitializeModuleSchema: func(moduleName string, moduleSchema schema.ModuleSchema) error {
+			r.schemas[moduleName] = moduleSchema
+			return nil
+		},
+		OnKVPair: func(moduleName string, key, _ []byte, _ bool) error {
+			r.kvPairs = append(r.kvPairs, moduleName+"/"+string(key))
+			return nil
+		},
+		OnObjectUpdate: func(moduleName string, update schema.ObjectUpdate) error {
+			r.updates = append(r.updates, recordedUpdate{moduleName, update})
+			return nil
+		},
+	}
+}
+
+func TestFilterModulesAndKeyPrefixes(t *testing.T) {
+	r := &recorder{}
+	listener := FilterModules(r.listener(), func(moduleName string) bool { return moduleName != "staking" })
+	listener = FilterKeyPrefixes(listener, "bank", []byte("a"), []byte("b"))
+
+	for _, moduleName := range []string{"bank", "staking", "gov"} {
+		if err := listener.InitializeModuleSchema(moduleName, testModuleSchema); err != nil {
+			t.Fatal(err)
+		}
+		for _, key := range []string{"a1", "b1", "c1"} {
+			if err := listener.OnKVPair(moduleName, []byte(key), nil, false); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := listener.OnObjectUpdate(moduleName, schema.ObjectUpdate{TypeName: "supply", Key: "atom", Value: int64(1)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(r.schemas) != 2 || r.schemas["staking"].ObjectTypes != nil {
+		t.Fatalf("unexpected schemas %v", r.schemas)
+	}
+	expectedKVPairs := []string{"bank/a1", "bank/b1", "gov/a1", "gov/b1", "gov/c1"}
+	if !reflect.DeepEqual(r.kvPairs, expectedKVPairs) {
+		t.Fatalf("expected %v, got %v", expectedKVPairs, r.kvPairs)
+	}
+	if len(r.updates) != 2 || r.updates[0].moduleName != "bank" || r.updates[1].moduleName != "gov" {
+		t.Fatalf("unexpected updates %v", r.updates)
+	}
+}
+
+func TestFilterObjectTypes(t *testing.T) {
+	r := &recorder{}
+	listener := FilterObjectTypes(r.listener(), func(_, objectType string) bool { return objectType == "balances" })
+
+	if err := listener.InitializeModuleSchema("bank", testModuleSchema); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r.schemas["bank"].ObjectTypes, testModuleSchema.ObjectTypes[:1]) {
+		t.Fatalf("unexpected schema %v", r.schemas["bank"])
+	}
+	// the original schema is not modified
+	if len(testModuleSchema.ObjectTypes) != 2 {
+		t.Fatal("original schema was modified")
+	}
+
+	for _, typeName := range []string{"balances", "supply"} {
+		if err := listener.OnObjectUpdate("bank", schema.ObjectUpdate{TypeName: typeName}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if len(r.updates) != 1 || r.updates[0].update.TypeName != "balances" {
+		t.Fatalf("unexpected updates %v", r.updates)
+	}
+}
+
+func TestProjectValueFields(t *testing.T) {
+	r := &recorder{}
+	listener := ProjectValueFields(r.listener(), "bank", "balances", "amount", "memo")
+	listener = ProjectValueFields(listener, "bank", "supply")
+
+	err := listener.OnObjectUpdate("bank", schema.ObjectUpdate{TypeName: "balances", Key: "addr1", Value: []interface{}{int64(1), "atom", "hi"}})
+	if err == nil {
+		t.Fatal("expected error before the schema is initialized")
+	}
+
+	if err := listener.InitializeModuleSchema("bank", testModuleSchema); err != nil {
+		t.Fatal(err)
+	}
+	balances := r.schemas["bank"].ObjectTypes[0]
+	expectedFields := []schema.Field{{Name: "amount", Kind: schema.Int64Kind}, {Name: "memo", Kind: schema.StringKind}}
+	if !reflect.DeepEqual(balances.ValueFields, expectedFields) {
+		t.Fatalf("expected %v, got %v", expectedFields, balances.ValueFields)
+	}
+	if len(r.schemas["bank"].ObjectTypes[1].ValueFields) != 0 {
+		t.Fatalf("expected no value fields for supply, got %v", r.schemas["bank"].ObjectTypes[1].ValueFields)
+	}
+	if err := r.schemas["bank"].Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	updates := []schema.ObjectUpdate{
+		{TypeName: "balances", Key: "addr1", Value: []interface{}{int64(1), "atom", "hi"}},
+		{TypeName: "balances", Key: "addr1", Value: schema.MapValueUpdates{"denom": "atom", "memo": "hello"}},
+		{TypeName: "balances", Key: "addr1", Delete: true},
+		{TypeName: "supply", Key: "atom", Value: int64(10)},
+	}
+	for _, update := range updates {
+		if err := listener.OnObjectUpdate("bank", update); err != nil {
+			t.Fatal(err)
+		}
+		// other modules are not projected
+		if err := listener.OnObjectUpdate("staking", update); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if !reflect.DeepEqual(r.updates[0].update.Value, []interface{}{int64(1), "hi"}) {
+		t.Fatalf("unexpected projected value %v", r.updates[0].update.Value)
+	}
+	if !reflect.DeepEqual(r.updates[1].update.Value, updates[0].Value) {
+		t.Fatalf("unexpected value %v", r.updates[1].update.Value)
+	}
+
+	values := map[string]interface{}{}
+	err = r.updates[2].update.Value.(schema.ValueUpdates).Iterate(func(col string, value interface{}) bool {
+		values[col] = value
+		return true
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(values, map[string]interface{}{"memo": "hello"}) {
+		t.Fatalf("unexpected projected value updates %v", values)
+	}
+
+	if !r.updates[4].update.Delete {
+		t.Fatal("expected delete update")
+	}
+	if r.updates[6].update.Value != nil {
+		t.Fatalf("expected no value for supply, got %v", r.updates[6].update.Value)
+	}
+	for i := 0; i < len(updates); i++ {
+		if err := r.schemas["bank"].ValidateObjectUpdate(r.updates[2*i].update); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	listener = ProjectValueFields(Listener{}, "bank", "balances", "unknown")
+	if err := listener.InitializeModuleSchema("bank", testModuleSchema); err == nil {
+		t.Fatal("expected error for unknown field")
+	}
+}
@@ -21,5 +21,5 @@ type ModuleCodec struct {
 // KVDecoder is a function that decodes a key-value pair into an ObjectUpdate.
 // If the KV-pair doesn't represent an object update, the function should return false
 // as the second return value. Error should only be non-nil when the decoder expected
-// to parse a valid update and was unable to.
+// to parse a valid update and was unable to. A nil value indicates that the key was deleted.
 type KVDecoder = func(key, value []byte) (ObjectUpdate, bool, error)
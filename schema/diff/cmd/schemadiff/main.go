@@ -0,0 +1,105 @@
+// schemadiff compares the module schemas of two versions of an app and exits with a non-zero
+// status if any of the changes is breaking for indexers, so that it can be used to gate releases.
+//
+// Usage:
+//
+//	schemadiff <old.json> <new.json>
+//
+// Each file contains a JSON object mapping module names to their schema.ModuleSchema.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"cosmossdk.io/schema"
+	"cosmossdk.io/schema/diff"
+)
+
+const (
+	exitCompatible = 0
+	exitBreaking   = 1
+	exitError      = 2
+)
+
+func main() {
+	code, err := run(os.Args[1:], os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+	}
+	os.Exit(code)
+}
+
+func run(args []string, out io.Writer) (int, error) {
+	if len(args) != 2 {
+		return exitError, errors.New("usage: schemadiff <old.json> <new.json>")
+	}
+
+	oldSchemas, err := readSchemas(args[0])
+	if err != nil {
+		return exitError, err
+	}
+	newSchemas, err := readSchemas(args[1])
+	if err != nil {
+		return exitError, err
+	}
+
+	modules := make([]string, 0, len(oldSchemas)+len(newSchemas))
+	for module := range oldSchemas {
+		modules = append(modules, module)
+	}
+	for module := range newSchemas {
+		if _, ok := oldSchemas[module]; !ok {
+			modules = append(modules, module)
+		}
+	}
+	sort.Strings(modules)
+
+	breaking := false
+	for _, module := range modules {
+		oldSchema, hasOld := oldSchemas[module]
+		newSchema, hasNew := newSchemas[module]
+		switch {
+		case !hasOld:
+			fmt.Fprintf(out, "%s: compatible module added\n", module)
+		case !hasNew:
+			fmt.Fprintf(out, "%s: BREAKING module removed\n", module)
+			breaking = true
+		default:
+			moduleDiff := diff.CompareModuleSchemas(oldSchema, newSchema)
+			for _, change := range moduleDiff.Changes {
+				fmt.Fprintf(out, "%s: %s\n", module, change)
+			}
+			breaking = breaking || moduleDiff.HasBreakingChanges()
+		}
+	}
+
+	if breaking {
+		return exitBreaking, nil
+	}
+	return exitCompatible, nil
+}
+
+func readSchemas(path string) (map[string]schema.ModuleSchema, error) {
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var schemas map[string]schema.ModuleSchema
+	if err := json.Unmarshal(bz, &schemas); err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", path, err)
+	}
+
+	for module, moduleSchema := range schemas {
+		if err := moduleSchema.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid schema for module %s in %s: %w", module, path, err)
+		}
+	}
+
+	return schemas, nil
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+
+	v1 := writeFile(t, dir, "v1.json", `{
+		"bank": {"ObjectTypes": [{"Name": "balances", "KeyFields": [{"Name": "denom", "Kind": "string"}], "ValueFields": [{"Name": "amount", "Kind": "integer"}]}]},
+		"gov": {"ObjectTypes": [{"Name": "params", "ValueFields": [{"Name": "quorum", "Kind": "decimal"}]}]}
+	}`)
+	v2 := writeFile(t, dir, "v2.json", `{
+		"bank": {"ObjectTypes": [{"Name": "balances", "KeyFields": [{"Name": "denom", "Kind": "string"}], "ValueFields": [{"Name": "amount", "Kind": "integer"}, {"Name": "memo", "Kind": "string", "Nullable": true}]}]},
+		"gov": {"ObjectTypes": [{"Name": "params", "ValueFields": [{"Name": "quorum", "Kind": "decimal"}]}]},
+		"mint": {"ObjectTypes": [{"Name": "minter", "ValueFields": [{"Name": "inflation", "Kind": "decimal"}]}]}
+	}`)
+	v3 := writeFile(t, dir, "v3.json", `{
+		"bank": {"ObjectTypes": [{"Name": "balances", "KeyFields": [{"Name": "denom", "Kind": "string"}], "ValueFields": [{"Name": "amount", "Kind": "int64"}]}]}
+	}`)
+
+	var out bytes.Buffer
+	code, err := run([]string{v1, v2}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != exitCompatible {
+		t.Fatalf("expected compatible exit code, got %d", code)
+	}
+	expected := `bank: compatible field added: object type "balances", field "memo" (nullable)
+mint: compatible module added
+`
+	if out.String() != expected {
+		t.Fatalf("expected output:\n%s\ngot:\n%s", expected, out.String())
+	}
+
+	out.Reset()
+	code, err = run([]string{v2, v3}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if code != exitBreaking {
+		t.Fatalf("expected breaking exit code, got %d", code)
+	}
+	expected = `bank: BREAKING field kind changed: object type "balances", field "amount" (integer -> int64)
+bank: BREAKING field removed: object type "balances", field "memo"
+gov: BREAKING module removed
+mint: BREAKING module removed
+`
+	if out.String() != expected {
+		t.Fatalf("expected output:\n%s\ngot:\n%s", expected, out.String())
+	}
+
+	invalid := writeFile(t, dir, "invalid.json", `{"bank": {"ObjectTypes": [{"Name": "balances"}]}}`)
+	if code, err := run([]string{v1, invalid}, &out); err == nil || code != exitError {
+		t.Fatalf("expected error for invalid schema, got %d %v", code, err)
+	}
+	if code, err := run([]string{v1}, &out); err == nil || code != exitError {
+		t.Fatalf("expected usage error, got %d %v", code, err)
+	}
+}
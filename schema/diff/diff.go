@@ -0,0 +1,325 @@
+// Package diff compares module schemas across versions of a module and classifies
+// the changes as compatible or breaking for indexers which have already indexed
+// data with the old schema.
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"cosmossdk.io/schema"
+)
+
+// ChangeType is the type of a change between two module schemas.
+type ChangeType int
+
+const (
+	// ObjectTypeAdded means that an object type was added. It is compatible.
+	ObjectTypeAdded ChangeType = iota + 1
+
+	// ObjectTypeRemoved means that an object type was removed. It is breaking.
+	ObjectTypeRemoved
+
+	// RetainDeletionsChanged means that the RetainDeletions flag of an object type changed.
+	// It is compatible because it only changes how future deletions are indexed.
+	RetainDeletionsChanged
+
+	// KeyFieldAdded means that a key field was added. It is breaking.
+	KeyFieldAdded
+
+	// KeyFieldRemoved means that a key field was removed. It is breaking.
+	KeyFieldRemoved
+
+	// FieldAdded means that a value field, or a field of a nested object, was added.
+	// It is compatible if the field is nullable and breaking otherwise.
+	FieldAdded
+
+	// FieldRemoved means that a value field, or a field of a nested object, was removed.
+	// It is breaking.
+	FieldRemoved
+
+	// FieldKindChanged means that the kind of a field, or the element kind of a list field,
+	// changed. It is breaking.
+	FieldKindChanged
+
+	// FieldNullabilityChanged means that a field became nullable, which is compatible,
+	// or non-nullable, which is breaking.
+	FieldNullabilityChanged
+
+	// FieldAddressPrefixChanged means that the address prefix of a field changed. It is breaking.
+	FieldAddressPrefixChanged
+
+	// EnumValueAdded means that a value was added to the enum definition of a field. It is compatible.
+	EnumValueAdded
+
+	// EnumValueRemoved means that a value was removed from the enum definition of a field. It is breaking.
+	EnumValueRemoved
+
+	// EnumNameChanged means that the name of the enum definition of a field changed. It is breaking.
+	EnumNameChanged
+)
+
+// String returns a string representation of the change type.
+func (t ChangeType) String() string {
+	switch t {
+	case ObjectTypeAdded:
+		return "object type added"
+	case ObjectTypeRemoved:
+		return "object type removed"
+	case RetainDeletionsChanged:
+		return "retain deletions changed"
+	case KeyFieldAdded:
+		return "key field added"
+	case KeyFieldRemoved:
+		return "key field removed"
+	case FieldAdded:
+		return "field added"
+	case FieldRemoved:
+		return "field removed"
+	case FieldKindChanged:
+		return "field kind changed"
+	case FieldNullabilityChanged:
+		return "field nullability changed"
+	case FieldAddressPrefixChanged:
+		return "field address prefix changed"
+	case EnumValueAdded:
+		return "enum value added"
+	case EnumValueRemoved:
+		return "enum value removed"
+	case EnumNameChanged:
+		return "enum name changed"
+	default:
+		return fmt.Sprintf("unknown(%d)", t)
+	}
+}
+
+// Change is a single change between two module schemas.
+type Change struct {
+	// Type is the type of the change.
+	Type ChangeType
+
+	// ObjectType is the name of the object type which changed.
+	ObjectType string
+
+	// Field is the name of the field which changed, with the names of the enclosing
+	// object fields separated by dots for nested fields. It is empty for changes of
+	// the object type itself.
+	Field string
+
+	// Breaking indicates whether the change is breaking for indexers which have indexed
+	// data with the old schema.
+	Breaking bool
+
+	// Details describes the change, for instance the old and new kinds of a field.
+	Details string
+}
+
+// String returns a human readable description of the change.
+func (c Change) String() string {
+	var sb strings.Builder
+	if c.Breaking {
+		sb.WriteString("BREAKING ")
+	} else {
+		sb.WriteString("compatible ")
+	}
+	sb.WriteString(c.Type.String())
+	fmt.Fprintf(&sb, ": object type %q", c.ObjectType)
+	if c.Field != "" {
+		fmt.Fprintf(&sb, ", field %q", c.Field)
+	}
+	if c.Details != "" {
+		fmt.Fprintf(&sb, " (%s)", c.Details)
+	}
+	return sb.String()
+}
+
+// ModuleSchemaDiff is the list of changes between two module schemas.
+type ModuleSchemaDiff struct {
+	// Changes are the changes between the schemas, sorted by object type in the order
+	// of the new schema, with the removed object types last.
+	Changes []Change
+}
+
+// Empty returns true if the schemas are equivalent.
+func (d ModuleSchemaDiff) Empty() bool {
+	return len(d.Changes) == 0
+}
+
+// HasBreakingChanges returns true if any of the changes is breaking.
+func (d ModuleSchemaDiff) HasBreakingChanges() bool {
+	for _, change := range d.Changes {
+		if change.Breaking {
+			return true
+		}
+	}
+	return false
+}
+
+// BreakingChanges returns the breaking changes.
+func (d ModuleSchemaDiff) BreakingChanges() []Change {
+	var res []Change
+	for _, change := range d.Changes {
+		if change.Breaking {
+			res = append(res, change)
+		}
+	}
+	return res
+}
+
+// CompareModuleSchemas compares the old and new schemas of a module. Fields are matched
+// by name, so reordering fields is not considered to be a change.
+func CompareModuleSchemas(oldSchema, newSchema schema.ModuleSchema) ModuleSchemaDiff {
+	var diff ModuleSchemaDiff
+
+	oldTypes := make(map[string]schema.ObjectType, len(oldSchema.ObjectTypes))
+	for _, objectType := range oldSchema.ObjectTypes {
+		oldTypes[objectType.Name] = objectType
+	}
+
+	newTypes := make(map[string]bool, len(newSchema.ObjectTypes))
+	for _, newType := range newSchema.ObjectTypes {
+		newTypes[newType.Name] = true
+
+		oldType, ok := oldTypes[newType.Name]
+		if !ok {
+			diff.Changes = append(diff.Changes, Change{Type: ObjectTypeAdded, ObjectType: newType.Name})
+			continue
+		}
+
+		diff.Changes = append(diff.Changes, compareObjectTypes(oldType, newType)...)
+	}
+
+	for _, oldType := range oldSchema.ObjectTypes {
+		if !newTypes[oldType.Name] {
+			diff.Changes = append(diff.Changes, Change{Type: ObjectTypeRemoved, ObjectType: oldType.Name, Breaking: true})
+		}
+	}
+
+	return diff
+}
+
+func compareObjectTypes(oldType, newType schema.ObjectType) []Change {
+	c := &comparer{objectType: newType.Name}
+
+	if oldType.RetainDeletions != newType.RetainDeletions {
+		c.add(RetainDeletionsChanged, "", false, fmt.Sprintf("%t -> %t", oldType.RetainDeletions, newType.RetainDeletions))
+	}
+
+	c.compareFields("", oldType.KeyFields, newType.KeyFields, true)
+	c.compareFields("", oldType.ValueFields, newType.ValueFields, false)
+
+	return c.changes
+}
+
+type comparer struct {
+	objectType string
+	changes    []Change
+}
+
+func (c *comparer) add(typ ChangeType, field string, breaking bool, details string) {
+	c.changes = append(c.changes, Change{
+		Type:       typ,
+		ObjectType: c.objectType,
+		Field:      field,
+		Breaking:   breaking,
+		Details:    details,
+	})
+}
+
+// compareFields compares the fields of an object type, or of a nested object when prefix is
+// the path of the enclosing field.
+func (c *comparer) compareFields(prefix string, oldFields, newFields []schema.Field, key bool) {
+	oldByName := make(map[string]schema.Field, len(oldFields))
+	for _, field := range oldFields {
+		oldByName[field.Name] = field
+	}
+
+	newNames := make(map[string]bool, len(newFields))
+	for _, newField := range newFields {
+		newNames[newField.Name] = true
+		path := prefix + newField.Name
+
+		oldField, ok := oldByName[newField.Name]
+		switch {
+		case ok:
+			c.compareField(path, oldField, newField)
+		case key:
+			c.add(KeyFieldAdded, path, true, "")
+		default:
+			details := "nullable"
+			if !newField.Nullable {
+				details = "non-nullable"
+			}
+			c.add(FieldAdded, path, !newField.Nullable, details)
+		}
+	}
+
+	for _, oldField := range oldFields {
+		if newNames[oldField.Name] {
+			continue
+		}
+		if key {
+			c.add(KeyFieldRemoved, prefix+oldField.Name, true, "")
+		} else {
+			c.add(FieldRemoved, prefix+oldField.Name, true, "")
+		}
+	}
+}
+
+func (c *comparer) compareField(path string, oldField, newField schema.Field) {
+	if oldField.Kind != newField.Kind {
+		c.add(FieldKindChanged, path, true, fmt.Sprintf("%s -> %s", oldField.Kind, newField.Kind))
+		// the other properties of the field are not comparable anymore
+		return
+	}
+
+	if oldField.ElementKind != newField.ElementKind {
+		c.add(FieldKindChanged, path, true, fmt.Sprintf("list of %s -> list of %s", oldField.ElementKind, newField.ElementKind))
+		return
+	}
+
+	if oldField.Nullable != newField.Nullable {
+		details := "nullable -> non-nullable"
+		if newField.Nullable {
+			details = "non-nullable -> nullable"
+		}
+		c.add(FieldNullabilityChanged, path, !newField.Nullable, details)
+	}
+
+	if oldField.AddressPrefix != newField.AddressPrefix {
+		c.add(FieldAddressPrefixChanged, path, true, fmt.Sprintf("%q -> %q", oldField.AddressPrefix, newField.AddressPrefix))
+	}
+
+	if oldField.Kind == schema.EnumKind || oldField.ElementKind == schema.EnumKind {
+		c.compareEnums(path, oldField.EnumDefinition, newField.EnumDefinition)
+	}
+
+	if oldField.Kind == schema.ObjectKind {
+		c.compareFields(path+".", oldField.ObjectFields, newField.ObjectFields, false)
+	}
+}
+
+func (c *comparer) compareEnums(path string, oldEnum, newEnum schema.EnumDefinition) {
+	if oldEnum.Name != newEnum.Name {
+		c.add(EnumNameChanged, path, true, fmt.Sprintf("%q -> %q", oldEnum.Name, newEnum.Name))
+	}
+
+	oldValues := make(map[string]bool, len(oldEnum.Values))
+	for _, value := range oldEnum.Values {
+		oldValues[value] = true
+	}
+
+	newValues := make(map[string]bool, len(newEnum.Values))
+	for _, value := range newEnum.Values {
+		newValues[value] = true
+		if !oldValues[value] {
+			c.add(EnumValueAdded, path, false, value)
+		}
+	}
+
+	for _, value := range oldEnum.Values {
+		if !newValues[value] {
+			c.add(EnumValueRemoved, path, true, value)
+		}
+	}
+}
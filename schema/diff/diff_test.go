@@ -0,0 +1,136 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"cosmossdk.io/schema"
+)
+
+var statusEnum = schema.EnumDefinition{Name: "status", Values: []string{"open", "closed"}}
+
+var oldSchema = schema.ModuleSchema{ObjectTypes: []schema.ObjectType{
+	{
+		Name:      "balances",
+		KeyFields: []schema.Field{{Name: "address", Kind: schema.BytesKind}},
+		ValueFields: []schema.Field{
+			{Name: "amount", Kind: schema.Int64Kind},
+			{Name: "memo", Kind: schema.StringKind},
+		},
+	},
+	{
+		Name:      "proposals",
+		KeyFields: []schema.Field{{Name: "id", Kind: schema.Uint64Kind}},
+		ValueFields: []schema.Field{
+			{Name: "status", Kind: schema.EnumKind, EnumDefinition: statusEnum},
+			{
+				Name: "metadata",
+				Kind: schema.ObjectKind,
+				ObjectFields: []schema.Field{
+					{Name: "title", Kind: schema.StringKind},
+				},
+			},
+			{Name: "votes", Kind: schema.ListKind, ElementKind: schema.Uint32Kind},
+		},
+	},
+	{
+		Name:        "params",
+		ValueFields: []schema.Field{{Name: "enabled", Kind: schema.BoolKind}},
+	},
+}}
+
+func TestCompareModuleSchemas(t *testing.T) {
+	d := CompareModuleSchemas(oldSchema, oldSchema)
+	if !d.Empty() || d.HasBreakingChanges() {
+		t.Fatalf("expected no changes, got %v", d.Changes)
+	}
+
+	newSchema := schema.ModuleSchema{ObjectTypes: []schema.ObjectType{
+		{
+			Name:      "balances",
+			KeyFields: []schema.Field{{Name: "address", Kind: schema.BytesKind}},
+			ValueFields: []schema.Field{
+				// reordered fields are not changes
+				{Name: "memo", Kind: schema.StringKind, Nullable: true},
+				{Name: "amount", Kind: schema.Int64Kind},
+				{Name: "denom", Kind: schema.StringKind, Nullable: true},
+			},
+			RetainDeletions: true,
+		},
+		{
+			Name:      "proposals",
+			KeyFields: []schema.Field{{Name: "id", Kind: schema.Uint64Kind}, {Name: "version", Kind: schema.Uint32Kind}},
+			ValueFields: []schema.Field{
+				{Name: "status", Kind: schema.EnumKind, EnumDefinition: schema.EnumDefinition{Name: "status", Values: []string{"open", "passed"}}},
+				{
+					Name: "metadata",
+					Kind: schema.ObjectKind,
+					ObjectFields: []schema.Field{
+						{Name: "title", Kind: schema.StringKind},
+						{Name: "summary", Kind: schema.StringKind},
+					},
+				},
+				{Name: "votes", Kind: schema.ListKind, ElementKind: schema.Uint64Kind},
+			},
+		},
+		{
+			Name:        "supply",
+			KeyFields:   []schema.Field{{Name: "denom", Kind: schema.StringKind}},
+			ValueFields: []schema.Field{{Name: "amount", Kind: schema.IntegerKind}},
+		},
+	}}
+
+	d = CompareModuleSchemas(oldSchema, newSchema)
+	expected := []Change{
+		{Type: RetainDeletionsChanged, ObjectType: "balances", Details: "false -> true"},
+		{Type: FieldNullabilityChanged, ObjectType: "balances", Field: "memo", Details: "non-nullable -> nullable"},
+		{Type: FieldAdded, ObjectType: "balances", Field: "denom", Details: "nullable"},
+		{Type: KeyFieldAdded, ObjectType: "proposals", Field: "version", Breaking: true},
+		{Type: EnumValueAdded, ObjectType: "proposals", Field: "status", Details: "passed"},
+		{Type: EnumValueRemoved, ObjectType: "proposals", Field: "status", Breaking: true, Details: "closed"},
+		{Type: FieldAdded, ObjectType: "proposals", Field: "metadata.summary", Breaking: true, Details: "non-nullable"},
+		{Type: FieldKindChanged, ObjectType: "proposals", Field: "votes", Breaking: true, Details: "list of uint32 -> list of uint64"},
+		{Type: ObjectTypeAdded, ObjectType: "supply"},
+		{Type: ObjectTypeRemoved, ObjectType: "params", Breaking: true},
+	}
+	if !reflect.DeepEqual(d.Changes, expected) {
+		t.Fatalf("expected changes:\n%v\ngot:\n%v", expected, d.Changes)
+	}
+	if !d.HasBreakingChanges() || len(d.BreakingChanges()) != 5 {
+		t.Fatalf("expected 5 breaking changes, got %v", d.BreakingChanges())
+	}
+
+	// the reverse changes
+	d = CompareModuleSchemas(newSchema, oldSchema)
+	breaking := map[ChangeType]bool{}
+	for _, change := range d.BreakingChanges() {
+		breaking[change.Type] = true
+	}
+	for _, typ := range []ChangeType{FieldNullabilityChanged, FieldRemoved, KeyFieldRemoved, EnumValueRemoved, ObjectTypeRemoved, FieldKindChanged} {
+		if !breaking[typ] {
+			t.Errorf("expected breaking %s change, got %v", typ, d.Changes)
+		}
+	}
+}
+
+func TestCompareModuleSchemasKindChange(t *testing.T) {
+	newSchema := schema.ModuleSchema{ObjectTypes: []schema.ObjectType{
+		oldSchema.ObjectTypes[0],
+		{
+			Name:        "params",
+			ValueFields: []schema.Field{{Name: "enabled", Kind: schema.StringKind}},
+		},
+		oldSchema.ObjectTypes[1],
+	}}
+
+	d := CompareModuleSchemas(oldSchema, newSchema)
+	expected := []Change{
+		{Type: FieldKindChanged, ObjectType: "params", Field: "enabled", Breaking: true, Details: "bool -> string"},
+	}
+	if !reflect.DeepEqual(d.Changes, expected) {
+		t.Fatalf("expected changes %v, got %v", expected, d.Changes)
+	}
+	if d.Changes[0].String() != `BREAKING field kind changed: object type "params", field "enabled" (bool -> string)` {
+		t.Fatalf("unexpected string %s", d.Changes[0])
+	}
+}
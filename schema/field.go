@@ -13,14 +13,24 @@ type Field struct {
 	// Nullable indicates whether null values are accepted for the field.
 	Nullable bool
 
-	// AddressPrefix is the address prefix of the field's kind, currently only used for Bech32AddressKind.
+	// AddressPrefix is the address prefix of the field's kind, currently only used for Bech32AddressKind,
+	// or ListKind when ElementKind is Bech32AddressKind.
 	AddressPrefix string
 
-	// EnumDefinition is the definition of the enum type and is only valid when Kind is EnumKind.
+	// EnumDefinition is the definition of the enum type and is only valid when Kind is EnumKind,
+	// or ListKind when ElementKind is EnumKind.
 	// The same enum types can be reused in the same module schema, but they always must contain
 	// the same values for the same enum name. This possibly introduces some duplication of
 	// definitions but makes it easier to reason about correctness and validation in isolation.
 	EnumDefinition EnumDefinition
+
+	// ObjectFields are the fields of the nested object and are only valid when Kind is ObjectKind.
+	// Field names must be unique within the nested object.
+	ObjectFields []Field
+
+	// ElementKind is the kind of the elements of the list and is only valid when Kind is ListKind.
+	// It can be any kind except ObjectKind and ListKind.
+	ElementKind Kind
 }
 
 // Validate validates the field.
@@ -35,28 +45,64 @@ func (c Field) Validate() error {
 		return fmt.Errorf("invalid field kind for %q: %w", c.Name, err)
 	}
 
+	// element kind only valid with ListKind, the element kind is the kind the address prefix
+	// and enum definition apply to
+	kind := c.Kind
+	if c.Kind == ListKind {
+		if err := c.ElementKind.Validate(); err != nil {
+			return fmt.Errorf("invalid element kind for %q: %w", c.Name, err)
+		}
+		if c.ElementKind == ObjectKind || c.ElementKind == ListKind {
+			return fmt.Errorf("element kind %s is not supported for field %q", c.ElementKind, c.Name)
+		}
+		kind = c.ElementKind
+	} else if c.ElementKind != InvalidKind {
+		return fmt.Errorf("element kind is only valid for field %q with type ListKind", c.Name)
+	}
+
 	// address prefix only valid with Bech32AddressKind
-	if c.Kind == Bech32AddressKind && c.AddressPrefix == "" {
+	if kind == Bech32AddressKind && c.AddressPrefix == "" {
 		return fmt.Errorf("missing address prefix for field %q", c.Name)
-	} else if c.Kind != Bech32AddressKind && c.AddressPrefix != "" {
+	} else if kind != Bech32AddressKind && c.AddressPrefix != "" {
 		return fmt.Errorf("address prefix is only valid for field %q with type Bech32AddressKind", c.Name)
 	}
 
 	// enum definition only valid with EnumKind
-	if c.Kind == EnumKind {
+	if kind == EnumKind {
 		if err := c.EnumDefinition.Validate(); err != nil {
 			return fmt.Errorf("invalid enum definition for field %q: %w", c.Name, err)
 		}
-	} else if c.Kind != EnumKind && (c.EnumDefinition.Name != "" || c.EnumDefinition.Values != nil) {
+	} else if kind != EnumKind && (c.EnumDefinition.Name != "" || c.EnumDefinition.Values != nil) {
 		return fmt.Errorf("enum definition is only valid for field %q with type EnumKind", c.Name)
 	}
 
+	// object fields only valid with ObjectKind
+	if c.Kind == ObjectKind {
+		if len(c.ObjectFields) == 0 {
+			return fmt.Errorf("missing object fields for field %q", c.Name)
+		}
+
+		fieldNames := map[string]bool{}
+		for _, field := range c.ObjectFields {
+			if err := field.Validate(); err != nil {
+				return fmt.Errorf("invalid object field %q of field %q: %w", field.Name, c.Name, err)
+			}
+
+			if fieldNames[field.Name] {
+				return fmt.Errorf("duplicate object field name %q in field %q", field.Name, c.Name)
+			}
+			fieldNames[field.Name] = true
+		}
+	} else if c.ObjectFields != nil {
+		return fmt.Errorf("object fields are only valid for field %q with type ObjectKind", c.Name)
+	}
+
 	return nil
 }
 
 // ValidateValue validates that the value conforms to the field's kind and nullability.
 // Unlike Kind.ValidateValue, it also checks that the value conforms to the EnumDefinition
-// if the field is an EnumKind.
+// if the field is an EnumKind, and validates the elements of ListKind and ObjectKind values.
 func (c Field) ValidateValue(value interface{}) error {
 	if value == nil {
 		if !c.Nullable {
@@ -69,8 +115,30 @@ func (c Field) ValidateValue(value interface{}) error {
 		return fmt.Errorf("invalid value for field %q: %w", c.Name, err)
 	}
 
-	if c.Kind == EnumKind {
+	switch c.Kind {
+	case EnumKind:
 		return c.EnumDefinition.ValidateValue(value.(string))
+	case ListKind:
+		for i, elem := range value.([]interface{}) {
+			if err := c.ElementKind.ValidateValueType(elem); err != nil {
+				return fmt.Errorf("invalid element %d for field %q: %w", i, c.Name, err)
+			}
+			if c.ElementKind == EnumKind {
+				if err := c.EnumDefinition.ValidateValue(elem.(string)); err != nil {
+					return err
+				}
+			}
+		}
+	case ObjectKind:
+		values := value.([]interface{})
+		if len(values) != len(c.ObjectFields) {
+			return fmt.Errorf("expected %d object fields for field %q, got %d values", len(c.ObjectFields), c.Name, len(values))
+		}
+		for i, field := range c.ObjectFields {
+			if err := field.ValidateValue(values[i]); err != nil {
+				return fmt.Errorf("invalid object field for field %q: %w", c.Name, err)
+			}
+		}
 	}
 
 	return nil
@@ -77,6 +77,104 @@ func TestField_Validate(t *testing.T) {
 				EnumDefinition: EnumDefinition{Name: "enum", Values: []string{"a", "b"}},
 			},
 		},
+		{
+			name: "valid list",
+			field: Field{
+				Name:        "field1",
+				Kind:        ListKind,
+				ElementKind: Uint64Kind,
+			},
+		},
+		{
+			name: "valid enum list",
+			field: Field{
+				Name:           "field1",
+				Kind:           ListKind,
+				ElementKind:    EnumKind,
+				EnumDefinition: EnumDefinition{Name: "enum", Values: []string{"a", "b"}},
+			},
+		},
+		{
+			name: "list of addresses without prefix",
+			field: Field{
+				Name:        "field1",
+				Kind:        ListKind,
+				ElementKind: Bech32AddressKind,
+			},
+			errContains: "missing address prefix",
+		},
+		{
+			name: "list without element kind",
+			field: Field{
+				Name: "field1",
+				Kind: ListKind,
+			},
+			errContains: "invalid element kind",
+		},
+		{
+			name: "list of lists",
+			field: Field{
+				Name:        "field1",
+				Kind:        ListKind,
+				ElementKind: ListKind,
+			},
+			errContains: "element kind list is not supported",
+		},
+		{
+			name: "element kind with non-ListKind",
+			field: Field{
+				Name:        "field1",
+				Kind:        StringKind,
+				ElementKind: StringKind,
+			},
+			errContains: "element kind is only valid for field \"field1\" with type ListKind",
+		},
+		{
+			name: "valid object",
+			field: Field{
+				Name: "field1",
+				Kind: ObjectKind,
+				ObjectFields: []Field{
+					{Name: "a", Kind: StringKind},
+					{Name: "b", Kind: ObjectKind, ObjectFields: []Field{{Name: "c", Kind: BoolKind}}},
+				},
+			},
+		},
+		{
+			name: "object without fields",
+			field: Field{
+				Name: "field1",
+				Kind: ObjectKind,
+			},
+			errContains: "missing object fields",
+		},
+		{
+			name: "object with invalid field",
+			field: Field{
+				Name:         "field1",
+				Kind:         ObjectKind,
+				ObjectFields: []Field{{Name: "a"}},
+			},
+			errContains: "invalid object field \"a\"",
+		},
+		{
+			name: "object with duplicate fields",
+			field: Field{
+				Name:         "field1",
+				Kind:         ObjectKind,
+				ObjectFields: []Field{{Name: "a", Kind: StringKind}, {Name: "a", Kind: BoolKind}},
+			},
+			errContains: "duplicate object field name",
+		},
+		{
+			name: "object fields with non-ObjectKind",
+			field: Field{
+				Name:         "field1",
+				Kind:         StringKind,
+				ObjectFields: []Field{{Name: "a", Kind: StringKind}},
+			},
+			errContains: "object fields are only valid for field \"field1\" with type ObjectKind",
+		},
 	}
 
 	for _, tt := range tests {
@@ -162,6 +260,50 @@ func TestField_ValidateValue(t *testing.T) {
 			value:       "c",
 			errContains: "not a valid enum value",
 		},
+		{
+			name:  "valid list",
+			field: Field{Name: "field1", Kind: ListKind, ElementKind: StringKind},
+			value: []interface{}{"a", "b"},
+		},
+		{
+			name:        "invalid list element",
+			field:       Field{Name: "field1", Kind: ListKind, ElementKind: StringKind},
+			value:       []interface{}{"a", 1},
+			errContains: "invalid element 1 for field \"field1\"",
+		},
+		{
+			name: "invalid enum list element",
+			field: Field{
+				Name:           "field1",
+				Kind:           ListKind,
+				ElementKind:    EnumKind,
+				EnumDefinition: EnumDefinition{Name: "enum", Values: []string{"a", "b"}},
+			},
+			value:       []interface{}{"a", "c"},
+			errContains: "not a valid enum value",
+		},
+		{
+			name:  "valid object",
+			field: testObjectField,
+			value: []interface{}{"a", nil},
+		},
+		{
+			name:  "valid nested object",
+			field: testObjectField,
+			value: []interface{}{"a", []interface{}{int32(1)}},
+		},
+		{
+			name:        "invalid object value count",
+			field:       testObjectField,
+			value:       []interface{}{"a"},
+			errContains: "expected 2 object fields",
+		},
+		{
+			name:        "invalid nested object value",
+			field:       testObjectField,
+			value:       []interface{}{"a", []interface{}{"b"}},
+			errContains: "invalid value for field \"c\"",
+		},
 	}
 
 	for _, tt := range tests {
@@ -181,3 +323,12 @@ func TestField_ValidateValue(t *testing.T) {
 		})
 	}
 }
+
+var testObjectField = Field{
+	Name: "field1",
+	Kind: ObjectKind,
+	ObjectFields: []Field{
+		{Name: "a", Kind: StringKind},
+		{Name: "b", Kind: ObjectKind, Nullable: true, ObjectFields: []Field{{Name: "c", Kind: Int32Kind}}},
+	},
+}
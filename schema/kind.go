@@ -83,10 +83,21 @@ const (
 	// JSONKind is a JSON type and values of this type should be of go type json.RawMessage and represent
 	// valid JSON.
 	JSONKind
+
+	// ObjectKind is a nested object type and values of this type must be of the go type []interface{}
+	// with one value for each of the nested fields, in order. Fields of this type are expected to set the
+	// ObjectFields field in the field definition to the nested fields. It is intended to represent
+	// structured data such as protobuf messages.
+	ObjectKind
+
+	// ListKind is a list type and values of this type must be of the go type []interface{} where
+	// each element is a value of the element kind. Fields of this type are expected to set the ElementKind
+	// field in the field definition to a kind which is neither ObjectKind nor ListKind.
+	ListKind
 )
 
 // MAX_VALID_KIND is the maximum valid kind value.
-const MAX_VALID_KIND = JSONKind
+const MAX_VALID_KIND = ListKind
 
 const (
 	// IntegerFormat is a regex that describes the format integer number strings must match. It specifies
@@ -105,7 +116,7 @@ func (t Kind) Validate() error {
 	if t <= InvalidKind {
 		return fmt.Errorf("unknown type: %d", t)
 	}
-	if t > JSONKind {
+	if t > MAX_VALID_KIND {
 		return fmt.Errorf("invalid type: %d", t)
 	}
 	return nil
@@ -154,11 +165,43 @@ func (t Kind) String() string {
 		return "enum"
 	case JSONKind:
 		return "json"
+	case ObjectKind:
+		return "object"
+	case ListKind:
+		return "list"
 	default:
 		return fmt.Sprintf("invalid(%d)", t)
 	}
 }
 
+// MarshalText implements encoding.TextMarshaler so that kinds are encoded with their string
+// representation, for instance in JSON. InvalidKind is encoded as an empty string.
+func (t Kind) MarshalText() ([]byte, error) {
+	if t == InvalidKind {
+		return []byte{}, nil
+	}
+	if err := t.Validate(); err != nil {
+		return nil, err
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and decodes the string representation
+// of a kind, or InvalidKind from an empty string.
+func (t *Kind) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*t = InvalidKind
+		return nil
+	}
+	for k := InvalidKind + 1; k <= MAX_VALID_KIND; k++ {
+		if k.String() == string(text) {
+			*t = k
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown kind %q", text)
+}
+
 // ValidateValueType returns an errContains if the value does not conform to the expected go type.
 // Some fields may accept nil values, however, this method does not have any notion of
 // nullability. This method only validates that the go type of the value is correct for the kind
@@ -267,6 +310,11 @@ func (t Kind) ValidateValueType(value interface{}) error {
 		if !ok {
 			return fmt.Errorf("expected json.RawMessage, got %T", value)
 		}
+	case ObjectKind, ListKind:
+		_, ok := value.([]interface{})
+		if !ok {
+			return fmt.Errorf("expected []interface{}, got %T", value)
+		}
 	default:
 		return fmt.Errorf("invalid type: %d", t)
 	}
@@ -275,7 +323,8 @@ func (t Kind) ValidateValueType(value interface{}) error {
 
 // ValidateValue returns an errContains if the value does not conform to the expected go type and format.
 // It is more thorough, but slower, than Kind.ValidateValueType and validates that Integer, Decimal and JSON
-// values are formatted correctly. It cannot validate enum values because Kind's do not have enum schemas.
+// values are formatted correctly. It cannot validate enum values because Kind's do not have enum schemas,
+// nor the elements of object and list values which are described by Field.
 func (t Kind) ValidateValue(value interface{}) error {
 	err := t.ValidateValueType(value)
 	if err != nil {
@@ -308,7 +357,7 @@ var (
 
 // KindForGoValue finds the simplest kind that can represent the given go value. It will not, however,
 // return kinds such as IntegerKind, DecimalKind, Bech32AddressKind, or EnumKind which all can be
-// represented as strings.
+// represented as strings, nor ObjectKind and ListKind which both are represented as []interface{}.
 func KindForGoValue(value interface{}) Kind {
 	switch value.(type) {
 	case string:
@@ -3,6 +3,7 @@ package schema
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 )
@@ -76,6 +77,10 @@ func TestKind_ValidateValueType(t *testing.T) {
 		{kind: Float64Kind, value: float32(1.0), valid: false},
 		{kind: JSONKind, value: json.RawMessage("{}"), valid: true},
 		{kind: JSONKind, value: "hello", valid: false},
+		{kind: ObjectKind, value: []interface{}{"a", int32(1)}, valid: true},
+		{kind: ObjectKind, value: map[string]interface{}{"a": 1}, valid: false},
+		{kind: ListKind, value: []interface{}{"a", "b"}, valid: true},
+		{kind: ListKind, value: []string{"a", "b"}, valid: false},
 		{kind: InvalidKind, value: "hello", valid: false},
 	}
 
@@ -208,6 +213,8 @@ func TestKind_String(t *testing.T) {
 		{Float32Kind, "float32"},
 		{Float64Kind, "float64"},
 		{JSONKind, "json"},
+		{ObjectKind, "object"},
+		{ListKind, "list"},
 		{EnumKind, "enum"},
 		{Bech32AddressKind, "bech32address"},
 		{InvalidKind, "invalid(0)"},
@@ -259,3 +266,36 @@ func TestKindForGoValue(t *testing.T) {
 		})
 	}
 }
+
+func TestKind_MarshalText(t *testing.T) {
+	for kind := InvalidKind; kind <= MAX_VALID_KIND; kind++ {
+		bz, err := json.Marshal(kind)
+		if err != nil {
+			t.Fatalf("unexpected error marshaling kind %s: %v", kind, err)
+		}
+
+		var decoded Kind
+		if err := json.Unmarshal(bz, &decoded); err != nil {
+			t.Fatalf("unexpected error unmarshaling kind %s: %v", bz, err)
+		}
+		if decoded != kind {
+			t.Fatalf("expected kind %s, got %s", kind, decoded)
+		}
+	}
+
+	bz, err := json.Marshal(Field{Name: "field1", Kind: ListKind, ElementKind: StringKind})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(bz), `"Kind":"list"`) || !strings.Contains(string(bz), `"ElementKind":"string"`) {
+		t.Fatalf("unexpected JSON %s", bz)
+	}
+
+	if _, err := json.Marshal(Kind(100)); err == nil {
+		t.Fatal("expected error marshaling invalid kind")
+	}
+	var kind Kind
+	if err := json.Unmarshal([]byte(`"unknown"`), &kind); err == nil {
+		t.Fatal("expected error unmarshaling unknown kind")
+	}
+}
@@ -37,7 +37,14 @@ func (s ModuleSchema) Validate() error {
 }
 
 func checkEnum(enumValueMap map[string]map[string]bool, field Field) error {
-	if field.Kind != EnumKind {
+	// enums can also be used by the fields of nested objects and the elements of lists
+	for _, objectField := range field.ObjectFields {
+		if err := checkEnum(enumValueMap, objectField); err != nil {
+			return err
+		}
+	}
+
+	if field.Kind != EnumKind && (field.Kind != ListKind || field.ElementKind != EnumKind) {
 		return nil
 	}
 
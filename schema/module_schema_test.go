@@ -110,6 +110,47 @@ func TestModuleSchema_Validate(t *testing.T) {
 			},
 			errContains: "different values",
 		},
+		{
+			name: "same enum with different values in nested object and list",
+			moduleSchema: ModuleSchema{
+				ObjectTypes: []ObjectType{
+					{
+						Name: "object1",
+						ValueFields: []Field{
+							{
+								Name: "v",
+								Kind: ObjectKind,
+								ObjectFields: []Field{
+									{
+										Name: "e",
+										Kind: EnumKind,
+										EnumDefinition: EnumDefinition{
+											Name:   "enum1",
+											Values: []string{"a", "b"},
+										},
+									},
+								},
+							},
+						},
+					},
+					{
+						Name: "object2",
+						ValueFields: []Field{
+							{
+								Name:        "v",
+								Kind:        ListKind,
+								ElementKind: EnumKind,
+								EnumDefinition: EnumDefinition{
+									Name:   "enum1",
+									Values: []string{"a", "c"},
+								},
+							},
+						},
+					},
+				},
+			},
+			errContains: "different values",
+		},
 	}
 
 	for _, tt := range tests {
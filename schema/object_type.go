@@ -39,6 +39,10 @@ func (o ObjectType) Validate() error {
 			return fmt.Errorf("invalid key field %q: %w", field.Name, err)
 		}
 
+		if field.Kind == ObjectKind || field.Kind == ListKind {
+			return fmt.Errorf("key field %q cannot be of type %s", field.Name, field.Kind)
+		}
+
 		if fieldNames[field.Name] {
 			return fmt.Errorf("duplicate field name %q", field.Name)
 		}
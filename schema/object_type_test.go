@@ -148,6 +148,34 @@ func TestObjectType_Validate(t *testing.T) {
 			},
 			errContains: "duplicate field name",
 		},
+		{
+			name: "object key field",
+			objectType: ObjectType{
+				Name: "object1",
+				KeyFields: []Field{
+					{
+						Name:         "field1",
+						Kind:         ObjectKind,
+						ObjectFields: []Field{{Name: "field2", Kind: StringKind}},
+					},
+				},
+			},
+			errContains: "key field \"field1\" cannot be of type object",
+		},
+		{
+			name: "list value field",
+			objectType: ObjectType{
+				Name: "object1",
+				ValueFields: []Field{
+					{
+						Name:        "field1",
+						Kind:        ListKind,
+						ElementKind: StringKind,
+					},
+				},
+			},
+			errContains: "",
+		},
 	}
 
 	for _, tt := range tests {
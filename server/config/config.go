@@ -150,6 +150,10 @@ type StateSyncConfig struct {
 	// SnapshotKeepRecent sets the number of recent state sync snapshots to keep.
 	// 0 keeps all snapshots.
 	SnapshotKeepRecent uint32 `mapstructure:"snapshot-keep-recent"`
+
+	// SnapshotMaxDeltas sets the number of incremental state sync snapshots taken
+	// after each full snapshot. 0 only takes full snapshots.
+	SnapshotMaxDeltas uint32 `mapstructure:"snapshot-max-deltas"`
 }
 
 // MempoolConfig defines the configurations for the SDK built-in app-side mempool
@@ -160,6 +164,11 @@ type MempoolConfig struct {
 	// unbounded in how many txs it may contain, and a positive value indicates
 	// the maximum amount of txs it may contain.
 	MaxTxs int `mapstructure:"max-txs"`
+
+	// Persist defines whether the transactions of the mempool are persisted to
+	// the data/mempool.wal file of the home directory, to be restored when the
+	// node restarts.
+	Persist bool `mapstructure:"persist"`
 }
 
 // State Streaming configuration
@@ -186,6 +186,11 @@ snapshot-interval = {{ .StateSync.SnapshotInterval }}
 # snapshot-keep-recent specifies the number of recent snapshots to keep and serve (0 to keep all).
 snapshot-keep-recent = {{ .StateSync.SnapshotKeepRecent }}
 
+# snapshot-max-deltas specifies the number of incremental snapshots, recording only the changes since
+# the previous snapshot, taken after each full snapshot (0 to only take full snapshots). Incremental
+# snapshots require the state of the heights since the previous snapshot to not be pruned yet.
+snapshot-max-deltas = {{ .StateSync.SnapshotMaxDeltas }}
+
 ###############################################################################
 ###                              State Streaming                            ###
 ###############################################################################
@@ -223,4 +228,9 @@ stop-node-on-err = {{ .Streaming.ABCI.StopNodeOnErr }}
 #
 # Note, this configuration only applies to SDK built-in app-side mempool
 # implementations.
-max-txs = {{ .Mempool.MaxTxs }}
\ No newline at end of file
+max-txs = {{ .Mempool.MaxTxs }}
+
+# Setting persist to true persists the transactions of the mempool to the data/mempool.wal
+# file, to re-insert them when the node restarts. The transactions which are no longer
+# valid are dropped. It has no effect when the mempool is disabled.
+persist = {{ .Mempool.Persist }}
\ No newline at end of file
@@ -81,6 +81,7 @@ const (
 
 	FlagStateSyncSnapshotInterval   = "state-sync.snapshot-interval"
 	FlagStateSyncSnapshotKeepRecent = "state-sync.snapshot-keep-recent"
+	FlagStateSyncSnapshotMaxDeltas  = "state-sync.snapshot-max-deltas"
 
 	// api-related flags
 
@@ -101,7 +102,8 @@ const (
 
 	// mempool flags
 
-	FlagMempoolMaxTxs = "mempool.max-txs"
+	FlagMempoolMaxTxs  = "mempool.max-txs"
+	FlagMempoolPersist = "mempool.persist"
 
 	// testnet keys
 
@@ -650,6 +652,14 @@ func startApp[T types.Application](svrCtx *Context, appCreator types.AppCreator[
 			svrCtx.Logger.Error(localErr.Error())
 		}
 	}
+
+	// re-insert the transactions of a persisted mempool before the node starts
+	if restorer, ok := any(app).(types.MempoolRestorer); ok {
+		if err := restorer.RestoreMempool(); err != nil {
+			cleanupFn()
+			return app, traceCleanupFn, err
+		}
+	}
 	return app, cleanupFn, nil
 }
 
@@ -1007,8 +1017,10 @@ func addStartNodeFlags[T types.Application](cmd *cobra.Command, opts StartCmdOpt
 	cmd.Flags().String(flagGRPCAddress, serverconfig.DefaultGRPCAddress, "the gRPC server address to listen on")
 	cmd.Flags().Uint64(FlagStateSyncSnapshotInterval, 0, "State sync snapshot interval")
 	cmd.Flags().Uint32(FlagStateSyncSnapshotKeepRecent, 2, "State sync snapshot to keep")
+	cmd.Flags().Uint32(FlagStateSyncSnapshotMaxDeltas, 0, "Incremental state sync snapshots to take after each full snapshot")
 	cmd.Flags().Bool(FlagDisableIAVLFastNode, false, "Disable fast node for IAVL tree")
 	cmd.Flags().Int(FlagMempoolMaxTxs, mempool.DefaultMaxTx, "Sets MaxTx value for the app-side mempool")
+	cmd.Flags().Bool(FlagMempoolPersist, false, "Persist the app-side mempool to restore its transactions on restart")
 	cmd.Flags().Duration(FlagShutdownGrace, 0*time.Second, "On Shutdown, duration to wait for resource clean up")
 
 	// support old flags name for backwards compatibility
@@ -63,6 +63,13 @@ type (
 		Close() error
 	}
 
+	// MempoolRestorer is implemented by the applications restoring the
+	// transactions of a persisted mempool on start, such as the ones embedding
+	// BaseApp.
+	MempoolRestorer interface {
+		RestoreMempool() error
+	}
+
 	// AppCreator is a function that allows us to lazily initialize an
 	// application using various configurations.
 	AppCreator[T Application] func(log.Logger, dbm.DB, io.Writer, AppOptions) T
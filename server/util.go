@@ -501,20 +501,9 @@ func DefaultBaseappOptions(appOpts types.AppOptions) []func(*baseapp.BaseApp) {
 		panic(err)
 	}
 
-	homeDir := cast.ToString(appOpts.Get(flags.FlagHome))
-	chainID := cast.ToString(appOpts.Get(flags.FlagChainID))
-	if chainID == "" {
-		// fallback to genesis chain-id
-		reader, err := os.Open(filepath.Join(homeDir, "config", "genesis.json"))
-		if err != nil {
-			panic(err)
-		}
-		defer reader.Close()
-
-		chainID, err = genutiltypes.ParseChainIDFromGenesis(reader)
-		if err != nil {
-			panic(fmt.Errorf("failed to parse chain-id from genesis file: %w", err))
-		}
+	chainID, err := GetChainID(appOpts)
+	if err != nil {
+		panic(err)
 	}
 
 	snapshotStore, err := GetSnapshotStore(appOpts)
@@ -525,15 +514,23 @@ func DefaultBaseappOptions(appOpts types.AppOptions) []func(*baseapp.BaseApp) {
 	snapshotOptions := snapshottypes.NewSnapshotOptions(
 		cast.ToUint64(appOpts.Get(FlagStateSyncSnapshotInterval)),
 		cast.ToUint32(appOpts.Get(FlagStateSyncSnapshotKeepRecent)),
-	)
+	).WithMaxDeltas(cast.ToUint32(appOpts.Get(FlagStateSyncSnapshotMaxDeltas)))
 
 	defaultMempool := baseapp.SetMempool(mempool.NoOpMempool{})
 	if maxTxs := cast.ToInt(appOpts.Get(FlagMempoolMaxTxs)); maxTxs >= 0 {
-		defaultMempool = baseapp.SetMempool(
-			mempool.NewSenderNonceMempool(
-				mempool.SenderNonceMaxTxOpt(maxTxs),
-			),
+		var mp mempool.Mempool = mempool.NewSenderNonceMempool(
+			mempool.SenderNonceMaxTxOpt(maxTxs),
 		)
+		if cast.ToBool(appOpts.Get(FlagMempoolPersist)) {
+			homeDir := cast.ToString(appOpts.Get(flags.FlagHome))
+			mp, err = mempool.NewPersistentMempool(mp, mempool.PersistentMempoolConfig{
+				Path: filepath.Join(homeDir, "data", "mempool.wal"),
+			})
+			if err != nil {
+				panic(err)
+			}
+		}
+		defaultMempool = baseapp.SetMempool(mp)
 	}
 
 	return []func(*baseapp.BaseApp){
@@ -554,6 +551,29 @@ func DefaultBaseappOptions(appOpts types.AppOptions) []func(*baseapp.BaseApp) {
 	}
 }
 
+// GetChainID returns the chain-id of the app options, falling back to the one
+// of the genesis file of the home directory.
+func GetChainID(appOpts types.AppOptions) (string, error) {
+	chainID := cast.ToString(appOpts.Get(flags.FlagChainID))
+	if chainID != "" {
+		return chainID, nil
+	}
+
+	homeDir := cast.ToString(appOpts.Get(flags.FlagHome))
+	reader, err := os.Open(filepath.Join(homeDir, "config", "genesis.json"))
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	chainID, err = genutiltypes.ParseChainIDFromGenesis(reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse chain-id from genesis file: %w", err)
+	}
+
+	return chainID, nil
+}
+
 func GetSnapshotStore(appOpts types.AppOptions) (*snapshots.Store, error) {
 	homeDir := cast.ToString(appOpts.Get(flags.FlagHome))
 	snapshotDir := filepath.Join(homeDir, "data", "snapshots")
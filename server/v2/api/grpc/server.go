@@ -12,20 +12,30 @@ import (
 	"cosmossdk.io/log"
 	serverv2 "cosmossdk.io/server/v2"
 	"cosmossdk.io/server/v2/api/grpc/gogoreflection"
+	"cosmossdk.io/server/v2/api/grpc/simulate"
 )
 
+// ServiceRegistrar is implemented by the apps registering gRPC services of
+// their own on the gRPC server.
+type ServiceRegistrar interface {
+	RegisterGRPCServices(registrar grpc.ServiceRegistrar) error
+}
+
 type GRPCServer[AppT serverv2.AppI[T], T transaction.Tx] struct {
 	logger     log.Logger
 	config     *Config
 	cfgOptions []CfgOption
+	txCodec    transaction.Codec[T]
 
 	grpcSrv *grpc.Server
 }
 
-// New creates a new grpc server.
-func New[AppT serverv2.AppI[T], T transaction.Tx](cfgOptions ...CfgOption) *GRPCServer[AppT, T] {
+// New creates a new grpc server. The tx codec decodes the txs of the simulation
+// service.
+func New[AppT serverv2.AppI[T], T transaction.Tx](txCodec transaction.Codec[T], cfgOptions ...CfgOption) *GRPCServer[AppT, T] {
 	return &GRPCServer[AppT, T]{
 		cfgOptions: cfgOptions,
+		txCodec:    txCodec,
 	}
 }
 
@@ -47,6 +57,19 @@ func (s *GRPCServer[AppT, T]) Init(appI AppT, v *viper.Viper, logger log.Logger)
 
 	// appI.RegisterGRPCServer(grpcSrv)
 
+	// the apps can register gRPC services of their own, such as the mempool
+	// service, by implementing ServiceRegistrar
+	if registrar, ok := any(appI).(ServiceRegistrar); ok {
+		if err := registrar.RegisterGRPCServices(grpcSrv); err != nil {
+			return fmt.Errorf("failed to register gRPC services: %w", err)
+		}
+	}
+
+	// the app supports the balance overrides of simulations if it implements
+	// simulate.BalanceOverrider
+	balances, _ := any(appI).(simulate.BalanceOverrider)
+	simulate.RegisterServiceServer(grpcSrv, simulate.NewService(appI.GetAppManager(), s.txCodec, balances))
+
 	// Reflection allows external clients to see what services and methods the gRPC server exposes.
 	gogoreflection.Register(grpcSrv)
 
@@ -0,0 +1,145 @@
+package simulate
+
+import (
+	"context"
+
+	gogoany "github.com/cosmos/gogoproto/types/any"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"cosmossdk.io/core/event"
+	"cosmossdk.io/core/header"
+	"cosmossdk.io/core/store"
+	"cosmossdk.io/core/transaction"
+	"cosmossdk.io/server/v2/appmanager"
+)
+
+// BalanceOverrider is implemented by the apps supporting the account balance
+// overrides of simulations.
+type BalanceOverrider interface {
+	// BalanceOverride returns the state changes setting the balance of a denom
+	// of an account to the given amount, as the app stores it.
+	BalanceOverride(address, denom, amount string) ([]store.StateChanges, error)
+}
+
+type service[T transaction.Tx] struct {
+	app      *appmanager.AppManager[T]
+	txCodec  transaction.Codec[T]
+	balances BalanceOverrider
+}
+
+// NewService returns the simulation service of the app. The balance overrider
+// can be nil, in which case the requests overriding balances are rejected.
+func NewService[T transaction.Tx](
+	app *appmanager.AppManager[T],
+	txCodec transaction.Codec[T],
+	balances BalanceOverrider,
+) ServiceServer {
+	return &service[T]{app: app, txCodec: txCodec, balances: balances}
+}
+
+// Simulate implements ServiceServer.
+func (s *service[T]) Simulate(ctx context.Context, req *SimulateRequest) (*SimulateResponse, error) {
+	if len(req.TxBytes) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "empty tx")
+	}
+	tx, err := s.txCodec.Decode(req.TxBytes)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "failed to decode tx: %v", err)
+	}
+
+	stateOverrides, err := s.stateOverrides(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var headerOverride *header.Info
+	if hdr := req.HeaderOverride; hdr != nil {
+		headerOverride = &header.Info{
+			Height:  hdr.Height,
+			Hash:    hdr.Hash,
+			AppHash: hdr.AppHash,
+			ChainID: hdr.ChainId,
+		}
+		if hdr.Time != nil {
+			headerOverride.Time = *hdr.Time
+		}
+	}
+
+	result, _, err := s.app.SimulateWithOverrides(ctx, tx, stateOverrides, headerOverride)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "failed to simulate tx: %v", err)
+	}
+
+	resp := &SimulateResponse{
+		GasWanted: result.GasWanted,
+		GasUsed:   result.GasUsed,
+		Events:    intoEvents(result.Events),
+	}
+	if result.Error != nil {
+		resp.Error = result.Error.Error()
+	}
+	for _, msgResp := range result.Resp {
+		// handlers can return no response
+		if msgResp == nil {
+			continue
+		}
+		anyResp, err := gogoany.NewAnyWithCacheWithValue(msgResp)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "failed to encode message response: %v", err)
+		}
+		resp.MsgResponses = append(resp.MsgResponses, anyResp)
+	}
+	return resp, nil
+}
+
+// stateOverrides returns the state changes of the store and balance overrides
+// of the request, in order.
+func (s *service[T]) stateOverrides(req *SimulateRequest) ([]store.StateChanges, error) {
+	var stateOverrides []store.StateChanges
+	for _, o := range req.StoreOverrides {
+		if o.StoreKey == "" || len(o.Key) == 0 {
+			return nil, status.Error(codes.InvalidArgument, "store overrides must have a store key and a key")
+		}
+		pair := store.KVPair{Key: o.Key, Value: o.Value, Remove: o.Delete}
+		if o.Delete {
+			pair.Value = nil
+		}
+		stateOverrides = append(stateOverrides, store.StateChanges{
+			Actor:        []byte(o.StoreKey),
+			StateChanges: store.KVPairs{pair},
+		})
+	}
+
+	if len(req.BalanceOverrides) == 0 {
+		return stateOverrides, nil
+	}
+	if s.balances == nil {
+		return nil, status.Error(codes.Unimplemented, "the app does not support balance overrides")
+	}
+	for _, o := range req.BalanceOverrides {
+		changes, err := s.balances.BalanceOverride(o.Address, o.Denom, o.Amount)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid balance override of %s: %v", o.Address, err)
+		}
+		stateOverrides = append(stateOverrides, changes...)
+	}
+	return stateOverrides, nil
+}
+
+func intoEvents(events []event.Event) []*Event {
+	if len(events) == 0 {
+		return nil
+	}
+	res := make([]*Event, len(events))
+	for i, e := range events {
+		attrs := make([]*EventAttribute, len(e.Attributes))
+		for j, attr := range e.Attributes {
+			attrs[j] = &EventAttribute{Key: attr.Key, Value: attr.Value}
+		}
+		res[i] = &Event{Type: e.Type, Attributes: attrs}
+	}
+	return res
+}
+
+var _ ServiceServer = (*service[transaction.Tx])(nil)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 
 	appmanager "cosmossdk.io/core/app"
+	"cosmossdk.io/core/header"
 	corestore "cosmossdk.io/core/store"
 	"cosmossdk.io/core/transaction"
 	"cosmossdk.io/server/v2/appmanager/store"
@@ -120,6 +121,24 @@ func (a AppManager[T]) Simulate(ctx context.Context, tx T) (appmanager.TxResult,
 	return result, cs, nil
 }
 
+// SimulateWithOverrides runs validation and execution flow of a Tx on the latest
+// state with the state overrides applied to it, and with the non-zero fields of
+// the header info override, if any, replacing the ones of the latest header
+// info. It allows what-if simulations, e.g. of txs of accounts without funds.
+func (a AppManager[T]) SimulateWithOverrides(
+	ctx context.Context,
+	tx T,
+	stateOverrides []corestore.StateChanges,
+	headerOverride *header.Info,
+) (appmanager.TxResult, corestore.WriterMap, error) {
+	_, state, err := a.db.StateLatest()
+	if err != nil {
+		return appmanager.TxResult{}, nil, err
+	}
+	result, cs := a.stf.SimulateWithOverrides(ctx, state, a.config.SimulationGasLimit, tx, stateOverrides, headerOverride)
+	return result, cs, nil
+}
+
 // Query queries the application at the provided version.
 // CONTRACT: Version must always be provided, if 0, get latest
 func (a AppManager[T]) Query(ctx context.Context, version uint64, request transaction.Msg) (transaction.Msg, error) {
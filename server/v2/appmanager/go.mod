@@ -4,12 +4,18 @@ go 1.21
 
 replace cosmossdk.io/core => ../../../core
 
-require cosmossdk.io/core v0.12.0
+require (
+	cosmossdk.io/core v0.12.0
+	github.com/stretchr/testify v1.9.0
+)
 
 require (
 	github.com/cosmos/gogoproto v1.5.0 // indirect
+	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
 	github.com/google/go-cmp v0.6.0 // indirect
+	github.com/kr/text v0.2.0 // indirect
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
 	golang.org/x/exp v0.0.0-20240314144324-c7f7c6466f7f // indirect
 	google.golang.org/protobuf v1.34.2 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,217 @@
+package appmanager
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+
+	appmanager "cosmossdk.io/core/app"
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/server/v2/appmanager/store"
+)
+
+// ReplayResult is the result of the re-execution of a committed block.
+type ReplayResult struct {
+	// Response is the response of the re-execution of the block.
+	Response *appmanager.BlockResponse
+	// StateChanges are the state changes of the re-execution of the block.
+	StateChanges []corestore.StateChanges
+	// StateDiffs are the keys whose committed change differs from their change
+	// in the re-execution of the block, sorted by actor and key.
+	StateDiffs []StateDiff
+}
+
+// StateDiff is a key whose committed change differs from its change in the
+// re-execution of a block.
+type StateDiff struct {
+	Actor []byte
+	Key   []byte
+	// Committed is the committed change of the key, nil if the key was not
+	// changed by the committed block.
+	Committed *corestore.KVPair
+	// Replayed is the change of the key in the re-execution, nil if the key was
+	// not changed by the re-execution.
+	Replayed *corestore.KVPair
+}
+
+// ReplayBlock re-executes a committed block against the state at the version
+// preceding it, and diffs the resulting state changes against the ones which
+// were committed, to debug non-determinism and consensus failures. The block
+// must be the one committed at its height, with the same context, and the
+// state of the preceding version must not have been pruned.
+//
+// If the store implements store.ChangesetReader, the state changes are diffed
+// against the committed changeset, which does not include the changes of the
+// memory stores. The removals of keys absent from the state of the preceding
+// version, which the changeset may or may not record, are dropped from both
+// before diffing. Otherwise the keys changed by the re-execution are diffed
+// against the state committed at the height of the block, whose value is then
+// the Committed change of a diff: the keys changed by the committed block only
+// are not detected.
+func (a AppManager[T]) ReplayBlock(
+	ctx context.Context,
+	block *appmanager.BlockRequest[T],
+) (*ReplayResult, error) {
+	if block.Height == 0 {
+		return nil, fmt.Errorf("cannot replay block at height 0")
+	}
+	state, err := a.db.StateAt(block.Height - 1)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get state at height %d: %w", block.Height-1, err)
+	}
+
+	blockResponse, newState, err := a.stf.DeliverBlock(ctx, block, state)
+	if err != nil {
+		return nil, fmt.Errorf("block delivery failed: %w", err)
+	}
+	stateChanges, err := newState.GetStateChanges()
+	if err != nil {
+		return nil, err
+	}
+	result := &ReplayResult{Response: blockResponse, StateChanges: stateChanges}
+
+	if reader, ok := a.db.(store.ChangesetReader); ok {
+		committed, err := reader.GetChangeset(block.Height)
+		if err != nil {
+			return nil, fmt.Errorf("unable to get changeset at height %d: %w", block.Height, err)
+		}
+		committedChanges, err := dropNoOpRemovals(state, committed.Changes)
+		if err != nil {
+			return nil, err
+		}
+		replayedChanges, err := dropNoOpRemovals(state, stateChanges)
+		if err != nil {
+			return nil, err
+		}
+		result.StateDiffs = DiffStateChanges(committedChanges, replayedChanges)
+		return result, nil
+	}
+
+	committedState, err := a.db.StateAt(block.Height)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get state at height %d: %w", block.Height, err)
+	}
+	result.StateDiffs, err = diffState(committedState, stateChanges)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// DiffStateChanges returns the keys whose change differs between the committed
+// and the replayed state changes, sorted by actor and key. A removal equals
+// another removal whatever their values.
+func DiffStateChanges(committed, replayed []corestore.StateChanges) []StateDiff {
+	committedPairs, replayedPairs := indexStateChanges(committed), indexStateChanges(replayed)
+
+	var diffs []StateDiff
+	for k, c := range committedPairs {
+		r, ok := replayedPairs[k]
+		if ok && equalPairs(c, r) {
+			continue
+		}
+		c, r := c, r
+		diff := StateDiff{Actor: []byte(k.actor), Key: []byte(k.key), Committed: &c}
+		if ok {
+			diff.Replayed = &r
+		}
+		diffs = append(diffs, diff)
+	}
+	for k, r := range replayedPairs {
+		if _, ok := committedPairs[k]; !ok {
+			r := r
+			diffs = append(diffs, StateDiff{Actor: []byte(k.actor), Key: []byte(k.key), Replayed: &r})
+		}
+	}
+
+	sortStateDiffs(diffs)
+	return diffs
+}
+
+// dropNoOpRemovals returns the state changes without the removals of the keys
+// absent from the provided state, which do not change it, and without the
+// actors left with no change.
+func dropNoOpRemovals(state corestore.ReaderMap, stateChanges []corestore.StateChanges) ([]corestore.StateChanges, error) {
+	var normalized []corestore.StateChanges
+	for _, sc := range stateChanges {
+		reader, err := state.GetReader(sc.Actor)
+		if err != nil {
+			return nil, err
+		}
+		var pairs corestore.KVPairs
+		for _, pair := range sc.StateChanges {
+			if pair.Remove {
+				has, err := reader.Has(pair.Key)
+				if err != nil {
+					return nil, err
+				}
+				if !has {
+					continue
+				}
+			}
+			pairs = append(pairs, pair)
+		}
+		if len(pairs) > 0 {
+			normalized = append(normalized, corestore.StateChanges{Actor: sc.Actor, StateChanges: pairs})
+		}
+	}
+	return normalized, nil
+}
+
+// diffState returns the keys whose change differs from their value in the
+// provided state, sorted by actor and key.
+func diffState(state corestore.ReaderMap, replayed []corestore.StateChanges) ([]StateDiff, error) {
+	var diffs []StateDiff
+	for _, sc := range replayed {
+		reader, err := state.GetReader(sc.Actor)
+		if err != nil {
+			return nil, err
+		}
+		for _, pair := range sc.StateChanges {
+			value, err := reader.Get(pair.Key)
+			if err != nil {
+				return nil, err
+			}
+			pair, committed := pair, corestore.KVPair{Key: pair.Key, Value: value, Remove: value == nil}
+			if equalPairs(committed, pair) {
+				continue
+			}
+			diffs = append(diffs, StateDiff{Actor: sc.Actor, Key: pair.Key, Committed: &committed, Replayed: &pair})
+		}
+	}
+
+	sortStateDiffs(diffs)
+	return diffs, nil
+}
+
+type actorKey struct {
+	actor, key string
+}
+
+// indexStateChanges indexes the last change of each key of the state changes.
+func indexStateChanges(stateChanges []corestore.StateChanges) map[actorKey]corestore.KVPair {
+	pairs := make(map[actorKey]corestore.KVPair)
+	for _, sc := range stateChanges {
+		for _, pair := range sc.StateChanges {
+			pairs[actorKey{actor: string(sc.Actor), key: string(pair.Key)}] = pair
+		}
+	}
+	return pairs
+}
+
+func equalPairs(a, b corestore.KVPair) bool {
+	if a.Remove || b.Remove {
+		return a.Remove == b.Remove
+	}
+	return bytes.Equal(a.Value, b.Value)
+}
+
+func sortStateDiffs(diffs []StateDiff) {
+	sort.Slice(diffs, func(i, j int) bool {
+		if c := bytes.Compare(diffs[i].Actor, diffs[j].Actor); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(diffs[i].Key, diffs[j].Key) < 0
+	})
+}
@@ -0,0 +1,183 @@
+package appmanager
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	appmanager "cosmossdk.io/core/app"
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/core/transaction"
+)
+
+func TestDiffStateChanges(t *testing.T) {
+	committed := []corestore.StateChanges{
+		{Actor: []byte("bank"), StateChanges: corestore.KVPairs{
+			{Key: []byte("a"), Value: []byte("1")},
+			{Key: []byte("b"), Value: []byte("1")},
+			{Key: []byte("c"), Remove: true},
+		}},
+		{Actor: []byte("auth"), StateChanges: corestore.KVPairs{
+			{Key: []byte("x"), Value: []byte("1")},
+		}},
+	}
+	replayed := []corestore.StateChanges{
+		{Actor: []byte("auth"), StateChanges: corestore.KVPairs{
+			{Key: []byte("x"), Value: []byte("1")},
+			{Key: []byte("y"), Value: []byte("1")},
+		}},
+		{Actor: []byte("bank"), StateChanges: corestore.KVPairs{
+			{Key: []byte("c"), Value: []byte("removed"), Remove: true},
+			{Key: []byte("a"), Value: []byte("2")},
+		}},
+	}
+
+	require.Equal(t, []StateDiff{
+		{Actor: []byte("auth"), Key: []byte("y"), Replayed: &corestore.KVPair{Key: []byte("y"), Value: []byte("1")}},
+		{
+			Actor:     []byte("bank"),
+			Key:       []byte("a"),
+			Committed: &corestore.KVPair{Key: []byte("a"), Value: []byte("1")},
+			Replayed:  &corestore.KVPair{Key: []byte("a"), Value: []byte("2")},
+		},
+		{Actor: []byte("bank"), Key: []byte("b"), Committed: &corestore.KVPair{Key: []byte("b"), Value: []byte("1")}},
+	}, DiffStateChanges(committed, replayed))
+
+	require.Empty(t, DiffStateChanges(committed, committed))
+}
+
+func TestReplayBlock(t *testing.T) {
+	// block 2 was executed against the state at height 1, and committed its changeset
+	db := &mockStore{
+		states: map[uint64]mockState{
+			1: {"bank": {"a": []byte("1"), "b": []byte("1")}},
+			2: {"bank": {"a": []byte("2")}},
+		},
+		changesets: map[uint64][]corestore.StateChanges{
+			2: {{Actor: []byte("bank"), StateChanges: corestore.KVPairs{
+				{Key: []byte("a"), Value: []byte("2")},
+				{Key: []byte("b"), Remove: true},
+			}}},
+		},
+	}
+	stf := &mockSTF{}
+	am := AppManager[transaction.Tx]{db: db, stf: stf}
+	block := &appmanager.BlockRequest[transaction.Tx]{Height: 2}
+
+	// the removals of absent keys, and the actors with no other change, are
+	// not diffs
+	stf.changes = []corestore.StateChanges{
+		{Actor: []byte("bank"), StateChanges: corestore.KVPairs{
+			{Key: []byte("a"), Value: []byte("2")},
+			{Key: []byte("b"), Remove: true},
+			{Key: []byte("c"), Remove: true},
+		}},
+		{Actor: []byte("auth"), StateChanges: corestore.KVPairs{
+			{Key: []byte("x"), Remove: true},
+		}},
+	}
+	result, err := am.ReplayBlock(context.Background(), block)
+	require.NoError(t, err)
+	require.Empty(t, result.StateDiffs)
+	require.Equal(t, stf.changes, result.StateChanges)
+	require.Equal(t, uint64(1), stf.height)
+
+	// a non-deterministic write is reported
+	stf.changes = []corestore.StateChanges{
+		{Actor: []byte("bank"), StateChanges: corestore.KVPairs{
+			{Key: []byte("a"), Value: []byte("3")},
+			{Key: []byte("b"), Remove: true},
+		}},
+	}
+	result, err = am.ReplayBlock(context.Background(), block)
+	require.NoError(t, err)
+	require.Equal(t, []StateDiff{{
+		Actor:     []byte("bank"),
+		Key:       []byte("a"),
+		Committed: &corestore.KVPair{Key: []byte("a"), Value: []byte("2")},
+		Replayed:  &corestore.KVPair{Key: []byte("a"), Value: []byte("3")},
+	}}, result.StateDiffs)
+
+	// the state of the preceding height must exist
+	_, err = am.ReplayBlock(context.Background(), &appmanager.BlockRequest[transaction.Tx]{Height: 4})
+	require.ErrorContains(t, err, "unable to get state at height 3")
+	_, err = am.ReplayBlock(context.Background(), &appmanager.BlockRequest[transaction.Tx]{Height: 0})
+	require.Error(t, err)
+}
+
+// mockStore is a store keeping the state and the changeset of each version.
+type mockStore struct {
+	states     map[uint64]mockState
+	changesets map[uint64][]corestore.StateChanges
+}
+
+func (s *mockStore) StateLatest() (uint64, corestore.ReaderMap, error) {
+	return 0, nil, fmt.Errorf("not implemented")
+}
+
+func (s *mockStore) StateAt(version uint64) (corestore.ReaderMap, error) {
+	state, ok := s.states[version]
+	if !ok {
+		return nil, fmt.Errorf("version %d does not exist", version)
+	}
+	return state, nil
+}
+
+func (s *mockStore) GetChangeset(version uint64) (*corestore.Changeset, error) {
+	changes, ok := s.changesets[version]
+	if !ok {
+		return nil, fmt.Errorf("version %d does not exist", version)
+	}
+	return &corestore.Changeset{Changes: changes}, nil
+}
+
+// mockState is the state of a version, the values of the keys of each actor.
+type mockState map[string]map[string][]byte
+
+func (s mockState) GetReader(actor []byte) (corestore.Reader, error) {
+	return mockReader{values: s[string(actor)]}, nil
+}
+
+type mockReader struct {
+	corestore.Reader
+	values map[string][]byte
+}
+
+func (r mockReader) Has(key []byte) (bool, error) {
+	_, ok := r.values[string(key)]
+	return ok, nil
+}
+
+func (r mockReader) Get(key []byte) ([]byte, error) {
+	return r.values[string(key)], nil
+}
+
+// mockSTF delivers the blocks by writing the provided state changes.
+type mockSTF struct {
+	StateTransitionFunction[transaction.Tx]
+	changes []corestore.StateChanges
+	height  uint64 // height of the state the last block was delivered on
+}
+
+func (s *mockSTF) DeliverBlock(
+	_ context.Context,
+	block *appmanager.BlockRequest[transaction.Tx],
+	state corestore.ReaderMap,
+) (*appmanager.BlockResponse, corestore.WriterMap, error) {
+	s.height = block.Height - 1
+	if _, ok := state.(mockState); !ok {
+		return nil, nil, fmt.Errorf("unexpected state %T", state)
+	}
+	return &appmanager.BlockResponse{}, mockWriterMap{changes: s.changes}, nil
+}
+
+type mockWriterMap struct {
+	corestore.WriterMap
+	changes []corestore.StateChanges
+}
+
+func (m mockWriterMap) GetStateChanges() ([]corestore.StateChanges, error) {
+	return m.changes, nil
+}
@@ -15,3 +15,10 @@ type Store interface {
 	// state. Must error when the version does not exist.
 	StateAt(version uint64) (store.ReaderMap, error)
 }
+
+// ChangesetReader is optionally implemented by the stores which keep the changes
+// committed at each version, against which blocks are replayed.
+type ChangesetReader interface {
+	// GetChangeset returns the changes committed at the provided version.
+	GetChangeset(version uint64) (*store.Changeset, error)
+}
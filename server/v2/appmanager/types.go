@@ -4,6 +4,7 @@ import (
 	"context"
 
 	appmanager "cosmossdk.io/core/app"
+	"cosmossdk.io/core/header"
 	"cosmossdk.io/core/store"
 	"cosmossdk.io/core/transaction"
 )
@@ -33,6 +34,18 @@ type StateTransitionFunction[T transaction.Tx] interface {
 		tx T,
 	) (appmanager.TxResult, store.WriterMap)
 
+	// SimulateWithOverrides executes a transaction in simulation mode, with the
+	// state overrides applied to the state and the non-zero fields of the header
+	// info override replacing the ones of the header info of the state.
+	SimulateWithOverrides(
+		ctx context.Context,
+		state store.ReaderMap,
+		gasLimit uint64,
+		tx T,
+		stateOverrides []store.StateChanges,
+		headerOverride *header.Info,
+	) (appmanager.TxResult, store.WriterMap)
+
 	// Query executes a query on the application.
 	Query(
 		ctx context.Context,
@@ -0,0 +1,261 @@
+package cometbft
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	abci "github.com/cometbft/cometbft/api/cometbft/abci/v1"
+	cmtcfg "github.com/cometbft/cometbft/config"
+	cmtbytes "github.com/cometbft/cometbft/libs/bytes"
+	sm "github.com/cometbft/cometbft/state"
+	cmtstore "github.com/cometbft/cometbft/store"
+	"github.com/spf13/cobra"
+
+	coreappmgr "cosmossdk.io/core/app"
+	"cosmossdk.io/core/comet"
+	"cosmossdk.io/core/store"
+	serverv2 "cosmossdk.io/server/v2"
+	"cosmossdk.io/server/v2/appmanager"
+)
+
+// ReplayReport is the diff of the re-execution of a committed block against
+// what was committed.
+type ReplayReport struct {
+	Height int64 `json:"height"`
+	// StateDiffs are the keys whose committed change differs from their change
+	// in the re-execution, sorted by store key and key.
+	StateDiffs []StateDiff `json:"state_diffs"`
+	// ResultDiffs are the fields of the committed block results which differ
+	// from the results of the re-execution.
+	ResultDiffs []ResultDiff `json:"result_diffs"`
+}
+
+// StateDiff is a key whose committed change differs from its change in the
+// re-execution of a block.
+type StateDiff struct {
+	StoreKey string            `json:"store_key"`
+	Key      cmtbytes.HexBytes `json:"key"`
+	// Committed and Replayed are the changes of the key, nil if the key was not
+	// changed.
+	Committed *KeyChange `json:"committed"`
+	Replayed  *KeyChange `json:"replayed"`
+}
+
+// KeyChange is the change of a key, its new value or its removal.
+type KeyChange struct {
+	Value   cmtbytes.HexBytes `json:"value,omitempty"`
+	Removed bool              `json:"removed,omitempty"`
+}
+
+// ResultDiff is a field of the committed block results which differs from the
+// results of the re-execution of the block.
+type ResultDiff struct {
+	Field     string          `json:"field"`
+	Committed json.RawMessage `json:"committed"`
+	Replayed  json.RawMessage `json:"replayed"`
+}
+
+// ReplayCmd returns the command re-executing a committed block against the
+// state at the preceding height and diffing its results against the committed
+// ones. As the start command, it creates the app with newApp and initializes
+// the server with it.
+func (s *CometBFTServer[AppT, T]) ReplayCmd(newApp serverv2.AppCreator[AppT, T]) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "replay [height]",
+		Short: "Re-execute a committed block and diff its results against the committed ones",
+		Long: `Re-execute the block committed at the given height against the app state at
+the preceding height, and diff the resulting state changes, events and tx results
+against the committed ones, to debug non-determinism and consensus failures.
+
+The node must be stopped. The app state at the preceding height must not have
+been pruned, and the block results must have been kept by CometBFT, i.e.
+discard_abci_responses must be false.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			height, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return fmt.Errorf("invalid height %s: %w", args[0], err)
+			}
+
+			v := serverv2.GetViperFromCmd(cmd)
+			logger := serverv2.GetLoggerFromCmd(cmd)
+			app := newApp(logger, v)
+			if closer, ok := app.GetStore().(io.Closer); ok {
+				defer closer.Close()
+			}
+			if err := s.Init(app, v, logger); err != nil {
+				return err
+			}
+
+			report, err := s.Consensus.replayBlock(cmd.Context(), s.config.CmtConfig, height)
+			if err != nil {
+				return err
+			}
+
+			bz, err := json.Marshal(report)
+			if err != nil {
+				return err
+			}
+			return printOutput(cmd, bz)
+		},
+	}
+
+	cmd.Flags().StringP(FlagOutput, "o", "json", "Output format (text|json)")
+
+	return cmd
+}
+
+// replayBlock re-executes the block committed at the given height, read from
+// the CometBFT databases, and diffs its results against the committed ones.
+func (c *Consensus[T]) replayBlock(ctx context.Context, cfg *cmtcfg.Config, height int64) (*ReplayReport, error) {
+	if height <= int64(c.cfg.InitialHeight) {
+		return nil, fmt.Errorf("cannot replay block %d: the blocks up to the initial height %d are not executed", height, c.cfg.InitialHeight)
+	}
+
+	blockStoreDB, err := cmtcfg.DefaultDBProvider(&cmtcfg.DBContext{ID: "blockstore", Config: cfg})
+	if err != nil {
+		return nil, err
+	}
+	defer blockStoreDB.Close()
+	stateDB, err := cmtcfg.DefaultDBProvider(&cmtcfg.DBContext{ID: "state", Config: cfg})
+	if err != nil {
+		return nil, err
+	}
+	defer stateDB.Close()
+
+	blockStore := cmtstore.NewBlockStore(blockStoreDB)
+	stateStore := sm.NewStore(stateDB, sm.StoreOptions{DiscardABCIResponses: false})
+
+	block, _ := blockStore.LoadBlock(height)
+	if block == nil {
+		return nil, fmt.Errorf("block %d not found", height)
+	}
+	committed, err := stateStore.LoadFinalizeBlockResponse(height)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the results of block %d: %w", height, err)
+	}
+	lastValSet, err := stateStore.LoadValidators(height - 1)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the validators at height %d: %w", height-1, err)
+	}
+
+	decodedTxs, err := decodeTxs(block.Txs.ToSliceOfBytes(), c.txCodec)
+	if err != nil {
+		return nil, err
+	}
+
+	// the block is delivered as in FinalizeBlock
+	blockReq := &coreappmgr.BlockRequest[T]{
+		Height:  uint64(height),
+		Time:    block.Time,
+		Hash:    block.Hash(),
+		AppHash: block.AppHash,
+		ChainId: block.ChainID,
+		Txs:     decodedTxs,
+	}
+	ciCtx := contextWithCometInfo(ctx, comet.Info{
+		Evidence:        toCoreEvidence(block.Evidence.Evidence.ToABCI()),
+		ValidatorsHash:  block.NextValidatorsHash,
+		ProposerAddress: block.ProposerAddress,
+		LastCommit:      toCoreCommitInfo(sm.BuildLastCommitInfo(block, lastValSet, int64(c.cfg.InitialHeight))),
+	})
+
+	result, err := c.app.ReplayBlock(ciCtx, blockReq)
+	if err != nil {
+		return nil, err
+	}
+	replayed, err := finalizeBlockResponse(result.Response, nil, nil, c.cfg.IndexEvents)
+	if err != nil {
+		return nil, err
+	}
+
+	resultDiffs, err := diffBlockResults(committed, replayed)
+	if err != nil {
+		return nil, err
+	}
+	return &ReplayReport{
+		Height:      height,
+		StateDiffs:  intoStateDiffs(result.StateDiffs),
+		ResultDiffs: resultDiffs,
+	}, nil
+}
+
+// diffBlockResults returns the fields of the committed block results which
+// differ from the replayed ones. The app hash and the consensus parameter
+// updates, which do not result from the execution of the block, are ignored.
+func diffBlockResults(committed, replayed *abci.FinalizeBlockResponse) ([]ResultDiff, error) {
+	diffs := &resultDiffs{}
+	diffs.add("events", committed.Events, replayed.Events)
+	diffs.add("validator_updates", committed.ValidatorUpdates, replayed.ValidatorUpdates)
+
+	if len(committed.TxResults) != len(replayed.TxResults) {
+		diffs.add("tx_results.length", len(committed.TxResults), len(replayed.TxResults))
+		return diffs.diffs, diffs.err
+	}
+	for i, c := range committed.TxResults {
+		r := replayed.TxResults[i]
+		field := fmt.Sprintf("tx_results[%d].", i)
+		diffs.add(field+"code", c.Code, r.Code)
+		diffs.add(field+"codespace", c.Codespace, r.Codespace)
+		diffs.add(field+"data", c.Data, r.Data)
+		diffs.add(field+"log", c.Log, r.Log)
+		diffs.add(field+"gas_wanted", c.GasWanted, r.GasWanted)
+		diffs.add(field+"gas_used", c.GasUsed, r.GasUsed)
+		diffs.add(field+"events", c.Events, r.Events)
+	}
+
+	return diffs.diffs, diffs.err
+}
+
+// resultDiffs accumulates the fields whose committed and replayed values differ
+// once encoded to JSON.
+type resultDiffs struct {
+	diffs []ResultDiff
+	err   error
+}
+
+func (d *resultDiffs) add(field string, committed, replayed any) {
+	if d.err != nil {
+		return
+	}
+	c, err := json.Marshal(committed)
+	if err != nil {
+		d.err = fmt.Errorf("failed to encode committed %s: %w", field, err)
+		return
+	}
+	r, err := json.Marshal(replayed)
+	if err != nil {
+		d.err = fmt.Errorf("failed to encode replayed %s: %w", field, err)
+		return
+	}
+	if !bytes.Equal(c, r) {
+		d.diffs = append(d.diffs, ResultDiff{Field: field, Committed: c, Replayed: r})
+	}
+}
+
+func intoStateDiffs(diffs []appmanager.StateDiff) []StateDiff {
+	stateDiffs := make([]StateDiff, len(diffs))
+	for i, diff := range diffs {
+		stateDiffs[i] = StateDiff{
+			StoreKey:  string(diff.Actor),
+			Key:       diff.Key,
+			Committed: intoKeyChange(diff.Committed),
+			Replayed:  intoKeyChange(diff.Replayed),
+		}
+	}
+	return stateDiffs
+}
+
+func intoKeyChange(pair *store.KVPair) *KeyChange {
+	if pair == nil {
+		return nil
+	}
+	if pair.Remove {
+		return &KeyChange{Removed: true}
+	}
+	return &KeyChange{Value: pair.Value}
+}
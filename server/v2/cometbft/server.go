@@ -34,7 +34,10 @@ var (
 		serverv2.AppI[transaction.Tx], transaction.Tx,
 	] = (*CometBFTServer[serverv2.AppI[transaction.Tx], transaction.Tx])(nil)
 	_ serverv2.HasCLICommands = (*CometBFTServer[serverv2.AppI[transaction.Tx], transaction.Tx])(nil)
-	_ serverv2.HasStartFlags  = (*CometBFTServer[serverv2.AppI[transaction.Tx], transaction.Tx])(nil)
+	_ serverv2.HasAppCLICommands[
+		serverv2.AppI[transaction.Tx], transaction.Tx,
+	] = (*CometBFTServer[serverv2.AppI[transaction.Tx], transaction.Tx])(nil)
+	_ serverv2.HasStartFlags = (*CometBFTServer[serverv2.AppI[transaction.Tx], transaction.Tx])(nil)
 )
 
 type CometBFTServer[AppT serverv2.AppI[T], T transaction.Tx] struct {
@@ -208,6 +211,14 @@ func (s *CometBFTServer[AppT, T]) CLICommands() serverv2.CLIConfig {
 	}
 }
 
+func (s *CometBFTServer[AppT, T]) AppCLICommands(newApp serverv2.AppCreator[AppT, T]) serverv2.CLIConfig {
+	return serverv2.CLIConfig{
+		Commands: []*cobra.Command{
+			s.ReplayCmd(newApp),
+		},
+	}
+}
+
 func (s *CometBFTServer[AppT, T]) WriteDefaultConfigAt(configPath string) error {
 	cometConfig := cmtcfg.DefaultConfig()
 	for _, opt := range s.cmtConfigOptions {
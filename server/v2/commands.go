@@ -65,7 +65,7 @@ func AddCommands[AppT AppI[T], T transaction.Tx](
 		return originalPersistentPreRunE(cmd, args)
 	}
 
-	cmds := server.CLICommands()
+	cmds := server.cliCommands(newApp)
 	startCmd := createStartCommand(server, newApp)
 	startCmd.SetContext(rootCmd.Context())
 	cmds.Commands = append(cmds.Commands, startCmd)
@@ -31,6 +31,12 @@ type HasCLICommands interface {
 	CLICommands() CLIConfig
 }
 
+// HasAppCLICommands is a server module that has CLI commands which, like the
+// start command, need the app and create it with the provided AppCreator.
+type HasAppCLICommands[AppT AppI[T], T transaction.Tx] interface {
+	AppCLICommands(AppCreator[AppT, T]) CLIConfig
+}
+
 // HasConfig is a server module that has a config.
 type HasConfig interface {
 	Config() any
@@ -119,6 +125,12 @@ func (s *Server[AppT, T]) Stop(ctx context.Context) error {
 
 // CLICommands returns all CLI commands of all components.
 func (s *Server[AppT, T]) CLICommands() CLIConfig {
+	return s.cliCommands(nil)
+}
+
+// cliCommands returns all CLI commands of all components, including the ones
+// creating the app with the provided AppCreator if it is not nil.
+func (s *Server[AppT, T]) cliCommands(newApp AppCreator[AppT, T]) CLIConfig {
 	compart := func(name string, cmds ...*cobra.Command) *cobra.Command {
 		if len(cmds) == 1 && strings.HasPrefix(cmds[0].Use, name) {
 			return cmds[0]
@@ -135,20 +147,27 @@ func (s *Server[AppT, T]) CLICommands() CLIConfig {
 
 	commands := CLIConfig{}
 	for _, mod := range s.components {
+		var srvCmd CLIConfig
 		if climod, ok := mod.(HasCLICommands); ok {
-			srvCmd := climod.CLICommands()
+			srvCmd = climod.CLICommands()
+		}
+		if appmod, ok := mod.(HasAppCLICommands[AppT, T]); ok && newApp != nil {
+			appCmd := appmod.AppCLICommands(newApp)
+			srvCmd.Commands = append(srvCmd.Commands, appCmd.Commands...)
+			srvCmd.Txs = append(srvCmd.Txs, appCmd.Txs...)
+			srvCmd.Queries = append(srvCmd.Queries, appCmd.Queries...)
+		}
 
-			if len(srvCmd.Commands) > 0 {
-				commands.Commands = append(commands.Commands, compart(mod.Name(), srvCmd.Commands...))
-			}
+		if len(srvCmd.Commands) > 0 {
+			commands.Commands = append(commands.Commands, compart(mod.Name(), srvCmd.Commands...))
+		}
 
-			if len(srvCmd.Txs) > 0 {
-				commands.Txs = append(commands.Txs, compart(mod.Name(), srvCmd.Txs...))
-			}
+		if len(srvCmd.Txs) > 0 {
+			commands.Txs = append(commands.Txs, compart(mod.Name(), srvCmd.Txs...))
+		}
 
-			if len(srvCmd.Queries) > 0 {
-				commands.Queries = append(commands.Queries, compart(mod.Name(), srvCmd.Queries...))
-			}
+		if len(srvCmd.Queries) > 0 {
+			commands.Queries = append(commands.Queries, compart(mod.Name(), srvCmd.Queries...))
 		}
 	}
 
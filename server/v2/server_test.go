@@ -58,7 +58,7 @@ func TestServer(t *testing.T) {
 	}
 
 	logger := log.NewLogger(os.Stdout)
-	grpcServer := grpc.New[serverv2.AppI[transaction.Tx], transaction.Tx]()
+	grpcServer := grpc.New[serverv2.AppI[transaction.Tx], transaction.Tx](nil)
 	if err := grpcServer.Init(&mockApp[transaction.Tx]{}, v, logger); err != nil {
 		t.Log(err)
 		t.Fail()
@@ -0,0 +1,215 @@
+// Package blockstm executes the txs of a block concurrently, in the style of
+// Block-STM, with the same results as their sequential execution.
+//
+// Every tx is first executed optimistically over a view of the state made of
+// the writes of the lower txs, held in a multi-version memory, recording the
+// keys it reads. The txs are then validated: a tx whose reads are not the ones
+// visible to it anymore, because a lower tx wrote them since, is re-executed.
+// Its previous writes are marked as estimates until then, so that the higher
+// txs reading them wait for the re-execution instead of reading stale values.
+// Execution and validation rounds alternate until all the txs are valid, every
+// round finalizing at least the lowest tx re-executed in it.
+//
+// The txs must only depend on the state and on the block: the results of txs
+// relying on memory shared across txs are undefined.
+package blockstm
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	"cosmossdk.io/core/store"
+)
+
+// ExecuteFn executes the tx of the given index on the given state, and returns
+// its state changes. It is called again for every re-execution of the tx, but
+// never concurrently for the same tx.
+type ExecuteFn func(txIndex int, state store.ReaderMap) ([]store.StateChanges, error)
+
+type executor struct {
+	ctx     context.Context
+	base    store.ReaderMap
+	workers int
+	execute ExecuteFn
+
+	mv           *mvMemory
+	incarnations []int
+	reads        []*readSet
+	changes      [][]store.StateChanges
+	// done are closed once the txs re-executed in the current round are executed.
+	done    []chan struct{}
+	aborted atomic.Bool
+}
+
+// Execute executes txs txs with up to workers concurrent executions over the
+// base state, which must be safe for concurrent reads, and returns the state
+// changes of each tx. The base state with the state changes applied in order
+// is the state resulting from the sequential execution of the txs.
+func Execute(ctx context.Context, base store.ReaderMap, txs, workers int, execute ExecuteFn) ([][]store.StateChanges, error) {
+	e := &executor{
+		ctx:          ctx,
+		base:         newSyncReaderMap(base),
+		workers:      max(workers, 1),
+		execute:      execute,
+		mv:           newMVMemory(txs),
+		incarnations: make([]int, txs),
+		reads:        make([]*readSet, txs),
+		changes:      make([][]store.StateChanges, txs),
+		done:         make([]chan struct{}, txs),
+	}
+
+	pending := make([]int, txs)
+	for i := range pending {
+		pending[i] = i
+	}
+	for len(pending) > 0 {
+		for _, txIndex := range pending {
+			e.mv.markEstimates(txIndex)
+			e.done[txIndex] = make(chan struct{})
+		}
+		if err := e.executeRound(pending); err != nil {
+			return nil, err
+		}
+
+		var err error
+		if pending, err = e.validateFrom(pending[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	return e.changes, nil
+}
+
+// executeRound executes the given txs, in ascending order, so that a tx waiting
+// for the re-execution of a lower tx never waits for a tx not being executed.
+func (e *executor) executeRound(txs []int) error {
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+		next     = make(chan int)
+	)
+	fail := func(err error) {
+		errOnce.Do(func() { firstErr = err })
+		e.aborted.Store(true)
+	}
+
+	for w := 0; w < min(e.workers, len(txs)); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for txIndex := range next {
+				if err := e.executeTx(txIndex); err != nil {
+					fail(err)
+				}
+			}
+		}()
+	}
+
+	for _, txIndex := range txs {
+		if err := e.ctx.Err(); err != nil {
+			fail(err)
+		}
+		if e.aborted.Load() {
+			// the txs left are still marked as done, for the executing txs waiting for them
+			close(e.done[txIndex])
+			continue
+		}
+		next <- txIndex
+	}
+	close(next)
+	wg.Wait()
+
+	return firstErr
+}
+
+func (e *executor) executeTx(txIndex int) error {
+	defer close(e.done[txIndex])
+
+	e.incarnations[txIndex]++
+	reads := &readSet{}
+	changes, err := e.execute(txIndex, &view{executor: e, txIndex: txIndex, reads: reads})
+	if err != nil {
+		return err
+	}
+	if e.aborted.Load() {
+		return errAborted
+	}
+
+	e.mv.record(version{txIndex: txIndex, incarnation: e.incarnations[txIndex]}, changes)
+	e.reads[txIndex] = reads
+	e.changes[txIndex] = changes
+	return nil
+}
+
+// wait waits for the tx being re-executed to be executed.
+func (e *executor) wait(txIndex int) error {
+	<-e.done[txIndex]
+	if e.aborted.Load() {
+		return errAborted
+	}
+	return nil
+}
+
+// validateFrom validates the txs from the given index, all the lower txs being
+// valid, and returns the invalid ones in ascending order.
+func (e *executor) validateFrom(from int) ([]int, error) {
+	txs := len(e.reads)
+	valid := make([]bool, txs)
+	errs := make([]error, txs)
+
+	var (
+		wg   sync.WaitGroup
+		next atomic.Int64
+	)
+	next.Store(int64(from))
+	for w := 0; w < min(e.workers, txs-from); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for txIndex := int(next.Add(1) - 1); txIndex < txs; txIndex = int(next.Add(1) - 1) {
+				valid[txIndex], errs[txIndex] = e.validate(txIndex, e.reads[txIndex])
+			}
+		}()
+	}
+	wg.Wait()
+
+	var invalid []int
+	for txIndex := from; txIndex < txs; txIndex++ {
+		if errs[txIndex] != nil {
+			return nil, errs[txIndex]
+		}
+		if !valid[txIndex] {
+			invalid = append(invalid, txIndex)
+		}
+	}
+	return invalid, nil
+}
+
+// syncReaderMap memoizes the readers of a store.ReaderMap, whose GetReader may
+// not be safe for concurrent use.
+type syncReaderMap struct {
+	mtx     sync.Mutex
+	state   store.ReaderMap
+	readers map[string]store.Reader
+}
+
+func newSyncReaderMap(state store.ReaderMap) *syncReaderMap {
+	return &syncReaderMap{state: state, readers: make(map[string]store.Reader)}
+}
+
+func (m *syncReaderMap) GetReader(actor []byte) (store.Reader, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	if reader, ok := m.readers[string(actor)]; ok {
+		return reader, nil
+	}
+	reader, err := m.state.GetReader(actor)
+	if err != nil {
+		return nil, err
+	}
+	m.readers[string(actor)] = reader
+	return reader, nil
+}
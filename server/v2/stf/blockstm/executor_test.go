@@ -0,0 +1,177 @@
+package blockstm
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/core/store"
+	"cosmossdk.io/server/v2/stf/branch"
+	"cosmossdk.io/server/v2/stf/mock"
+)
+
+var (
+	actorA = []byte("a")
+	actorB = []byte("b")
+)
+
+// runTx is a tx conflicting with many others: it increments a shared counter,
+// sums the balances of a range of accounts and moves a balance between them.
+func runTx(txIndex int, state store.WriterMap) error {
+	a, err := state.GetWriter(actorA)
+	if err != nil {
+		return err
+	}
+	b, err := state.GetWriter(actorB)
+	if err != nil {
+		return err
+	}
+
+	// every third tx increments the counter
+	if txIndex%3 == 0 {
+		bz, err := a.Get([]byte("counter"))
+		if err != nil {
+			return err
+		}
+		counter, _ := strconv.Atoi(string(bz))
+		if err := a.Set([]byte("counter"), []byte(strconv.Itoa(counter+1))); err != nil {
+			return err
+		}
+	}
+
+	// every fifth tx sums the balances, in reverse order for the odd ones
+	if txIndex%5 == 0 {
+		var it store.Iterator
+		if txIndex%2 == 0 {
+			it, err = b.Iterator([]byte("acc/"), []byte("acc0"))
+		} else {
+			it, err = b.ReverseIterator([]byte("acc/"), []byte("acc0"))
+		}
+		if err != nil {
+			return err
+		}
+		sum := 0
+		for ; it.Valid(); it.Next() {
+			balance, _ := strconv.Atoi(string(it.Value()))
+			sum += balance
+		}
+		if err := it.Close(); err != nil {
+			return err
+		}
+		if err := a.Set([]byte(fmt.Sprintf("sum/%03d", txIndex)), []byte(strconv.Itoa(sum))); err != nil {
+			return err
+		}
+	}
+
+	// move the balance of an account to another, removing the former
+	from, to := []byte(fmt.Sprintf("acc/%02d", txIndex%7)), []byte(fmt.Sprintf("acc/%02d", (txIndex+3)%11))
+	bz, err := b.Get(from)
+	if err != nil || bz == nil {
+		return err
+	}
+	balance, _ := strconv.Atoi(string(bz))
+	bz, err = b.Get(to)
+	if err != nil {
+		return err
+	}
+	toBalance, _ := strconv.Atoi(string(bz))
+	if err := b.Delete(from); err != nil {
+		return err
+	}
+	return b.Set(to, []byte(strconv.Itoa(balance+toBalance)))
+}
+
+func newBaseState(t *testing.T) store.WriterMap {
+	t.Helper()
+	state := branch.DefaultNewWriterMap(mock.DB())
+	b, err := state.GetWriter(actorB)
+	require.NoError(t, err)
+	for i := 0; i < 11; i++ {
+		require.NoError(t, b.Set([]byte(fmt.Sprintf("acc/%02d", i)), []byte(strconv.Itoa(100+i))))
+	}
+	return state
+}
+
+func sortedChanges(t *testing.T, state store.WriterMap) []store.StateChanges {
+	t.Helper()
+	changes, err := state.GetStateChanges()
+	require.NoError(t, err)
+	sort.Slice(changes, func(i, j int) bool { return bytes.Compare(changes[i].Actor, changes[j].Actor) < 0 })
+	for _, sc := range changes {
+		sort.Slice(sc.StateChanges, func(i, j int) bool {
+			return bytes.Compare(sc.StateChanges[i].Key, sc.StateChanges[j].Key) < 0
+		})
+	}
+	return changes
+}
+
+func TestExecute(t *testing.T) {
+	const txs = 200
+
+	expected := newBaseState(t)
+	for i := 0; i < txs; i++ {
+		txState := branch.DefaultNewWriterMap(expected)
+		require.NoError(t, runTx(i, txState))
+		changes, err := txState.GetStateChanges()
+		require.NoError(t, err)
+		require.NoError(t, expected.ApplyStateChanges(changes))
+	}
+
+	for _, workers := range []int{1, 4, 16} {
+		t.Run(fmt.Sprintf("workers=%d", workers), func(t *testing.T) {
+			base := newBaseState(t)
+			var executions atomic.Int64
+			changes, err := Execute(context.Background(), base, txs, workers, func(txIndex int, view store.ReaderMap) ([]store.StateChanges, error) {
+				executions.Add(1)
+				txState := branch.DefaultNewWriterMap(view)
+				// let the txs overlap, so that they conflict
+				time.Sleep(100 * time.Microsecond)
+				if err := runTx(txIndex, txState); err != nil {
+					return nil, err
+				}
+				return txState.GetStateChanges()
+			})
+			require.NoError(t, err)
+			if workers == 1 {
+				require.Equal(t, int64(txs), executions.Load())
+			} else {
+				require.Greater(t, executions.Load(), int64(txs))
+			}
+
+			for _, txChanges := range changes {
+				require.NoError(t, base.ApplyStateChanges(txChanges))
+			}
+			require.Equal(t, sortedChanges(t, expected), sortedChanges(t, base))
+		})
+	}
+}
+
+func TestExecuteError(t *testing.T) {
+	expectedErr := errors.New("failure")
+	_, err := Execute(context.Background(), newBaseState(t), 100, 4, func(txIndex int, view store.ReaderMap) ([]store.StateChanges, error) {
+		txState := branch.DefaultNewWriterMap(view)
+		if err := runTx(txIndex, txState); err != nil {
+			return nil, err
+		}
+		if txIndex == 50 {
+			return nil, expectedErr
+		}
+		return txState.GetStateChanges()
+	})
+	require.ErrorIs(t, err, expectedErr)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Execute(ctx, newBaseState(t), 100, 4, func(txIndex int, view store.ReaderMap) ([]store.StateChanges, error) {
+		return nil, nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
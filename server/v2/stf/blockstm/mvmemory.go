@@ -0,0 +1,189 @@
+package blockstm
+
+import (
+	"bytes"
+	"sort"
+	"sync"
+
+	"github.com/tidwall/btree"
+
+	"cosmossdk.io/core/store"
+)
+
+// version identifies the incarnation of the tx which wrote a value. Values read
+// from the state the block is executed on have the storageVersion.
+type version struct {
+	txIndex     int
+	incarnation int
+}
+
+var storageVersion = version{txIndex: -1}
+
+// write is the write of a key by an incarnation of a tx.
+type write struct {
+	version
+	value  []byte
+	remove bool
+	// estimate marks the writes of a tx which is being re-executed, which are
+	// likely to be written again.
+	estimate bool
+}
+
+// keyWrites holds the writes of the txs to a key, sorted by tx index.
+type keyWrites struct {
+	key    []byte
+	writes []write
+}
+
+// latest returns the write of the highest tx index lower than txIndex.
+func (k *keyWrites) latest(txIndex int) (write, bool) {
+	i := sort.Search(len(k.writes), func(i int) bool { return k.writes[i].txIndex >= txIndex })
+	if i == 0 {
+		return write{}, false
+	}
+	return k.writes[i-1], true
+}
+
+func (k *keyWrites) set(w write) {
+	i := sort.Search(len(k.writes), func(i int) bool { return k.writes[i].txIndex >= w.txIndex })
+	if i < len(k.writes) && k.writes[i].txIndex == w.txIndex {
+		k.writes[i] = w
+		return
+	}
+	k.writes = append(k.writes, write{})
+	copy(k.writes[i+1:], k.writes[i:])
+	k.writes[i] = w
+}
+
+func (k *keyWrites) delete(txIndex int) {
+	i := sort.Search(len(k.writes), func(i int) bool { return k.writes[i].txIndex >= txIndex })
+	if i < len(k.writes) && k.writes[i].txIndex == txIndex {
+		k.writes = append(k.writes[:i], k.writes[i+1:]...)
+	}
+}
+
+func byKeys(a, b *keyWrites) bool { return bytes.Compare(a.key, b.key) < 0 }
+
+// location is a key of the state of an actor.
+type location struct {
+	actor, key string
+}
+
+// keyWrite is the visible write of a key, returned by mvMemory.rangeWrites.
+type keyWrite struct {
+	write
+	key []byte
+}
+
+// mvMemory is the multi-version memory of the writes of the txs of a block. A tx
+// reads the value written by the highest lower tx which wrote it, if any, or the
+// value of the state the block is executed on.
+type mvMemory struct {
+	mtx    sync.RWMutex
+	actors map[string]*btree.BTreeG[*keyWrites]
+	// written are the locations written by the last incarnation of each tx.
+	written [][]location
+}
+
+func newMVMemory(txs int) *mvMemory {
+	return &mvMemory{
+		actors:  make(map[string]*btree.BTreeG[*keyWrites]),
+		written: make([][]location, txs),
+	}
+}
+
+// read returns the write of the key visible to the given tx, if any.
+func (m *mvMemory) read(txIndex int, actor string, key []byte) (write, bool) {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	tree, ok := m.actors[actor]
+	if !ok {
+		return write{}, false
+	}
+	k, ok := tree.Get(&keyWrites{key: key})
+	if !ok {
+		return write{}, false
+	}
+	return k.latest(txIndex)
+}
+
+// rangeWrites returns the writes of the keys of the range [start, end) visible
+// to the given tx, in ascending or descending key order, including removals.
+func (m *mvMemory) rangeWrites(txIndex int, actor string, start, end []byte, ascending bool) []keyWrite {
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+
+	tree, ok := m.actors[actor]
+	if !ok {
+		return nil
+	}
+
+	var writes []keyWrite
+	tree.Ascend(&keyWrites{key: start}, func(k *keyWrites) bool {
+		if end != nil && bytes.Compare(k.key, end) >= 0 {
+			return false
+		}
+		if w, ok := k.latest(txIndex); ok {
+			writes = append(writes, keyWrite{write: w, key: k.key})
+		}
+		return true
+	})
+	if !ascending {
+		for i, j := 0, len(writes)-1; i < j; i, j = i+1, j-1 {
+			writes[i], writes[j] = writes[j], writes[i]
+		}
+	}
+	return writes
+}
+
+// record replaces the writes of the previous incarnation of a tx by the given
+// state changes of its new incarnation.
+func (m *mvMemory) record(v version, changes []store.StateChanges) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, loc := range m.written[v.txIndex] {
+		if k, ok := m.actors[loc.actor].Get(&keyWrites{key: []byte(loc.key)}); ok {
+			k.delete(v.txIndex)
+		}
+	}
+
+	written := make([]location, 0, len(m.written[v.txIndex]))
+	for _, sc := range changes {
+		actor := string(sc.Actor)
+		tree, ok := m.actors[actor]
+		if !ok {
+			tree = btree.NewBTreeGOptions(byKeys, btree.Options{NoLocks: true})
+			m.actors[actor] = tree
+		}
+		for _, pair := range sc.StateChanges {
+			k, ok := tree.Get(&keyWrites{key: pair.Key})
+			if !ok {
+				k = &keyWrites{key: pair.Key}
+				tree.Set(k)
+			}
+			k.set(write{version: v, value: pair.Value, remove: pair.Remove})
+			written = append(written, location{actor: actor, key: string(pair.Key)})
+		}
+	}
+	m.written[v.txIndex] = written
+}
+
+// markEstimates marks the writes of a tx as estimates, before it is re-executed.
+func (m *mvMemory) markEstimates(txIndex int) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+
+	for _, loc := range m.written[txIndex] {
+		k, ok := m.actors[loc.actor].Get(&keyWrites{key: []byte(loc.key)})
+		if !ok {
+			continue
+		}
+		for i := range k.writes {
+			if k.writes[i].txIndex == txIndex {
+				k.writes[i].estimate = true
+			}
+		}
+	}
+}
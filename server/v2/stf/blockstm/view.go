@@ -0,0 +1,347 @@
+package blockstm
+
+import (
+	"bytes"
+	"errors"
+
+	"cosmossdk.io/core/store"
+)
+
+var errAborted = errors.New("block execution aborted")
+
+// read is a key read by a tx, and the version of the value it read.
+type read struct {
+	location
+	version version
+}
+
+// iteration is a range iterated over by a tx, and the keys it iterated over.
+type iteration struct {
+	actor      string
+	start, end []byte
+	ascending  bool
+	keys       []keyVersion
+	// exhausted is true if the tx iterated past the last key of the range.
+	exhausted bool
+}
+
+type keyVersion struct {
+	key     []byte
+	version version
+}
+
+// readSet holds the reads of an incarnation of a tx, which it is validated against.
+type readSet struct {
+	reads      []read
+	iterations []*iteration
+}
+
+// view is the state a tx is executed on, made of the writes of the lower txs
+// over the state the block is executed on. It records the reads of the tx.
+type view struct {
+	executor *executor
+	txIndex  int
+	reads    *readSet
+}
+
+var _ store.ReaderMap = (*view)(nil)
+
+func (v *view) GetReader(actor []byte) (store.Reader, error) {
+	base, err := v.executor.base.GetReader(actor)
+	if err != nil {
+		return nil, err
+	}
+	return &viewReader{view: v, actor: string(actor), base: base}, nil
+}
+
+// read returns the write of the key visible to the tx, waiting for the lower txs
+// being re-executed.
+func (v *view) read(actor string, key []byte) (write, bool, error) {
+	for {
+		w, ok := v.executor.mv.read(v.txIndex, actor, key)
+		if !ok || !w.estimate {
+			return w, ok, nil
+		}
+		if err := v.executor.wait(w.txIndex); err != nil {
+			return write{}, false, err
+		}
+	}
+}
+
+// rangeWrites returns the writes of the range visible to the tx, waiting for the
+// lower txs being re-executed.
+func (v *view) rangeWrites(actor string, start, end []byte, ascending bool) ([]keyWrite, error) {
+	for {
+		writes := v.executor.mv.rangeWrites(v.txIndex, actor, start, end, ascending)
+		blocking := -1
+		for _, w := range writes {
+			if w.estimate {
+				blocking = w.txIndex
+				break
+			}
+		}
+		if blocking < 0 {
+			return writes, nil
+		}
+		if err := v.executor.wait(blocking); err != nil {
+			return nil, err
+		}
+	}
+}
+
+type viewReader struct {
+	view  *view
+	actor string
+	base  store.Reader
+}
+
+func (r *viewReader) Has(key []byte) (bool, error) {
+	w, ok, err := r.view.read(r.actor, key)
+	if err != nil {
+		return false, err
+	}
+	if ok {
+		r.record(key, w.version)
+		return !w.remove, nil
+	}
+
+	has, err := r.base.Has(key)
+	if err != nil {
+		return false, err
+	}
+	r.record(key, storageVersion)
+	return has, nil
+}
+
+func (r *viewReader) Get(key []byte) ([]byte, error) {
+	w, ok, err := r.view.read(r.actor, key)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		r.record(key, w.version)
+		if w.remove {
+			return nil, nil
+		}
+		return w.value, nil
+	}
+
+	value, err := r.base.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	r.record(key, storageVersion)
+	return value, nil
+}
+
+func (r *viewReader) Iterator(start, end []byte) (store.Iterator, error) {
+	return r.iterator(start, end, true)
+}
+
+func (r *viewReader) ReverseIterator(start, end []byte) (store.Iterator, error) {
+	return r.iterator(start, end, false)
+}
+
+func (r *viewReader) iterator(start, end []byte, ascending bool) (store.Iterator, error) {
+	writes, err := r.view.rangeWrites(r.actor, start, end, ascending)
+	if err != nil {
+		return nil, err
+	}
+	it, err := newMergedIterator(r.base, start, end, writes, ascending)
+	if err != nil {
+		return nil, err
+	}
+
+	iter := &iteration{actor: r.actor, start: bytes.Clone(start), end: bytes.Clone(end), ascending: ascending}
+	r.view.reads.iterations = append(r.view.reads.iterations, iter)
+	return newRecordingIterator(it, iter), nil
+}
+
+func (r *viewReader) record(key []byte, v version) {
+	r.view.reads.reads = append(r.view.reads.reads, read{
+		location: location{actor: r.actor, key: string(key)},
+		version:  v,
+	})
+}
+
+// recordingIterator records the keys iterated over, and their versions.
+type recordingIterator struct {
+	*mergedIterator
+	iteration *iteration
+}
+
+func newRecordingIterator(it *mergedIterator, iter *iteration) store.Iterator {
+	r := &recordingIterator{mergedIterator: it, iteration: iter}
+	r.record()
+	return r
+}
+
+func (r *recordingIterator) Next() {
+	r.mergedIterator.Next()
+	r.record()
+}
+
+func (r *recordingIterator) record() {
+	if !r.Valid() {
+		r.iteration.exhausted = true
+		return
+	}
+	r.iteration.keys = append(r.iteration.keys, keyVersion{key: bytes.Clone(r.Key()), version: r.version()})
+}
+
+// validate returns true if the reads of the tx are still the ones visible to it.
+func (e *executor) validate(txIndex int, reads *readSet) (bool, error) {
+	for _, rd := range reads.reads {
+		v := storageVersion
+		if w, ok := e.mv.read(txIndex, rd.actor, []byte(rd.key)); ok {
+			v = w.version
+		}
+		if v != rd.version {
+			return false, nil
+		}
+	}
+
+	for _, iter := range reads.iterations {
+		ok, err := e.validateIteration(txIndex, iter)
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+// validateIteration returns true if iterating over the range yields the same
+// keys at the same versions as the tx iterated over.
+func (e *executor) validateIteration(txIndex int, iter *iteration) (bool, error) {
+	base, err := e.base.GetReader([]byte(iter.actor))
+	if err != nil {
+		return false, err
+	}
+	writes := e.mv.rangeWrites(txIndex, iter.actor, iter.start, iter.end, iter.ascending)
+	it, err := newMergedIterator(base, iter.start, iter.end, writes, iter.ascending)
+	if err != nil {
+		return false, err
+	}
+	defer it.Close()
+
+	for _, kv := range iter.keys {
+		if !it.Valid() || !bytes.Equal(it.Key(), kv.key) || it.version() != kv.version {
+			return false, nil
+		}
+		it.Next()
+	}
+	return !iter.exhausted || !it.Valid(), nil
+}
+
+// mergedIterator merges an iterator of the state the block is executed on with
+// the writes of the lower txs, which shadow it.
+type mergedIterator struct {
+	base      store.Iterator
+	writes    []keyWrite
+	pos       int
+	ascending bool
+	// fromBase and fromWrites tell where the current key comes from. A key of
+	// both the state and the writes is read from the writes.
+	fromBase, fromWrites bool
+}
+
+func newMergedIterator(base store.Reader, start, end []byte, writes []keyWrite, ascending bool) (*mergedIterator, error) {
+	var (
+		it  store.Iterator
+		err error
+	)
+	if ascending {
+		it, err = base.Iterator(start, end)
+	} else {
+		it, err = base.ReverseIterator(start, end)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	iter := &mergedIterator{base: it, writes: writes, ascending: ascending}
+	iter.skip()
+	return iter, nil
+}
+
+// skip positions the iterator on the next key which is not removed.
+func (it *mergedIterator) skip() {
+	for {
+		hasBase, hasWrite := it.base.Valid(), it.pos < len(it.writes)
+		it.fromBase, it.fromWrites = false, false
+		if !hasWrite {
+			it.fromBase = hasBase
+			return
+		}
+
+		w := it.writes[it.pos]
+		c := 1
+		if hasBase {
+			c = bytes.Compare(it.base.Key(), w.key)
+			if !it.ascending {
+				c = -c
+			}
+		}
+		if c < 0 {
+			it.fromBase = true
+			return
+		}
+		if !w.remove {
+			it.fromBase, it.fromWrites = c == 0, true
+			return
+		}
+
+		// a removed key also shadows the key of the state
+		it.pos++
+		if c == 0 {
+			it.base.Next()
+		}
+	}
+}
+
+func (it *mergedIterator) Domain() (start, end []byte) { return it.base.Domain() }
+
+func (it *mergedIterator) Valid() bool { return it.fromBase || it.fromWrites }
+
+func (it *mergedIterator) Next() {
+	if it.fromBase {
+		it.base.Next()
+	}
+	if it.fromWrites {
+		it.pos++
+	}
+	it.skip()
+}
+
+func (it *mergedIterator) Key() []byte {
+	if it.fromWrites {
+		return it.writes[it.pos].key
+	}
+	return it.base.Key()
+}
+
+func (it *mergedIterator) Value() []byte {
+	if it.fromWrites {
+		return it.writes[it.pos].value
+	}
+	return it.base.Value()
+}
+
+func (it *mergedIterator) version() version {
+	if it.fromWrites {
+		return it.writes[it.pos].version
+	}
+	return storageVersion
+}
+
+// Error returns the error of the state iterator, which may be exhausted while
+// the merged iterator is still valid.
+func (it *mergedIterator) Error() error {
+	if it.Valid() {
+		return nil
+	}
+	return it.base.Error()
+}
+
+func (it *mergedIterator) Close() error { return it.base.Close() }
@@ -40,7 +40,7 @@ func (bs BranchService) ExecuteWithGasLimit(
 
 func (bs BranchService) execute(ctx *executionContext, f func(ctx context.Context) error) error {
 	branchedState := ctx.branchFn(ctx.unmeteredState)
-	meteredBranchedState := ctx.makeGasMeteredStore(ctx.meter, branchedState)
+	meteredBranchedState := ctx.meterState(ctx.meter, branchedState)
 
 	branchedCtx := &executionContext{
 		Context:             ctx.Context,
@@ -51,6 +51,8 @@ func (bs BranchService) execute(ctx *executionContext, f func(ctx context.Contex
 		sender:              ctx.sender,
 		headerInfo:          ctx.headerInfo,
 		execMode:            ctx.execMode,
+		gasSchedule:         ctx.gasSchedule,
+		msgTypeURL:          ctx.msgTypeURL,
 		branchFn:            ctx.branchFn,
 		makeGasMeter:        ctx.makeGasMeter,
 		makeGasMeteredStore: ctx.makeGasMeteredStore,
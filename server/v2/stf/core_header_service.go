@@ -53,3 +53,24 @@ func (s STF[T]) getHeaderInfo(state store.WriterMap) (i header.Info, err error)
 	err = i.FromBytes(v)
 	return i, err
 }
+
+// overrideHeaderInfo returns the header info with its fields replaced by the
+// non-zero fields of the override.
+func overrideHeaderInfo(hi, override header.Info) header.Info {
+	if override.Height != 0 {
+		hi.Height = override.Height
+	}
+	if override.Hash != nil {
+		hi.Hash = override.Hash
+	}
+	if !override.Time.IsZero() {
+		hi.Time = override.Time
+	}
+	if override.AppHash != nil {
+		hi.AppHash = override.AppHash
+	}
+	if override.ChainID != "" {
+		hi.ChainID = override.ChainID
+	}
+	return hi
+}
@@ -0,0 +1,49 @@
+package gas
+
+import (
+	coregas "cosmossdk.io/core/gas"
+	"cosmossdk.io/core/store"
+)
+
+// DefaultSchedule returns the gas schedule applying DefaultConfig to all the stores.
+func DefaultSchedule() coregas.Schedule {
+	return coregas.Schedule{Default: DefaultConfig.GasConfig()}
+}
+
+// NewStoreConfig returns the StoreConfig of a core gas config.
+func NewStoreConfig(config coregas.GasConfig) StoreConfig {
+	return StoreConfig{
+		ReadCostFlat:     config.ReadCostFlat,
+		ReadCostPerByte:  config.ReadCostPerByte,
+		HasCost:          config.HasCost,
+		WriteCostFlat:    config.WriteCostFlat,
+		WriteCostPerByte: config.WriteCostPerByte,
+		DeleteCostFlat:   config.DeleteCost,
+		IterNextCostFlat: config.IterNextCostFlat,
+	}
+}
+
+// GasConfig returns the core gas config of the StoreConfig.
+func (c StoreConfig) GasConfig() coregas.GasConfig {
+	return coregas.GasConfig{
+		HasCost:          c.HasCost,
+		DeleteCost:       c.DeleteCostFlat,
+		ReadCostFlat:     c.ReadCostFlat,
+		ReadCostPerByte:  c.ReadCostPerByte,
+		WriteCostFlat:    c.WriteCostFlat,
+		WriteCostPerByte: c.WriteCostPerByte,
+		IterNextCostFlat: c.IterNextCostFlat,
+	}
+}
+
+// WrapWithGasSchedule wraps the state with the gas meter like DefaultWrapWithGasMeter,
+// metering each store with its config in the schedule for the message of the
+// given type URL, which is empty outside of message execution.
+func WrapWithGasSchedule(schedule coregas.Schedule, msgTypeURL string, meter coregas.Meter, state store.WriterMap) store.WriterMap {
+	if meter.Limit() == coregas.NoGasLimit {
+		return state
+	}
+	return NewScheduledWriterMap(func(actor []byte) StoreConfig {
+		return NewStoreConfig(schedule.Config(msgTypeURL, string(actor)))
+	}, meter, state)
+}
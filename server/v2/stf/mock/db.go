@@ -1,6 +1,10 @@
 package mock
 
 import (
+	"bytes"
+	"sort"
+	"strings"
+
 	"cosmossdk.io/core/store"
 )
 
@@ -31,8 +35,41 @@ func (m memState) Get(bytes []byte) ([]byte, error) {
 	return m.kv[string(key)], nil
 }
 
-func (m memState) Iterator(start, end []byte) (store.Iterator, error) { panic("implement me") }
+func (m memState) Iterator(start, end []byte) (store.Iterator, error) {
+	return m.iterator(start, end, true), nil
+}
 
 func (m memState) ReverseIterator(start, end []byte) (store.Iterator, error) {
-	panic("implement me")
+	return m.iterator(start, end, false), nil
+}
+
+func (m memState) iterator(start, end []byte, ascending bool) store.Iterator {
+	var pairs []store.KVPair
+	for k, v := range m.kv {
+		if !strings.HasPrefix(k, string(m.address)) {
+			continue
+		}
+		key := []byte(k[len(m.address):])
+		if (start != nil && bytes.Compare(key, start) < 0) || (end != nil && bytes.Compare(key, end) >= 0) {
+			continue
+		}
+		pairs = append(pairs, store.KVPair{Key: key, Value: v})
+	}
+	sort.Slice(pairs, func(i, j int) bool {
+		return (bytes.Compare(pairs[i].Key, pairs[j].Key) < 0) == ascending
+	})
+	return &memIterator{start: start, end: end, pairs: pairs}
 }
+
+type memIterator struct {
+	start, end []byte
+	pairs      []store.KVPair
+}
+
+func (it *memIterator) Domain() (start, end []byte) { return it.start, it.end }
+func (it *memIterator) Valid() bool                 { return len(it.pairs) > 0 }
+func (it *memIterator) Next()                       { it.pairs = it.pairs[1:] }
+func (it *memIterator) Key() []byte                 { return it.pairs[0].Key }
+func (it *memIterator) Value() []byte               { return it.pairs[0].Value }
+func (it *memIterator) Error() error                { return nil }
+func (it *memIterator) Close() error                { return nil }
@@ -15,8 +15,10 @@ import (
 	"cosmossdk.io/core/router"
 	"cosmossdk.io/core/store"
 	"cosmossdk.io/core/transaction"
+	"cosmossdk.io/server/v2/stf/blockstm"
 	stfgas "cosmossdk.io/server/v2/stf/gas"
 	"cosmossdk.io/server/v2/stf/internal"
+	"cosmossdk.io/server/v2/stf/tracing"
 )
 
 // Identity defines STF's bytes identity and it's used by STF to store things in its own state.
@@ -40,6 +42,13 @@ type STF[T transaction.Tx] struct {
 	branchFn            branchFn // branchFn is a function that given a readonly state it returns a writable version of it.
 	makeGasMeter        makeGasMeterFn
 	makeGasMeteredState makeGasMeteredStateFn
+
+	traceAccess bool // traceAccess enables the tracing of the keys accessed by each tx of a block.
+	traceValues bool // traceValues enables the tracing of the values of the accessed keys.
+
+	parallelWorkers int // parallelWorkers is the number of txs of a block executed concurrently, if above 1.
+
+	getGasSchedule gas.ScheduleProvider // getGasSchedule returns the gas schedule of the stores, if set.
 }
 
 // NewSTF returns a new STF instance.
@@ -80,6 +89,35 @@ func NewSTF[T transaction.Tx](
 	}, nil
 }
 
+// SetAccessTracing enables or disables the tracing of the keys read and written by
+// each tx delivered in a block, which is exposed on its appmanager.TxResult. The
+// values read and written are traced too if traceValues is true.
+func (s *STF[T]) SetAccessTracing(enabled, traceValues bool) {
+	s.traceAccess = enabled
+	s.traceValues = traceValues
+}
+
+// SetParallelExecution sets the number of txs of a block executed concurrently,
+// by an optimistic executor whose results are the same as the ones of the
+// sequential execution of the txs. The txs are executed sequentially if workers
+// is 1 or less, which is the default.
+//
+// The txs must only depend on the state and on the block: the handlers must not
+// rely on memory shared across txs.
+func (s *STF[T]) SetParallelExecution(workers int) {
+	s.parallelWorkers = workers
+}
+
+// SetGasScheduleProvider sets the function returning the gas schedule the stores
+// are metered with, which takes precedence over the default gas metering. It is
+// called with the state of each block after the execution of its consensus
+// messages, so that a schedule kept in the state sees their updates, and with
+// the state txs are simulated, validated or queried on. The default gas metering
+// applies to the states for which it returns no schedule.
+func (s *STF[T]) SetGasScheduleProvider(getGasSchedule gas.ScheduleProvider) {
+	s.getGasSchedule = getGasSchedule
+}
+
 // DeliverBlock is our state transition function.
 // It takes a read only view of the state to apply the block to,
 // executes the block and returns the block results and the new state.
@@ -111,6 +149,13 @@ func (s STF[T]) DeliverBlock(
 		return nil, nil, fmt.Errorf("failed to execute consensus messages: %w", err)
 	}
 
+	// the gas schedule is read after the consensus messages, which may update it
+	ctx, gasSchedule, err := s.withGasSchedule(ctx, newState)
+	if err != nil {
+		return nil, nil, err
+	}
+	exCtx.gasSchedule = gasSchedule
+
 	// reset events
 	exCtx.events = make([]event.Event, 0)
 	// pre block is called separate from begin block in order to prepopulate state
@@ -142,14 +187,22 @@ func (s STF[T]) DeliverBlock(
 	}
 
 	// execute txs
-	txResults := make([]appmanager.TxResult, len(block.Txs))
+	var txResults []appmanager.TxResult
 	// TODO: skip first tx if vote extensions are enabled (marko)
-	for i, txBytes := range block.Txs {
-		// check if we need to return early or continue delivering txs
-		if err = isCtxCancelled(ctx); err != nil {
+	if s.parallelWorkers > 1 && len(block.Txs) > 1 {
+		txResults, err = s.deliverTxsParallel(ctx, newState, block.Txs, hi)
+		if err != nil {
 			return nil, nil, err
 		}
-		txResults[i] = s.deliverTx(ctx, newState, txBytes, transaction.ExecModeFinalize, hi)
+	} else {
+		txResults = make([]appmanager.TxResult, len(block.Txs))
+		for i, txBytes := range block.Txs {
+			// check if we need to return early or continue delivering txs
+			if err = isCtxCancelled(ctx); err != nil {
+				return nil, nil, err
+			}
+			txResults[i] = s.deliverBlockTx(ctx, newState, txBytes, hi)
+		}
 	}
 	// reset events
 	exCtx.events = make([]event.Event, 0)
@@ -167,9 +220,54 @@ func (s STF[T]) DeliverBlock(
 		BeginBlockEvents:          beginBlockEvents,
 		TxResults:                 txResults,
 		EndBlockEvents:            endBlockEvents,
+		GasSchedule:               gasSchedule,
 	}, newState, nil
 }
 
+// deliverBlockTx executes a TX of a block and returns the result, with the trace
+// of the keys it accessed if access tracing is enabled.
+func (s STF[T]) deliverBlockTx(
+	ctx context.Context,
+	state store.WriterMap,
+	tx T,
+	hi header.Info,
+) appmanager.TxResult {
+	if !s.traceAccess {
+		return s.deliverTx(ctx, state, tx, transaction.ExecModeFinalize, hi)
+	}
+
+	tracer := tracing.NewWriterMap(state, s.traceValues)
+	result := s.deliverTx(ctx, tracer, tx, transaction.ExecModeFinalize, hi)
+	result.Trace = tracer.Trace()
+	return result
+}
+
+// deliverTxsParallel executes the TXs of a block concurrently and applies their
+// state changes to the provided state, in order.
+func (s STF[T]) deliverTxsParallel(
+	ctx context.Context,
+	state store.WriterMap,
+	txs []T,
+	hi header.Info,
+) ([]appmanager.TxResult, error) {
+	txResults := make([]appmanager.TxResult, len(txs))
+	changes, err := blockstm.Execute(ctx, state, len(txs), s.parallelWorkers, func(i int, view store.ReaderMap) ([]store.StateChanges, error) {
+		txState := s.branchFn(view)
+		txResults[i] = s.deliverBlockTx(ctx, txState, txs[i], hi)
+		return txState.GetStateChanges()
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute txs: %w", err)
+	}
+
+	for _, txChanges := range changes {
+		if err := state.ApplyStateChanges(txChanges); err != nil {
+			return nil, err
+		}
+	}
+	return txResults, nil
+}
+
 // deliverTx executes a TX and returns the result.
 func (s STF[T]) deliverTx(
 	ctx context.Context,
@@ -321,12 +419,16 @@ func (s STF[T]) runTxMsgs(
 	execCtx.setGasLimit(gasLimit)
 	for i, msg := range msgs {
 		execCtx.sender = txSenders[i]
+		// the router names the messages without the slash of their type URL
+		execCtx.setMsgTypeURL("/" + msgTypeURL(msg))
 		resp, err := s.msgRouter.InvokeUntyped(execCtx, msg)
 		if err != nil {
 			return nil, 0, nil, fmt.Errorf("message execution at index %d failed: %w", i, err)
 		}
 		msgResps[i] = resp
 	}
+	// the state is no longer metered with the schedule of the last message
+	execCtx.setMsgTypeURL("")
 
 	consumed := execCtx.meter.Limit() - execCtx.meter.Remaining()
 	return msgResps, consumed, execCtx.events, nil
@@ -427,11 +529,40 @@ func (s STF[T]) Simulate(
 	state store.ReaderMap,
 	gasLimit uint64,
 	tx T,
+) (appmanager.TxResult, store.WriterMap) {
+	return s.SimulateWithOverrides(ctx, state, gasLimit, tx, nil, nil)
+}
+
+// SimulateWithOverrides simulates the execution of a tx on the provided state
+// with the state overrides applied to it, for what-if simulations. If the
+// header info override is not nil, its non-zero fields replace the ones of the
+// header info of the state the tx is simulated with.
+func (s STF[T]) SimulateWithOverrides(
+	ctx context.Context,
+	state store.ReaderMap,
+	gasLimit uint64,
+	tx T,
+	stateOverrides []store.StateChanges,
+	headerOverride *header.Info,
 ) (appmanager.TxResult, store.WriterMap) {
 	simulationState := s.branchFn(state)
+	if err := simulationState.ApplyStateChanges(stateOverrides); err != nil {
+		return appmanager.TxResult{Error: fmt.Errorf("unable to apply state overrides: %w", err)}, nil
+	}
 	hi, err := s.getHeaderInfo(simulationState)
 	if err != nil {
-		return appmanager.TxResult{}, nil
+		return appmanager.TxResult{Error: err}, nil
+	}
+	if headerOverride != nil {
+		hi = overrideHeaderInfo(hi, *headerOverride)
+		// the header info is also read from the state by the tx validation
+		if err := s.setHeaderInfo(simulationState, hi); err != nil {
+			return appmanager.TxResult{Error: fmt.Errorf("invalid header info override: %w", err)}, nil
+		}
+	}
+	ctx, _, err = s.withGasSchedule(ctx, simulationState)
+	if err != nil {
+		return appmanager.TxResult{Error: err}, nil
 	}
 	txr := s.deliverTx(ctx, simulationState, tx, internal.ExecModeSimulate, hi)
 
@@ -447,6 +578,10 @@ func (s STF[T]) ValidateTx(
 	tx T,
 ) appmanager.TxResult {
 	validationState := s.branchFn(state)
+	ctx, _, err := s.withGasSchedule(ctx, validationState)
+	if err != nil {
+		return appmanager.TxResult{Error: err}
+	}
 	gasUsed, events, err := s.validateTx(ctx, validationState, gasLimit, tx)
 	return appmanager.TxResult{
 		Events:  events,
@@ -467,6 +602,10 @@ func (s STF[T]) Query(
 	if err != nil {
 		return nil, err
 	}
+	ctx, _, err = s.withGasSchedule(ctx, queryState)
+	if err != nil {
+		return nil, err
+	}
 	queryCtx := s.makeContext(ctx, nil, queryState, internal.ExecModeSimulate)
 	queryCtx.setHeaderInfo(hi)
 	queryCtx.setGasLimit(gasLimit)
@@ -501,6 +640,10 @@ func (s STF[T]) clone() STF[T] {
 		branchFn:            s.branchFn,
 		makeGasMeter:        s.makeGasMeter,
 		makeGasMeteredState: s.makeGasMeteredState,
+		traceAccess:         s.traceAccess,
+		traceValues:         s.traceValues,
+		parallelWorkers:     s.parallelWorkers,
+		getGasSchedule:      s.getGasSchedule,
 	}
 }
 
@@ -523,6 +666,10 @@ type executionContext struct {
 	headerInfo header.Info
 	// execMode retains information about the exec mode.
 	execMode transaction.ExecMode
+	// gasSchedule is the gas schedule the state is metered with, if any.
+	gasSchedule *gas.Schedule
+	// msgTypeURL is the type URL of the message being executed, if any.
+	msgTypeURL string
 
 	branchFn            branchFn
 	makeGasMeter        makeGasMeterFn
@@ -540,12 +687,30 @@ func (e *executionContext) setHeaderInfo(hi header.Info) {
 // setGasLimit will update the gas limit of the *executionContext
 func (e *executionContext) setGasLimit(limit uint64) {
 	meter := e.makeGasMeter(limit)
-	meteredState := e.makeGasMeteredStore(meter, e.unmeteredState)
+	meteredState := e.meterState(meter, e.unmeteredState)
 
 	e.meter = meter
 	e.state = meteredState
 }
 
+// setMsgTypeURL sets the type URL of the message being executed, whose gas
+// schedule then meters the state.
+func (e *executionContext) setMsgTypeURL(msgTypeURL string) {
+	e.msgTypeURL = msgTypeURL
+	if e.gasSchedule != nil {
+		e.state = e.meterState(e.meter, e.unmeteredState)
+	}
+}
+
+// meterState wraps the state with the gas meter, metering the stores with the
+// gas schedule if any.
+func (e *executionContext) meterState(meter gas.Meter, state store.WriterMap) store.WriterMap {
+	if e.gasSchedule == nil {
+		return e.makeGasMeteredStore(meter, state)
+	}
+	return stfgas.WrapWithGasSchedule(*e.gasSchedule, e.msgTypeURL, meter, state)
+}
+
 // TODO: too many calls to makeContext can be expensive
 // makeContext creates and returns a new execution context for the STF[T] type.
 // It takes in the following parameters:
@@ -563,6 +728,7 @@ func (s STF[T]) makeContext(
 	execMode transaction.ExecMode,
 ) *executionContext {
 	valuedCtx := context.WithValue(ctx, corecontext.ExecModeKey, execMode)
+	gasSchedule, _ := ctx.Value(gasScheduleKey{}).(*gas.Schedule)
 	return newExecutionContext(
 		s.makeGasMeter,
 		s.makeGasMeteredState,
@@ -571,6 +737,7 @@ func (s STF[T]) makeContext(
 		sender,
 		store,
 		execMode,
+		gasSchedule,
 		s.msgRouter,
 		s.queryRouter,
 	)
@@ -584,6 +751,7 @@ func newExecutionContext(
 	sender transaction.Identity,
 	state store.WriterMap,
 	execMode transaction.ExecMode,
+	gasSchedule *gas.Schedule,
 	msgRouter Router,
 	queryRouter Router,
 ) *executionContext {
@@ -599,6 +767,7 @@ func newExecutionContext(
 		sender:              sender,
 		headerInfo:          header.Info{},
 		execMode:            execMode,
+		gasSchedule:         gasSchedule,
 		branchFn:            branchFn,
 		makeGasMeter:        makeGasMeterFn,
 		makeGasMeteredStore: makeGasMeteredStoreFn,
@@ -607,6 +776,28 @@ func newExecutionContext(
 	}
 }
 
+// gasScheduleKey is the context key of the gas schedule txs are executed with.
+type gasScheduleKey struct{}
+
+// withGasSchedule returns the context carrying the gas schedule of the provided
+// state, if a gas schedule provider is set and the state holds a schedule, and
+// the schedule.
+func (s STF[T]) withGasSchedule(ctx context.Context, state store.WriterMap) (context.Context, *gas.Schedule, error) {
+	if s.getGasSchedule == nil {
+		return ctx, nil, nil
+	}
+	// the provider is given a branch of the state, so that it cannot write to it
+	scheduleCtx := s.makeContext(ctx, appmanager.RuntimeIdentity, s.branchFn(state), internal.ExecModeSimulate)
+	schedule, err := s.getGasSchedule(scheduleCtx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get gas schedule: %w", err)
+	}
+	if schedule == nil {
+		return ctx, nil, nil
+	}
+	return context.WithValue(ctx, gasScheduleKey{}, schedule), schedule, nil
+}
+
 // applyStateChanges applies the state changes from the source store to the destination store.
 // It retrieves the state changes from the source store using GetStateChanges method,
 // and then applies those changes to the destination store using ApplyStateChanges method.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"crypto/sha256"
 	"fmt"
+	"strconv"
 	"testing"
 	"time"
 
@@ -14,6 +15,7 @@ import (
 	appmanager "cosmossdk.io/core/app"
 	appmodulev2 "cosmossdk.io/core/appmodule/v2"
 	coregas "cosmossdk.io/core/gas"
+	"cosmossdk.io/core/header"
 	"cosmossdk.io/core/store"
 	"cosmossdk.io/server/v2/stf/branch"
 	"cosmossdk.io/server/v2/stf/gas"
@@ -123,6 +125,205 @@ func TestSTF(t *testing.T) {
 		require.Equal(t, mockTx.GasLimit, txResult.GasWanted)
 	})
 
+	t.Run("access tracing", func(t *testing.T) {
+		s := s.clone()
+		s.SetAccessTracing(true, true)
+		addMsgHandlerToSTF(t, &s, func(ctx context.Context, msg *gogotypes.BoolValue) (*gogotypes.BoolValue, error) {
+			state, err := ctx.(*executionContext).state.GetWriter(actorName)
+			require.NoError(t, err)
+			// the key written at validation is not read from the state before the tx
+			for _, key := range []string{"begin-block", "validate", "absent"} {
+				_, err := state.Get([]byte(key))
+				require.NoError(t, err)
+			}
+			require.NoError(t, state.Set([]byte("exec"), []byte("exec")))
+			require.NoError(t, state.Delete([]byte("begin-block")))
+			return nil, nil
+		})
+
+		result, newState, err := s.DeliverBlock(context.Background(), &appmanager.BlockRequest[mock.Tx]{
+			Height:  uint64(1),
+			Time:    time.Date(2024, 2, 3, 18, 23, 0, 0, time.UTC),
+			AppHash: sum[:],
+			Hash:    sum[:],
+			Txs:     []mock.Tx{mockTx},
+		}, state)
+		require.NoError(t, err)
+		stateHas(t, newState, "exec")
+		stateNotHas(t, newState, "begin-block")
+
+		trace := result.TxResults[0].Trace
+		require.NotNil(t, trace)
+		// the header info is read from the state of stf
+		require.Equal(t, Identity, trace.Reads[len(trace.Reads)-1].Actor)
+		require.Equal(t, []appmanager.KeyAccess{
+			{Actor: actorName, Key: []byte("absent")},
+			{Actor: actorName, Key: []byte("begin-block"), Value: []byte("begin-block")},
+		}, trace.Reads[:len(trace.Reads)-1])
+		require.Equal(t, []appmanager.KeyAccess{
+			{Actor: actorName, Key: []byte("begin-block"), Remove: true},
+			{Actor: actorName, Key: []byte("exec"), Value: []byte("exec")},
+			{Actor: actorName, Key: []byte("post-tx-exec"), Value: []byte("post-tx-exec")},
+			{Actor: actorName, Key: []byte("validate"), Value: []byte("validate")},
+		}, trace.Writes)
+
+		// the values are only traced on demand
+		s.SetAccessTracing(true, false)
+		result, _, err = s.DeliverBlock(context.Background(), &appmanager.BlockRequest[mock.Tx]{
+			Height:  uint64(1),
+			Time:    time.Date(2024, 2, 3, 18, 23, 0, 0, time.UTC),
+			AppHash: sum[:],
+			Hash:    sum[:],
+			Txs:     []mock.Tx{mockTx},
+		}, state)
+		require.NoError(t, err)
+		for _, access := range append(result.TxResults[0].Trace.Reads, result.TxResults[0].Trace.Writes...) {
+			require.Nil(t, access.Value)
+		}
+	})
+
+	t.Run("parallel execution", func(t *testing.T) {
+		s := s.clone()
+		// every tx increments the same counter, conflicting with all the others
+		addMsgHandlerToSTF(t, &s, func(ctx context.Context, msg *gogotypes.BoolValue) (*gogotypes.BoolValue, error) {
+			state, err := ctx.(*executionContext).state.GetWriter(actorName)
+			require.NoError(t, err)
+			bz, err := state.Get([]byte("counter"))
+			require.NoError(t, err)
+			counter, _ := strconv.Atoi(string(bz))
+			require.NoError(t, state.Set([]byte("counter"), []byte(strconv.Itoa(counter+1))))
+			return &gogotypes.BoolValue{Value: counter%2 == 0}, nil
+		})
+
+		block := &appmanager.BlockRequest[mock.Tx]{
+			Height:  uint64(1),
+			Time:    time.Date(2024, 2, 3, 18, 23, 0, 0, time.UTC),
+			AppHash: sum[:],
+			Hash:    sum[:],
+		}
+		for i := 0; i < 20; i++ {
+			block.Txs = append(block.Txs, mockTx)
+		}
+
+		expected, expectedState, err := s.DeliverBlock(context.Background(), block, state)
+		require.NoError(t, err)
+
+		s.SetParallelExecution(4)
+		result, newState, err := s.DeliverBlock(context.Background(), block, state)
+		require.NoError(t, err)
+		require.Equal(t, expected.TxResults, result.TxResults)
+
+		// the state changes of the actors are returned in no particular order
+		expectedChanges, err := expectedState.GetStateChanges()
+		require.NoError(t, err)
+		changes, err := newState.GetStateChanges()
+		require.NoError(t, err)
+		require.ElementsMatch(t, expectedChanges, changes)
+
+		counter, err := newState.GetReader(actorName)
+		require.NoError(t, err)
+		bz, err := counter.Get([]byte("counter"))
+		require.NoError(t, err)
+		require.Equal(t, "20", string(bz))
+	})
+
+	t.Run("gas schedule", func(t *testing.T) {
+		block := &appmanager.BlockRequest[mock.Tx]{
+			Height:  uint64(1),
+			Time:    time.Date(2024, 2, 3, 18, 23, 0, 0, time.UTC),
+			AppHash: sum[:],
+			Hash:    sum[:],
+			Txs:     []mock.Tx{mockTx},
+		}
+		expected, _, err := s.DeliverBlock(context.Background(), block, state)
+		require.NoError(t, err)
+		require.Nil(t, expected.GasSchedule)
+
+		// the default gas metering applies to the states holding no schedule
+		s := s.clone()
+		s.SetGasScheduleProvider(func(ctx context.Context) (*coregas.Schedule, error) { return nil, nil })
+		result, _, err := s.DeliverBlock(context.Background(), block, state)
+		require.NoError(t, err)
+		require.Equal(t, expected.TxResults, result.TxResults)
+		require.Nil(t, result.GasSchedule)
+
+		schedule := gas.DefaultSchedule()
+		s.SetGasScheduleProvider(func(ctx context.Context) (*coregas.Schedule, error) {
+			schedule := schedule
+			return &schedule, nil
+		})
+
+		// the default schedule meters the stores like the default gas metering
+		result, _, err = s.DeliverBlock(context.Background(), block, state)
+		require.NoError(t, err)
+		require.Equal(t, expected.TxResults[0].GasUsed, result.TxResults[0].GasUsed)
+		require.Equal(t, &schedule, result.GasSchedule)
+
+		// the message writes once to the store, validation writes once too
+		config := gas.DefaultConfig
+		config.WriteCostFlat += 1000
+		schedule.Messages = map[string]coregas.MessageSchedule{
+			"/" + msgTypeURL(mockTx.Msg): {Stores: map[string]coregas.GasConfig{string(actorName): config.GasConfig()}},
+		}
+		result, _, err = s.DeliverBlock(context.Background(), block, state)
+		require.NoError(t, err)
+		require.Equal(t, expected.TxResults[0].GasUsed+1000, result.TxResults[0].GasUsed)
+		require.Equal(t, &schedule, result.GasSchedule)
+
+		schedule.Stores = map[string]coregas.GasConfig{string(actorName): config.GasConfig()}
+		result, _, err = s.DeliverBlock(context.Background(), block, state)
+		require.NoError(t, err)
+		require.Equal(t, expected.TxResults[0].GasUsed+2000, result.TxResults[0].GasUsed)
+
+		// the schedule applies to simulations too
+		simulation, _ := s.Simulate(context.Background(), state, mockTx.GasLimit, mockTx)
+		require.NoError(t, simulation.Error)
+		require.Equal(t, result.TxResults[0].GasUsed, simulation.GasUsed)
+
+		s.SetGasScheduleProvider(func(ctx context.Context) (*coregas.Schedule, error) {
+			return nil, fmt.Errorf("no schedule")
+		})
+		_, _, err = s.DeliverBlock(context.Background(), block, state)
+		require.ErrorContains(t, err, "no schedule")
+	})
+
+	t.Run("simulate with overrides", func(t *testing.T) {
+		s := s.clone()
+		// the tx fails without funds, and records the height it executed at
+		addMsgHandlerToSTF(t, &s, func(ctx context.Context, msg *gogotypes.BoolValue) (*gogotypes.BoolValue, error) {
+			exCtx := ctx.(*executionContext)
+			state, err := exCtx.state.GetWriter(actorName)
+			require.NoError(t, err)
+			funds, err := state.Get([]byte("funds"))
+			require.NoError(t, err)
+			if funds == nil {
+				return nil, fmt.Errorf("no funds")
+			}
+			require.NoError(t, state.Set([]byte("height"), []byte(strconv.FormatInt(exCtx.headerInfo.Height, 10))))
+			return nil, nil
+		})
+
+		result, _ := s.Simulate(context.Background(), state, mockTx.GasLimit, mockTx)
+		require.ErrorContains(t, result.Error, "no funds")
+
+		overrides := []store.StateChanges{{
+			Actor:        actorName,
+			StateChanges: store.KVPairs{{Key: []byte("funds"), Value: []byte("100")}},
+		}}
+		hi := header.Info{Height: 42, Hash: sum[:], AppHash: sum[:]}
+		result, simulationState := s.SimulateWithOverrides(context.Background(), state, mockTx.GasLimit, mockTx, overrides, &hi)
+		require.NoError(t, result.Error)
+		stateHas(t, simulationState, "funds")
+		height, err := simulationState.GetReader(actorName)
+		require.NoError(t, err)
+		bz, err := height.Get([]byte("height"))
+		require.NoError(t, err)
+		require.Equal(t, "42", string(bz))
+
+		// the overrides are not applied to the provided state
+		stateNotHas(t, state, "funds")
+	})
+
 	t.Run("exec tx out of gas", func(t *testing.T) {
 		s := s.clone()
 
@@ -0,0 +1,202 @@
+// Package tracing defines a store.WriterMap recording the keys read and written
+// through it, used by stf to trace the keys accessed by each transaction.
+package tracing
+
+import (
+	"bytes"
+	"sort"
+
+	appmanager "cosmossdk.io/core/app"
+	"cosmossdk.io/core/store"
+)
+
+// accessKey identifies a key of the state of an actor.
+type accessKey struct {
+	actor, key string
+}
+
+// WriterMap wraps a store.WriterMap and records the keys read and written
+// through it. A key read after being written is not recorded as read, since
+// its value then comes from the writer itself.
+type WriterMap struct {
+	state       store.WriterMap
+	traceValues bool
+
+	reads  map[accessKey]appmanager.KeyAccess
+	writes map[accessKey]appmanager.KeyAccess
+}
+
+// NewWriterMap returns a WriterMap tracing the accesses to state, including the
+// values read and written if traceValues is true.
+func NewWriterMap(state store.WriterMap, traceValues bool) *WriterMap {
+	return &WriterMap{
+		state:       state,
+		traceValues: traceValues,
+		reads:       make(map[accessKey]appmanager.KeyAccess),
+		writes:      make(map[accessKey]appmanager.KeyAccess),
+	}
+}
+
+func (m *WriterMap) GetReader(actor []byte) (store.Reader, error) { return m.GetWriter(actor) }
+
+func (m *WriterMap) GetWriter(actor []byte) (store.Writer, error) {
+	state, err := m.state.GetWriter(actor)
+	if err != nil {
+		return nil, err
+	}
+	return &writer{parent: state, actor: actor, tracer: m}, nil
+}
+
+func (m *WriterMap) ApplyStateChanges(stateChanges []store.StateChanges) error {
+	for _, sc := range stateChanges {
+		for _, pair := range sc.StateChanges {
+			m.recordWrite(sc.Actor, pair.Key, pair.Value, pair.Remove)
+		}
+	}
+	return m.state.ApplyStateChanges(stateChanges)
+}
+
+func (m *WriterMap) GetStateChanges() ([]store.StateChanges, error) {
+	return m.state.GetStateChanges()
+}
+
+// Trace returns the keys read and written so far, sorted by actor and key.
+func (m *WriterMap) Trace() *appmanager.TxTrace {
+	return &appmanager.TxTrace{
+		Reads:  sortedAccesses(m.reads),
+		Writes: sortedAccesses(m.writes),
+	}
+}
+
+func (m *WriterMap) recordRead(actor, key, value []byte) {
+	k := accessKey{actor: string(actor), key: string(key)}
+	if _, ok := m.writes[k]; ok {
+		return
+	}
+	if _, ok := m.reads[k]; ok {
+		return
+	}
+	access := appmanager.KeyAccess{Actor: bytes.Clone(actor), Key: bytes.Clone(key)}
+	if m.traceValues {
+		access.Value = bytes.Clone(value)
+	}
+	m.reads[k] = access
+}
+
+func (m *WriterMap) recordWrite(actor, key, value []byte, remove bool) {
+	access := appmanager.KeyAccess{Actor: bytes.Clone(actor), Key: bytes.Clone(key), Remove: remove}
+	if m.traceValues && !remove {
+		access.Value = bytes.Clone(value)
+	}
+	m.writes[accessKey{actor: string(actor), key: string(key)}] = access
+}
+
+func sortedAccesses(accesses map[accessKey]appmanager.KeyAccess) []appmanager.KeyAccess {
+	sorted := make([]appmanager.KeyAccess, 0, len(accesses))
+	for _, access := range accesses {
+		sorted = append(sorted, access)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if c := bytes.Compare(sorted[i].Actor, sorted[j].Actor); c != 0 {
+			return c < 0
+		}
+		return bytes.Compare(sorted[i].Key, sorted[j].Key) < 0
+	})
+	return sorted
+}
+
+var _ store.Writer = (*writer)(nil)
+
+// writer records the accesses to the state of an actor.
+type writer struct {
+	parent store.Writer
+	actor  []byte
+	tracer *WriterMap
+}
+
+func (w *writer) Has(key []byte) (bool, error) {
+	has, err := w.parent.Has(key)
+	if err == nil {
+		w.tracer.recordRead(w.actor, key, nil)
+	}
+	return has, err
+}
+
+func (w *writer) Get(key []byte) ([]byte, error) {
+	value, err := w.parent.Get(key)
+	if err == nil {
+		w.tracer.recordRead(w.actor, key, value)
+	}
+	return value, err
+}
+
+func (w *writer) Iterator(start, end []byte) (store.Iterator, error) {
+	itr, err := w.parent.Iterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return newIterator(itr, w), nil
+}
+
+func (w *writer) ReverseIterator(start, end []byte) (store.Iterator, error) {
+	itr, err := w.parent.ReverseIterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	return newIterator(itr, w), nil
+}
+
+func (w *writer) Set(key, value []byte) error {
+	if err := w.parent.Set(key, value); err != nil {
+		return err
+	}
+	w.tracer.recordWrite(w.actor, key, value, false)
+	return nil
+}
+
+func (w *writer) Delete(key []byte) error {
+	if err := w.parent.Delete(key); err != nil {
+		return err
+	}
+	w.tracer.recordWrite(w.actor, key, nil, true)
+	return nil
+}
+
+func (w *writer) ApplyChangeSets(changes []store.KVPair) error {
+	if err := w.parent.ApplyChangeSets(changes); err != nil {
+		return err
+	}
+	for _, pair := range changes {
+		w.tracer.recordWrite(w.actor, pair.Key, pair.Value, pair.Remove)
+	}
+	return nil
+}
+
+func (w *writer) ChangeSets() ([]store.KVPair, error) {
+	return w.parent.ChangeSets()
+}
+
+var _ store.Iterator = (*iterator)(nil)
+
+// iterator records the keys it iterates over as read.
+type iterator struct {
+	store.Iterator
+	writer *writer
+}
+
+func newIterator(parent store.Iterator, w *writer) store.Iterator {
+	itr := &iterator{Iterator: parent, writer: w}
+	itr.record()
+	return itr
+}
+
+func (itr *iterator) Next() {
+	itr.Iterator.Next()
+	itr.record()
+}
+
+func (itr *iterator) record() {
+	if itr.Valid() {
+		itr.writer.tracer.recordRead(itr.writer.actor, itr.Key(), itr.Value())
+	}
+}
@@ -47,7 +47,10 @@ require (
 
 require github.com/cometbft/cometbft/api v1.0.0-rc.1
 
-require cosmossdk.io/x/consensus v0.0.0-00010101000000-000000000000
+require (
+	cosmossdk.io/schema v0.0.0-00010101000000-000000000000
+	cosmossdk.io/x/consensus v0.0.0-00010101000000-000000000000
+)
 
 require (
 	buf.build/gen/go/cometbft/cometbft/protocolbuffers/go v1.34.2-20240701160653-fedbb9acfd2f.2 // indirect
@@ -246,6 +249,7 @@ replace (
 	cosmossdk.io/core/testing => ../core/testing
 	cosmossdk.io/depinject => ../depinject
 	cosmossdk.io/log => ../log
+	cosmossdk.io/schema => ../schema
 	cosmossdk.io/store => ../store
 	cosmossdk.io/tools/confix => ../tools/confix
 	cosmossdk.io/x/accounts => ../x/accounts
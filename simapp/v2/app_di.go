@@ -14,6 +14,7 @@ import (
 	"cosmossdk.io/depinject"
 	"cosmossdk.io/runtime/v2"
 	serverv2 "cosmossdk.io/server/v2"
+	"cosmossdk.io/server/v2/cometbft/mempool"
 	"cosmossdk.io/store/v2"
 	"cosmossdk.io/store/v2/commitment/iavl"
 	"cosmossdk.io/store/v2/db"
@@ -46,6 +47,18 @@ import (
 // DefaultNodeHome default home directories for the application daemon
 var DefaultNodeHome string
 
+const (
+	// FlagParallelWorkers is the app.toml key of the number of txs of a block
+	// executed concurrently. The txs are executed sequentially by default.
+	FlagParallelWorkers = "stf.parallel-workers"
+	// FlagTraceAccess is the app.toml key enabling the tracing of the keys read
+	// and written by the txs of the blocks.
+	FlagTraceAccess = "stf.trace-access"
+	// FlagTraceValues is the app.toml key enabling the tracing of the values
+	// read and written by the txs of the blocks, along with their keys.
+	FlagTraceValues = "stf.trace-values"
+)
+
 // SimApp extends an ABCI application, but with most of its parameters exported.
 // They are exported for convenience in creating helper functions, as object
 // capabilities aren't needed for testing.
@@ -55,6 +68,7 @@ type SimApp[T transaction.Tx] struct {
 	appCodec          codec.Codec
 	txConfig          client.TxConfig
 	interfaceRegistry codectypes.InterfaceRegistry
+	mempool           mempool.Mempool[T] // the mempool of the consensus server
 
 	// keepers
 	AccountsKeeper        accounts.Keeper
@@ -206,7 +220,10 @@ func NewSimApp[T transaction.Tx](
 		panic(err)
 	}
 
-	app.App, err = appBuilder.Build()
+	app.App, err = appBuilder.Build(
+		runtime.AppBuilderWithParallelExecution[T](viper.GetInt(FlagParallelWorkers)),
+		runtime.AppBuilderWithAccessTracing[T](viper.GetBool(FlagTraceAccess), viper.GetBool(FlagTraceValues)),
+	)
 	if err != nil {
 		panic(err)
 	}
@@ -0,0 +1,151 @@
+package simapp
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	coreapp "cosmossdk.io/core/app"
+	"cosmossdk.io/core/comet"
+	corecontext "cosmossdk.io/core/context"
+	"cosmossdk.io/core/log"
+	"cosmossdk.io/core/store"
+	"cosmossdk.io/core/transaction"
+	banktypes "cosmossdk.io/x/bank/types"
+
+	"github.com/cosmos/cosmos-sdk/crypto/keys/secp256k1"
+	simtestutil "github.com/cosmos/cosmos-sdk/testutil/sims"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	_ "github.com/cosmos/cosmos-sdk/x/genutil" // import for side-effects
+)
+
+const testChainID = "simapp-v2-test"
+
+// TestParallelExecution checks that a block delivered with its txs executed
+// concurrently results in the app hash of its sequential delivery.
+func TestParallelExecution(t *testing.T) {
+	app := newTestApp(t, viper.New())
+	genesisJSON, txs := testBlock(t, app)
+	expectedResults, expectedHash := deliverTestBlock(t, app, genesisJSON, txs)
+	require.Len(t, expectedResults, len(txs))
+	for _, result := range expectedResults {
+		require.NoError(t, result.Error)
+	}
+
+	v := viper.New()
+	v.Set(FlagParallelWorkers, 4)
+	results, appHash := deliverTestBlock(t, newTestApp(t, v), genesisJSON, txs)
+	require.Equal(t, expectedResults, results)
+	require.Equal(t, expectedHash, appHash)
+}
+
+// TestAccessTracing checks that the keys accessed by the txs are traced when
+// enabled in the config.
+func TestAccessTracing(t *testing.T) {
+	app := newTestApp(t, viper.New())
+	genesisJSON, txs := testBlock(t, app)
+	results, _ := deliverTestBlock(t, app, genesisJSON, txs)
+	require.Nil(t, results[0].Trace)
+
+	v := viper.New()
+	v.Set(FlagTraceAccess, true)
+	results, _ = deliverTestBlock(t, newTestApp(t, v), genesisJSON, txs)
+	require.NotNil(t, results[0].Trace)
+	require.NotEmpty(t, results[0].Trace.Reads)
+	require.NotEmpty(t, results[0].Trace.Writes)
+	for _, write := range results[0].Trace.Writes {
+		require.Nil(t, write.Value)
+	}
+
+	v.Set(FlagTraceValues, true)
+	results, _ = deliverTestBlock(t, newTestApp(t, v), genesisJSON, txs)
+	require.NotNil(t, results[0].Trace.Writes[0].Value)
+}
+
+// newTestApp returns an app with the given config, whose node home is a
+// temporary directory.
+func newTestApp(t *testing.T, v *viper.Viper) *SimApp[transaction.Tx] {
+	t.Helper()
+	defaultNodeHome := DefaultNodeHome
+	DefaultNodeHome = t.TempDir()
+	defer func() { DefaultNodeHome = defaultNodeHome }()
+
+	app := NewSimApp[transaction.Tx](log.NewNopLogger(), v)
+	t.Cleanup(func() { require.NoError(t, app.Close()) })
+	return app
+}
+
+// testBlock returns a genesis funding senders, and txs of theirs all sending
+// to the same recipient, so that they conflict.
+func testBlock(t *testing.T, app *SimApp[transaction.Tx]) ([]byte, []transaction.Tx) {
+	t.Helper()
+	genesis := app.DefaultGenesis()
+	var bankGenesis banktypes.GenesisState
+	app.AppCodec().MustUnmarshalJSON(genesis[banktypes.ModuleName], &bankGenesis)
+	recipient := sdk.AccAddress(secp256k1.GenPrivKey().PubKey().Address())
+	r := rand.New(rand.NewSource(1))
+	var txs []transaction.Tx
+	for i := 0; i < 8; i++ {
+		priv := secp256k1.GenPrivKey()
+		sender := sdk.AccAddress(priv.PubKey().Address())
+		bankGenesis.Balances = append(bankGenesis.Balances, banktypes.Balance{
+			Address: sender.String(),
+			Coins:   sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 100)),
+		})
+		tx, err := simtestutil.GenSignedMockTx(r, app.TxConfig(),
+			[]sdk.Msg{banktypes.NewMsgSend(sender.String(), recipient.String(), sdk.NewCoins(sdk.NewInt64Coin(sdk.DefaultBondDenom, 10)))},
+			sdk.NewCoins(), simtestutil.DefaultGenTxGas, testChainID, []uint64{0}, []uint64{0}, priv)
+		require.NoError(t, err)
+		txs = append(txs, tx.(transaction.Tx))
+	}
+	genesis[banktypes.ModuleName] = app.AppCodec().MustMarshalJSON(&bankGenesis)
+	genesisJSON, err := json.Marshal(genesis)
+	require.NoError(t, err)
+	return genesisJSON, txs
+}
+
+// deliverTestBlock initializes the app with the genesis, delivers a block of
+// the txs, and returns their results and the app hash the block results in.
+func deliverTestBlock(
+	t *testing.T,
+	app *SimApp[transaction.Tx],
+	genesisJSON []byte,
+	txs []transaction.Tx,
+) ([]coreapp.TxResult, []byte) {
+	t.Helper()
+	hash := sha256.Sum256([]byte("test-hash"))
+	ctx := context.WithValue(context.Background(), corecontext.CometInfoKey, comet.Info{})
+	_, genesisState, err := app.GetAppManager().InitGenesis(ctx, &coreapp.BlockRequest[transaction.Tx]{
+		Time:      time.Date(2024, 2, 3, 18, 23, 0, 0, time.UTC),
+		Hash:      hash[:],
+		AppHash:   hash[:],
+		ChainId:   testChainID,
+		IsGenesis: true,
+	}, genesisJSON, nil)
+	require.NoError(t, err)
+	changes, err := genesisState.GetStateChanges()
+	require.NoError(t, err)
+	_, err = app.App.GetStore().Commit(&store.Changeset{Changes: changes})
+	require.NoError(t, err)
+
+	resp, newState, err := app.GetAppManager().DeliverBlock(ctx, &coreapp.BlockRequest[transaction.Tx]{
+		Height:  2,
+		Time:    time.Date(2024, 2, 3, 18, 24, 0, 0, time.UTC),
+		Hash:    hash[:],
+		AppHash: hash[:],
+		ChainId: testChainID,
+		Txs:     txs,
+	})
+	require.NoError(t, err)
+	changes, err = newState.GetStateChanges()
+	require.NoError(t, err)
+	appHash, err := app.App.GetStore().Commit(&store.Changeset{Changes: changes})
+	require.NoError(t, err)
+	return resp.TxResults, appHash
+}
@@ -0,0 +1,106 @@
+package simapp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc"
+
+	"cosmossdk.io/core/transaction"
+	serverv2grpc "cosmossdk.io/server/v2/api/grpc"
+	"cosmossdk.io/server/v2/cometbft/mempool"
+
+	mempoolservice "github.com/cosmos/cosmos-sdk/client/grpc/mempool"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkmempool "github.com/cosmos/cosmos-sdk/types/mempool"
+)
+
+var (
+	_ serverv2grpc.ServiceRegistrar = (*SimApp[transaction.Tx])(nil)
+	_ sdkmempool.Mempool            = consensusMempool[transaction.Tx]{}
+	_ sdkmempool.Snapshotter        = consensusMempool[transaction.Tx]{}
+)
+
+// SetMempool sets the mempool of the consensus server, whose pending
+// transactions are served by the mempool service.
+func (app *SimApp[T]) SetMempool(mp mempool.Mempool[T]) {
+	app.mempool = mp
+}
+
+// servedMempool is a mempool of the consensus server whose pending transactions
+// can be served by the mempool service: it counts its transactions and copies
+// them while holding its lock. The no-op mempool, which holds no transaction,
+// is not one.
+type servedMempool[T transaction.Tx] interface {
+	mempool.Mempool[T]
+
+	CountTx() int
+	Snapshot(context.Context) (mempool.Iterator[T], error)
+}
+
+// RegisterGRPCServices registers the gRPC services of the app which are not
+// module services on the gRPC server. The mempool service is only registered
+// when the mempool of the consensus server is set and can be served.
+func (app *SimApp[T]) RegisterGRPCServices(registrar grpc.ServiceRegistrar) error {
+	if mp, ok := app.mempool.(servedMempool[T]); ok {
+		mempoolservice.RegisterMempoolService(registrar, consensusMempool[T]{mp: mp}, app.txConfig.TxEncoder())
+	}
+	return nil
+}
+
+var errReadOnlyMempool = errors.New("the mempool of the consensus server is read-only")
+
+// consensusMempool exposes the mempool of the consensus server as a read-only
+// sdk mempool, the mempool service only reading its snapshots.
+type consensusMempool[T transaction.Tx] struct {
+	mp servedMempool[T]
+}
+
+func (m consensusMempool[T]) Insert(context.Context, sdk.Tx) error { return errReadOnlyMempool }
+
+func (m consensusMempool[T]) Remove(sdk.Tx) error { return errReadOnlyMempool }
+
+// Select selects no transaction, the transactions of the mempool of the
+// consensus server being selected by the consensus server itself.
+func (m consensusMempool[T]) Select(context.Context, [][]byte) sdkmempool.Iterator { return nil }
+
+func (m consensusMempool[T]) CountTx() int { return m.mp.CountTx() }
+
+// Snapshot returns an iterator over a snapshot of the mempool of the consensus
+// server. It fails if a transaction of the snapshot is not an sdk transaction.
+func (m consensusMempool[T]) Snapshot(ctx context.Context) (sdkmempool.Iterator, error) {
+	it, err := m.mp.Snapshot(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var txs []sdk.Tx
+	for ; it != nil; it = it.Next() {
+		tx, ok := any(it.Tx()).(sdk.Tx)
+		if !ok {
+			return nil, fmt.Errorf("transaction of type %T is not an sdk transaction", it.Tx())
+		}
+		txs = append(txs, tx)
+	}
+	return newConsensusMempoolIterator(txs), nil
+}
+
+// consensusMempoolIterator iterates over the transactions of a snapshot of the
+// mempool of the consensus server.
+type consensusMempoolIterator struct {
+	txs []sdk.Tx
+}
+
+func newConsensusMempoolIterator(txs []sdk.Tx) sdkmempool.Iterator {
+	if len(txs) == 0 {
+		return nil
+	}
+	return consensusMempoolIterator{txs: txs}
+}
+
+func (i consensusMempoolIterator) Next() sdkmempool.Iterator {
+	return newConsensusMempoolIterator(i.txs[1:])
+}
+
+func (i consensusMempoolIterator) Tx() sdk.Tx { return i.txs[0] }
@@ -16,6 +16,7 @@ import (
 	serverv2 "cosmossdk.io/server/v2"
 	"cosmossdk.io/server/v2/api/grpc"
 	"cosmossdk.io/server/v2/cometbft"
+	"cosmossdk.io/server/v2/cometbft/mempool"
 	"cosmossdk.io/simapp/v2"
 	confixcmd "cosmossdk.io/tools/confix/cmd"
 	authcmd "cosmossdk.io/x/auth/client/cli"
@@ -72,10 +73,14 @@ func (t *temporaryTxDecoder[T]) DecodeJSON(bz []byte) (T, error) {
 	return out, nil
 }
 
-func newApp[AppT serverv2.AppI[T], T transaction.Tx](
-	logger log.Logger, viper *viper.Viper,
-) AppT {
-	return serverv2.AppI[T](simapp.NewSimApp[T](logger, viper)).(AppT)
+// newApp returns the creator of the app, which serves the pending transactions
+// of the given mempool of the consensus server.
+func newApp[AppT serverv2.AppI[T], T transaction.Tx](mp mempool.Mempool[T]) serverv2.AppCreator[AppT, T] {
+	return func(logger log.Logger, viper *viper.Viper) AppT {
+		app := simapp.NewSimApp[T](logger, viper)
+		app.SetMempool(mp)
+		return serverv2.AppI[T](app).(AppT)
+	}
 }
 
 func initRootCmd[AppT serverv2.AppI[T], T transaction.Tx](
@@ -108,13 +113,17 @@ func initRootCmd[AppT serverv2.AppI[T], T transaction.Tx](
 		offchain.OffChain(),
 	)
 
+	// the app serves the pending transactions of the mempool of the consensus
+	// server, unless it is the default no-op mempool which holds none
+	cometOptions := cometbft.DefaultServerOptions[T]()
+
 	// Add empty server struct here for writing default config
 	if err = serverv2.AddCommands(
 		rootCmd,
-		newApp,
+		newApp[AppT, T](cometOptions.Mempool),
 		logger,
-		cometbft.New[AppT, T](&temporaryTxDecoder[T]{txConfig}, cometbft.DefaultServerOptions[T]()),
-		grpc.New[AppT, T](),
+		cometbft.New[AppT, T](&temporaryTxDecoder[T]{txConfig}, cometOptions),
+		grpc.New[AppT, T](&temporaryTxDecoder[T]{txConfig}),
 	); err != nil {
 		panic(err)
 	}
@@ -0,0 +1,63 @@
+package simapp
+
+import (
+	"fmt"
+
+	"cosmossdk.io/collections"
+	"cosmossdk.io/core/store"
+	"cosmossdk.io/core/transaction"
+	"cosmossdk.io/math"
+	"cosmossdk.io/server/v2/api/grpc/simulate"
+	banktypes "cosmossdk.io/x/bank/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ simulate.BalanceOverrider = (*SimApp[transaction.Tx])(nil)
+
+// The key codecs of the bank balances and of their denom to address index.
+var (
+	balancesKeyCodec     = collections.PairKeyCodec(sdk.AccAddressKey, collections.StringKey)
+	denomAddressKeyCodec = collections.PairKeyCodec(collections.StringKey, sdk.LengthPrefixedAddressKey(sdk.AccAddressKey)) //nolint:staticcheck // the index is length prefixed
+)
+
+// BalanceOverride returns the state changes setting the bank balance of a denom
+// of an account, to simulate txs of accounts without funds. The total supply is
+// left as is.
+func (app *SimApp[T]) BalanceOverride(address, denom, amount string) ([]store.StateChanges, error) {
+	addr, err := app.AuthKeeper.AddressCodec().StringToBytes(address)
+	if err != nil {
+		return nil, err
+	}
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return nil, err
+	}
+	amt, ok := math.NewIntFromString(amount)
+	if !ok || amt.IsNegative() {
+		return nil, fmt.Errorf("invalid amount %s", amount)
+	}
+
+	balanceKey, err := collections.EncodeKeyWithPrefix(
+		banktypes.BalancesPrefix, balancesKeyCodec, collections.Join(sdk.AccAddress(addr), denom),
+	)
+	if err != nil {
+		return nil, err
+	}
+	indexKey, err := collections.EncodeKeyWithPrefix(
+		banktypes.DenomAddressPrefix, denomAddressKeyCodec, collections.Join(denom, sdk.AccAddress(addr)),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// the bank module removes the zero balances
+	pairs := store.KVPairs{{Key: balanceKey, Remove: true}, {Key: indexKey, Remove: true}}
+	if !amt.IsZero() {
+		value, err := banktypes.BalanceValueCodec.Encode(amt)
+		if err != nil {
+			return nil, err
+		}
+		pairs = store.KVPairs{{Key: balanceKey, Value: value}, {Key: indexKey, Value: []byte{}}}
+	}
+	return []store.StateChanges{{Actor: []byte(banktypes.StoreKey), StateChanges: pairs}}, nil
+}
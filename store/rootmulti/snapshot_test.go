@@ -9,6 +9,7 @@ import (
 	"io"
 	"math/rand"
 	"testing"
+	"time"
 
 	dbm "github.com/cosmos/cosmos-db"
 	"github.com/stretchr/testify/assert"
@@ -233,6 +234,140 @@ func TestMultistoreSnapshotRestore(t *testing.T) {
 	}
 }
 
+func TestMultistoreSnapshotRestoreDelta(t *testing.T) {
+	source := newMultiStoreWithMixedMountsAndBasicData(dbm.NewMemDB())
+	// a version without changes
+	source.Commit()
+	version := uint64(source.LastCommitID().Version)
+	require.EqualValues(t, 4, version)
+	require.True(t, source.CanSnapshotDelta(1, version))
+	require.False(t, source.CanSnapshotDelta(version, version))
+	require.False(t, source.CanSnapshotDelta(1, version+1))
+
+	snapshot := func(write func(protoWriter *snapshots.StreamWriter) error) []snapshottypes.SnapshotItem {
+		chunks := make(chan io.ReadCloser, 100)
+		go func() {
+			streamWriter := snapshots.NewStreamWriter(chunks)
+			defer streamWriter.Close()
+			require.NoError(t, write(streamWriter))
+		}()
+		streamReader, err := snapshots.NewStreamReader(chunks)
+		require.NoError(t, err)
+		defer streamReader.Close()
+		items := []snapshottypes.SnapshotItem{}
+		for {
+			item := snapshottypes.SnapshotItem{}
+			err := streamReader.ReadMsg(&item)
+			if errors.Is(err, io.EOF) {
+				return items
+			}
+			require.NoError(t, err)
+			items = append(items, item)
+		}
+	}
+	restore := func(items []snapshottypes.SnapshotItem, read func(protoReader *snapshots.StreamReader) error) error {
+		chunks := make(chan io.ReadCloser, 100)
+		go func() {
+			streamWriter := snapshots.NewStreamWriter(chunks)
+			defer streamWriter.Close()
+			for i := range items {
+				require.NoError(t, streamWriter.WriteMsg(&items[i]))
+			}
+		}()
+		streamReader, err := snapshots.NewStreamReader(chunks)
+		require.NoError(t, err)
+		defer streamReader.Close()
+		return read(streamReader)
+	}
+
+	full := snapshot(func(protoWriter *snapshots.StreamWriter) error {
+		return source.Snapshot(1, protoWriter)
+	})
+	delta := snapshot(func(protoWriter *snapshots.StreamWriter) error {
+		return source.SnapshotDelta(1, version, protoWriter)
+	})
+
+	// the changes of each version are in the order of the stores and keys
+	var items []string
+	for _, item := range delta {
+		switch item := item.Item.(type) {
+		case *snapshottypes.SnapshotItem_Delta:
+			items = append(items, fmt.Sprintf("version %d", item.Delta.Version))
+		case *snapshottypes.SnapshotItem_Store:
+			items = append(items, item.Store.Name)
+		case *snapshottypes.SnapshotItem_Change:
+			items = append(items, fmt.Sprintf("%s=%v delete=%v", item.Change.Key, item.Change.Value, item.Change.Delete))
+		}
+	}
+	require.Equal(t, []string{
+		"version 2", "iavl1", "b=[2] delete=false", "c=[3] delete=false", "iavl2", "B=[102] delete=false",
+		"version 3", "iavl2", "C=[103] delete=false", "X=[] delete=true",
+		"version 4",
+	}, items)
+
+	target := newMultiStoreWithMixedMounts(dbm.NewMemDB())
+	require.NoError(t, restore(full, func(protoReader *snapshots.StreamReader) error {
+		_, err := target.Restore(1, snapshottypes.CurrentFormat, protoReader)
+		return err
+	}))
+	require.Error(t, restore(delta, func(protoReader *snapshots.StreamReader) error {
+		_, err := target.RestoreDelta(2, version, protoReader)
+		return err
+	}))
+	require.NoError(t, restore(delta, func(protoReader *snapshots.StreamReader) error {
+		_, err := target.RestoreDelta(1, version, protoReader)
+		return err
+	}))
+
+	assert.Equal(t, source.LastCommitID(), target.LastCommitID())
+	for _, key := range source.StoreKeysByName() {
+		sourceStore := source.GetStoreByName(key.Name()).(types.CommitKVStore)
+		targetStore := target.GetStoreByName(key.Name()).(types.CommitKVStore)
+		if sourceStore.GetStoreType() == types.StoreTypeIAVL {
+			assertStoresEqual(t, sourceStore, targetStore, "store %q not equal", key.Name())
+		}
+	}
+
+	// a change missing from the snapshot is detected by the hash verification
+	target = newMultiStoreWithMixedMounts(dbm.NewMemDB())
+	require.NoError(t, restore(full, func(protoReader *snapshots.StreamReader) error {
+		_, err := target.Restore(1, snapshottypes.CurrentFormat, protoReader)
+		return err
+	}))
+	err := restore(append(delta[:2:2], delta[3:]...), func(protoReader *snapshots.StreamReader) error {
+		_, err := target.RestoreDelta(1, version, protoReader)
+		return err
+	})
+	require.ErrorIs(t, err, snapshottypes.ErrDeltaHashMismatch)
+}
+
+func TestMultistoreSnapshotDeltaPrunedBase(t *testing.T) {
+	source := newMultiStoreWithMixedMountsAndBasicData(dbm.NewMemDB())
+	snapshotStore, err := snapshots.NewStore(dbm.NewMemDB(), t.TempDir())
+	require.NoError(t, err)
+	opts := snapshottypes.NewSnapshotOptions(1, 2).WithMaxDeltas(2)
+	manager := snapshots.NewManager(snapshotStore, opts, source, nil, log.NewNopLogger())
+
+	snapshot, err := manager.Create(2)
+	require.NoError(t, err)
+	require.Equal(t, snapshottypes.CurrentFormat, snapshot.Format)
+
+	// the changes since the latest snapshot are available
+	source.Commit()
+	require.True(t, source.CanSnapshotDelta(2, 4))
+
+	// once the base version is pruned, a full snapshot is taken instead of an incremental one
+	require.NoError(t, source.PruneStores(2))
+	// IAVL versions are pruned asynchronously
+	require.Eventually(t, func() bool {
+		return !source.CanSnapshotDelta(2, 4)
+	}, time.Second, 10*time.Millisecond)
+	snapshot, err = manager.Create(4)
+	require.NoError(t, err)
+	require.Equal(t, snapshottypes.CurrentFormat, snapshot.Format)
+	require.Zero(t, snapshot.Metadata.BaseHeight)
+}
+
 func benchmarkMultistoreSnapshot(b *testing.B, stores uint8, storeKeys uint64) {
 	b.Helper()
 	b.Skip("Noisy with slow setup time, please see https://github.com/cosmos/cosmos-sdk/issues/8855.")
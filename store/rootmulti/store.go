@@ -1,6 +1,7 @@
 package rootmulti
 
 import (
+	"bytes"
 	"crypto/sha256"
 	"errors"
 	"fmt"
@@ -842,28 +843,10 @@ func (rs *Store) Snapshot(height uint64, protoWriter protoio.Writer) error {
 		return errorsmod.Wrapf(types.ErrLogic, "cannot snapshot future height %v", height)
 	}
 
-	// Collect stores to snapshot (only IAVL stores are supported)
-	type namedStore struct {
-		*iavl.Store
-		name string
-	}
-	stores := []namedStore{}
-	keys := keysFromStoreKeyMap(rs.stores)
-	for _, key := range keys {
-		switch store := rs.GetCommitKVStore(key).(type) {
-		case *iavl.Store:
-			stores = append(stores, namedStore{name: key.Name(), Store: store})
-		case *transient.Store, *mem.Store:
-			// Non-persisted stores shouldn't be snapshotted
-			continue
-		default:
-			return errorsmod.Wrapf(types.ErrLogic,
-				"don't know how to snapshot store %q of type %T", key.Name(), store)
-		}
+	stores, err := rs.snapshotStores()
+	if err != nil {
+		return err
 	}
-	sort.Slice(stores, func(i, j int) bool {
-		return strings.Compare(stores[i].name, stores[j].name) == -1
-	})
 
 	// Export each IAVL store. Stores are serialized as a stream of SnapshotItem Protobuf
 	// messages. The first item contains a SnapshotStore with store metadata (i.e. name),
@@ -927,6 +910,226 @@ func (rs *Store) Snapshot(height uint64, protoWriter protoio.Writer) error {
 	return nil
 }
 
+// namedStore is an IAVL store to snapshot, with its name.
+type namedStore struct {
+	*iavl.Store
+	name string
+}
+
+// snapshotStores returns the stores to snapshot, sorted by name.
+func (rs *Store) snapshotStores() ([]namedStore, error) {
+	// Collect stores to snapshot (only IAVL stores are supported)
+	stores := []namedStore{}
+	keys := keysFromStoreKeyMap(rs.stores)
+	for _, key := range keys {
+		switch store := rs.GetCommitKVStore(key).(type) {
+		case *iavl.Store:
+			stores = append(stores, namedStore{name: key.Name(), Store: store})
+		case *transient.Store, *mem.Store:
+			// Non-persisted stores shouldn't be snapshotted
+			continue
+		default:
+			return nil, errorsmod.Wrapf(types.ErrLogic,
+				"don't know how to snapshot store %q of type %T", key.Name(), store)
+		}
+	}
+	sort.Slice(stores, func(i, j int) bool {
+		return strings.Compare(stores[i].name, stores[j].name) == -1
+	})
+	return stores, nil
+}
+
+// CanSnapshotDelta implements snapshottypes.DeltaSnapshotter. IAVL versions are pruned from the
+// oldest, so the changes since the base height are available as long as every store still has
+// the base version. Once the base version is pruned, it returns false and the snapshot manager
+// silently takes a full snapshot instead of an incremental one, so the pruning settings must keep
+// the heights of the snapshots for incremental snapshots to be taken.
+func (rs *Store) CanSnapshotDelta(base, height uint64) bool {
+	if base == 0 || base >= height || height > uint64(GetLatestVersion(rs.db)) {
+		return false
+	}
+	stores, err := rs.snapshotStores()
+	if err != nil {
+		return false
+	}
+	for _, store := range stores {
+		if !store.VersionExists(int64(base)) {
+			return false
+		}
+	}
+	return true
+}
+
+// SnapshotDelta implements snapshottypes.DeltaSnapshotter. For each version in (base, height], a
+// SnapshotDeltaItem with the commit hash of the version is followed by a SnapshotStoreItem for
+// each store changed at the version, itself followed by a SnapshotChangeItem for each changed
+// key, in the order of the keys.
+func (rs *Store) SnapshotDelta(base, height uint64, protoWriter protoio.Writer) error {
+	if !rs.CanSnapshotDelta(base, height) {
+		return errorsmod.Wrapf(types.ErrLogic, "cannot snapshot the changes from height %v to %v", base, height)
+	}
+
+	stores, err := rs.snapshotStores()
+	if err != nil {
+		return err
+	}
+
+	for version := int64(base) + 1; version <= int64(height); version++ {
+		cInfo, err := rs.GetCommitInfo(version)
+		if err != nil {
+			return errorsmod.Wrapf(err, "failed to get commit info of version %v", version)
+		}
+		err = protoWriter.WriteMsg(&snapshottypes.SnapshotItem{
+			Item: &snapshottypes.SnapshotItem_Delta{
+				Delta: &snapshottypes.SnapshotDeltaItem{
+					Version: uint64(version),
+					Hash:    cInfo.Hash(),
+				},
+			},
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, store := range stores {
+			err := store.TraverseStateChanges(version, version, func(_ int64, changeSet *iavltree.ChangeSet) error {
+				if len(changeSet.Pairs) == 0 {
+					return nil
+				}
+				err := protoWriter.WriteMsg(&snapshottypes.SnapshotItem{
+					Item: &snapshottypes.SnapshotItem_Store{
+						Store: &snapshottypes.SnapshotStoreItem{
+							Name: store.name,
+						},
+					},
+				})
+				if err != nil {
+					return err
+				}
+				for _, pair := range changeSet.Pairs {
+					err := protoWriter.WriteMsg(&snapshottypes.SnapshotItem{
+						Item: &snapshottypes.SnapshotItem_Change{
+							Change: &snapshottypes.SnapshotChangeItem{
+								Key:    pair.Key,
+								Value:  pair.Value,
+								Delete: pair.Delete,
+							},
+						},
+					})
+					if err != nil {
+						return err
+					}
+				}
+				return nil
+			})
+			if err != nil {
+				rs.logger.Error("delta snapshot failed", "store", store.name, "version", version, "err", err)
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// RestoreDelta implements snapshottypes.DeltaSnapshotter. The changes of each version are
+// written in the stores and committed, and the resulting commit hash is verified against the one
+// recorded in the snapshot.
+// returns next snapshot item and error.
+func (rs *Store) RestoreDelta(
+	base, height uint64, protoReader protoio.Reader,
+) (snapshottypes.SnapshotItem, error) {
+	if latest := rs.LastCommitID().Version; latest != int64(base) {
+		return snapshottypes.SnapshotItem{}, errorsmod.Wrapf(types.ErrLogic,
+			"cannot restore the changes since height %v at height %v", base, latest)
+	}
+
+	var (
+		store   *iavl.Store
+		version uint64
+		hash    []byte
+	)
+	// commit commits the changes of the current version, if any.
+	commit := func() error {
+		if version == 0 {
+			return nil
+		}
+		commitID := rs.Commit()
+		if uint64(commitID.Version) != version {
+			return errorsmod.Wrapf(types.ErrLogic, "committed version %v, expected %v", commitID.Version, version)
+		}
+		if !bytes.Equal(commitID.Hash, hash) {
+			return errorsmod.Wrapf(snapshottypes.ErrDeltaHashMismatch,
+				"version %v: expected %X, got %X", version, hash, commitID.Hash)
+		}
+		return nil
+	}
+
+	var snapshotItem snapshottypes.SnapshotItem
+loop:
+	for {
+		snapshotItem = snapshottypes.SnapshotItem{}
+		err := protoReader.ReadMsg(&snapshotItem)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return snapshottypes.SnapshotItem{}, errorsmod.Wrap(err, "invalid protobuf message")
+		}
+
+		switch item := snapshotItem.Item.(type) {
+		case *snapshottypes.SnapshotItem_Delta:
+			if err := commit(); err != nil {
+				return snapshottypes.SnapshotItem{}, err
+			}
+			if expected := max(version, base) + 1; item.Delta.Version != expected {
+				return snapshottypes.SnapshotItem{}, errorsmod.Wrapf(types.ErrLogic,
+					"received changes of version %v, expected %v", item.Delta.Version, expected)
+			}
+			version, hash, store = item.Delta.Version, item.Delta.Hash, nil
+			rs.logger.Debug("restoring delta snapshot", "version", version)
+
+		case *snapshottypes.SnapshotItem_Store:
+			if version == 0 {
+				return snapshottypes.SnapshotItem{}, errorsmod.Wrap(types.ErrLogic, "received store item before delta item")
+			}
+			var ok bool
+			store, ok = rs.GetStoreByName(item.Store.Name).(*iavl.Store)
+			if !ok || store == nil {
+				return snapshottypes.SnapshotItem{}, errorsmod.Wrapf(types.ErrLogic, "cannot restore changes into non-IAVL store %q", item.Store.Name)
+			}
+
+		case *snapshottypes.SnapshotItem_Change:
+			if store == nil {
+				return snapshottypes.SnapshotItem{}, errorsmod.Wrap(types.ErrLogic, "received change item before store item")
+			}
+			if item.Change.Delete {
+				store.Delete(item.Change.Key)
+				continue
+			}
+			// Protobuf does not differentiate between []byte{} as nil, but IAVL does not allow nil
+			// values, so we can always set them to empty.
+			value := item.Change.Value
+			if value == nil {
+				value = []byte{}
+			}
+			store.Set(item.Change.Key, value)
+
+		default:
+			break loop
+		}
+	}
+
+	if err := commit(); err != nil {
+		return snapshottypes.SnapshotItem{}, err
+	}
+	if version != height {
+		return snapshottypes.SnapshotItem{}, errorsmod.Wrapf(types.ErrLogic,
+			"restored the changes up to version %v, expected %v", version, height)
+	}
+
+	return snapshotItem, nil
+}
+
 // Restore implements snapshottypes.Snapshotter.
 // returns next snapshot item and error.
 func (rs *Store) Restore(
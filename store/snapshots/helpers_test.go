@@ -6,6 +6,7 @@ import (
 	"compress/zlib"
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"io"
 	"os"
 	"testing"
@@ -203,6 +204,89 @@ func (m *mockErrorSnapshotter) GetSnapshotInterval() uint64 {
 func (m *mockErrorSnapshotter) SetSnapshotInterval(snapshotInterval uint64) {
 }
 
+// mockDeltaSnapshotter is a snapshotter whose state is a list of items only ever appended to, the
+// changes of a height being the items appended at the height.
+type mockDeltaSnapshotter struct {
+	mockSnapshotter
+	// versions are the items of each height.
+	versions map[uint64][][]byte
+	// height is the restored height.
+	height uint64
+}
+
+var _ snapshottypes.DeltaSnapshotter = (*mockDeltaSnapshotter)(nil)
+
+func (m *mockDeltaSnapshotter) Snapshot(height uint64, protoWriter protoio.Writer) error {
+	for _, item := range m.versions[height] {
+		if err := snapshottypes.WriteExtensionPayload(protoWriter, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockDeltaSnapshotter) Restore(
+	height uint64, format uint32, protoReader protoio.Reader,
+) (snapshottypes.SnapshotItem, error) {
+	item, err := m.mockSnapshotter.Restore(height, format, protoReader)
+	m.height = height
+	return item, err
+}
+
+func (m *mockDeltaSnapshotter) CanSnapshotDelta(base, height uint64) bool {
+	return m.versions[base] != nil && m.versions[height] != nil
+}
+
+func (m *mockDeltaSnapshotter) SnapshotDelta(base, height uint64, protoWriter protoio.Writer) error {
+	err := protoWriter.WriteMsg(&snapshottypes.SnapshotItem{
+		Item: &snapshottypes.SnapshotItem_Delta{
+			Delta: &snapshottypes.SnapshotDeltaItem{Version: height},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, item := range m.versions[height][len(m.versions[base]):] {
+		if err := snapshottypes.WriteExtensionPayload(protoWriter, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockDeltaSnapshotter) RestoreDelta(
+	base, height uint64, protoReader protoio.Reader,
+) (snapshottypes.SnapshotItem, error) {
+	if m.height != base {
+		return snapshottypes.SnapshotItem{}, fmt.Errorf("cannot restore the changes since %d at %d", base, m.height)
+	}
+
+	var item snapshottypes.SnapshotItem
+	if err := protoReader.ReadMsg(&item); err != nil {
+		return snapshottypes.SnapshotItem{}, err
+	}
+	if item.GetDelta().GetVersion() != height {
+		return snapshottypes.SnapshotItem{}, fmt.Errorf("unexpected item %v", item)
+	}
+	for {
+		item.Reset()
+		err := protoReader.ReadMsg(&item)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return snapshottypes.SnapshotItem{}, errorsmod.Wrap(err, "invalid protobuf message")
+		}
+		payload := item.GetExtensionPayload()
+		if payload == nil {
+			break
+		}
+		m.items = append(m.items, payload.Payload)
+	}
+	m.height = height
+
+	return item, nil
+}
+
 // setupBusyManager creates a manager with an empty store that is busy creating a snapshot at height 1.
 // The snapshot will complete when the returned closer is called.
 func setupBusyManager(t *testing.T) *snapshots.Manager {
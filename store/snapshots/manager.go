@@ -183,14 +183,58 @@ func (m *Manager) Create(height uint64) (*types.Snapshot, error) {
 
 	// Spawn goroutine to generate snapshot chunks and pass their io.ReadClosers through a channel
 	ch := make(chan io.ReadCloser)
+	if latest != nil && m.canSnapshotDelta(latest, height) {
+		go m.createDeltaSnapshot(latest.Height, height, ch)
+
+		return m.store.SaveDelta(height, latest.Height, ch)
+	}
 	go m.createSnapshot(height, ch)
 
 	return m.store.Save(height, types.CurrentFormat, ch)
 }
 
+// canSnapshotDelta returns whether an incremental snapshot of the changes since the latest
+// snapshot can be taken at the given height, without exceeding the maximum number of
+// incremental snapshots after a full one.
+func (m *Manager) canSnapshotDelta(latest *types.Snapshot, height uint64) bool {
+	if m.opts.MaxDeltas == 0 {
+		return false
+	}
+	snapshotter, ok := m.multistore.(types.DeltaSnapshotter)
+	if !ok {
+		return false
+	}
+	chain, err := m.store.Chain(latest)
+	if err != nil {
+		m.logger.Error("failed to resolve the latest snapshot chain, taking a full snapshot", "height", latest.Height, "err", err)
+		return false
+	}
+	// the chain starts with a full snapshot
+	if uint32(len(chain)-1) >= m.opts.MaxDeltas {
+		return false
+	}
+	return snapshotter.CanSnapshotDelta(latest.Height, height)
+}
+
 // createSnapshot do the heavy work of snapshotting after the validations of request are done
 // the produced chunks are written to the channel.
 func (m *Manager) createSnapshot(height uint64, ch chan<- io.ReadCloser) {
+	m.writeSnapshot(height, ch, func(streamWriter *StreamWriter) error {
+		return m.multistore.Snapshot(height, streamWriter)
+	})
+}
+
+// createDeltaSnapshot is like createSnapshot, but writes the changes since the base height
+// instead of the full state.
+func (m *Manager) createDeltaSnapshot(base, height uint64, ch chan<- io.ReadCloser) {
+	m.writeSnapshot(height, ch, func(streamWriter *StreamWriter) error {
+		return m.multistore.(types.DeltaSnapshotter).SnapshotDelta(base, height, streamWriter)
+	})
+}
+
+// writeSnapshot writes the multistore items with the given function, followed by the extension
+// snapshots, to the channel.
+func (m *Manager) writeSnapshot(height uint64, ch chan<- io.ReadCloser, snapshot func(*StreamWriter) error) {
 	streamWriter := NewStreamWriter(ch)
 	if streamWriter == nil {
 		return
@@ -201,7 +245,7 @@ func (m *Manager) createSnapshot(height uint64, ch chan<- io.ReadCloser) {
 		}
 	}()
 
-	if err := m.multistore.Snapshot(height, streamWriter); err != nil {
+	if err := snapshot(streamWriter); err != nil {
 		streamWriter.CloseWithError(err)
 		return
 	}
@@ -231,8 +275,20 @@ func (m *Manager) createSnapshot(height uint64, ch chan<- io.ReadCloser) {
 }
 
 // List lists snapshots, mirroring ABCI ListSnapshots. It can be concurrent with other operations.
+// Incremental snapshots are not listed, as they can't be restored through state sync.
 func (m *Manager) List() ([]*types.Snapshot, error) {
-	return m.store.List()
+	snapshots, err := m.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make([]*types.Snapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if snapshot.Format != types.FormatDelta {
+			listed = append(listed, snapshot)
+		}
+	}
+	return listed, nil
 }
 
 // LoadChunk loads a chunk into a byte slice, mirroring ABCI LoadChunk. It can be called
@@ -274,7 +330,8 @@ func (m *Manager) Restore(snapshot types.Snapshot) error {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
-	// check multistore supported format preemptive
+	// check multistore supported format preemptive, incremental snapshots can only be restored
+	// locally on top of their base snapshot, see RestoreLocalSnapshot.
 	if snapshot.Format != types.CurrentFormat {
 		return errorsmod.Wrapf(types.ErrUnknownFormat, "snapshot format %v", snapshot.Format)
 	}
@@ -303,7 +360,7 @@ func (m *Manager) Restore(snapshot types.Snapshot) error {
 	chChunks := m.loadChunkStream(snapshot.Height, snapshot.Format, chChunkIDs)
 
 	go func() {
-		err := m.doRestoreSnapshot(snapshot, chChunks)
+		err := m.doRestoreSnapshot(snapshot, chChunks, true)
 		chDone <- restoreDone{
 			complete: err == nil,
 			err:      err,
@@ -337,7 +394,9 @@ func (m *Manager) loadChunkStream(height uint64, format uint32, chunkIDs <-chan
 }
 
 // doRestoreSnapshot do the heavy work of snapshot restoration after preliminary checks on request have passed.
-func (m *Manager) doRestoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.ReadCloser) error {
+// The extensions are only restored if restoreExtensions is true, as restoring the snapshots of a chain only
+// needs the extensions of its last snapshot.
+func (m *Manager) doRestoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.ReadCloser, restoreExtensions bool) error {
 	dir := m.store.pathSnapshot(snapshot.Height, snapshot.Format)
 	if err := os.MkdirAll(dir, 0o750); err != nil {
 		return errorsmod.Wrapf(err, "failed to create snapshot directory %q", dir)
@@ -363,9 +422,23 @@ func (m *Manager) doRestoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.
 		return payload.Payload, nil
 	}
 
-	nextItem, err = m.multistore.Restore(snapshot.Height, snapshot.Format, streamReader)
-	if err != nil {
-		return errorsmod.Wrap(err, "multistore restore")
+	if snapshot.Format == types.FormatDelta {
+		snapshotter, ok := m.multistore.(types.DeltaSnapshotter)
+		if !ok {
+			return errorsmod.Wrapf(types.ErrUnknownFormat, "snapshot format %v", snapshot.Format)
+		}
+		nextItem, err = snapshotter.RestoreDelta(snapshot.Metadata.BaseHeight, snapshot.Height, streamReader)
+		if err != nil {
+			return errorsmod.Wrap(err, "multistore delta restore")
+		}
+	} else {
+		nextItem, err = m.multistore.Restore(snapshot.Height, snapshot.Format, streamReader)
+		if err != nil {
+			return errorsmod.Wrap(err, "multistore restore")
+		}
+	}
+	if !restoreExtensions {
+		return nil
 	}
 
 	for {
@@ -460,9 +533,11 @@ func (m *Manager) RestoreChunk(chunk []byte) (bool, error) {
 	return false, nil
 }
 
-// RestoreLocalSnapshot restores app state from a local snapshot.
+// RestoreLocalSnapshot restores app state from a local snapshot. An incremental snapshot is
+// restored by restoring the full snapshot it is based on, followed by the incremental snapshots
+// up to it.
 func (m *Manager) RestoreLocalSnapshot(height uint64, format uint32) error {
-	snapshot, ch, err := m.store.Load(height, format)
+	snapshot, err := m.store.Get(height, format)
 	if err != nil {
 		return err
 	}
@@ -471,6 +546,11 @@ func (m *Manager) RestoreLocalSnapshot(height uint64, format uint32) error {
 		return fmt.Errorf("snapshot doesn't exist, height: %d, format: %d", height, format)
 	}
 
+	chain, err := m.store.Chain(snapshot)
+	if err != nil {
+		return err
+	}
+
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
@@ -480,7 +560,16 @@ func (m *Manager) RestoreLocalSnapshot(height uint64, format uint32) error {
 	}
 	defer m.endLocked()
 
-	return m.doRestoreSnapshot(*snapshot, ch)
+	for i, snapshot := range chain {
+		_, ch, err := m.store.Load(snapshot.Height, snapshot.Format)
+		if err != nil {
+			return err
+		}
+		if err := m.doRestoreSnapshot(*snapshot, ch, i == len(chain)-1); err != nil {
+			return errorsmod.Wrapf(err, "restore snapshot at height %d, format %d", snapshot.Height, snapshot.Format)
+		}
+	}
+	return nil
 }
 
 // sortedExtensionNames sort extension names for deterministic iteration.
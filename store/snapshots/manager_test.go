@@ -247,6 +247,84 @@ func TestManager_Restore(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestManager_Delta(t *testing.T) {
+	store, err := snapshots.NewStore(db.NewMemDB(), GetTempDir(t))
+	require.NoError(t, err)
+	source := &mockDeltaSnapshotter{
+		mockSnapshotter: mockSnapshotter{prunedHeights: make(map[int64]struct{})},
+		versions:        make(map[uint64][][]byte),
+	}
+	items := [][]byte{}
+	for height := uint64(1); height <= 5; height++ {
+		items = append(items, []byte{byte(height)})
+		source.versions[height] = items[:len(items):len(items)]
+	}
+	deltaOpts := types.NewSnapshotOptions(1, 2)
+	deltaOpts.MaxDeltas = 2
+	manager := snapshots.NewManager(store, deltaOpts, source, nil, log.NewNopLogger())
+	require.NoError(t, manager.RegisterExtensions(newExtSnapshotter(3)))
+
+	// a full snapshot is followed by up to MaxDeltas incremental snapshots
+	expected := []struct {
+		format uint32
+		base   uint64
+	}{
+		{types.CurrentFormat, 0},
+		{types.FormatDelta, 1},
+		{types.FormatDelta, 2},
+		{types.CurrentFormat, 0},
+		{types.FormatDelta, 4},
+	}
+	for i, e := range expected {
+		height := uint64(i + 1)
+		snapshot, err := manager.Create(height)
+		require.NoError(t, err)
+		require.Equal(t, e.format, snapshot.Format, "height %d", height)
+		require.Equal(t, e.base, snapshot.Metadata.BaseHeight, "height %d", height)
+	}
+
+	// an incremental snapshot is restored on top of its chain
+	for height := uint64(1); height <= 5; height++ {
+		target := &mockDeltaSnapshotter{
+			mockSnapshotter: mockSnapshotter{prunedHeights: make(map[int64]struct{})},
+		}
+		extSnapshotter := newExtSnapshotter(0)
+		manager := snapshots.NewManager(store, deltaOpts, target, nil, log.NewNopLogger())
+		require.NoError(t, manager.RegisterExtensions(extSnapshotter))
+
+		require.NoError(t, manager.RestoreLocalSnapshot(height, expected[height-1].format))
+		require.Equal(t, source.versions[height], target.items)
+		require.Equal(t, height, target.height)
+		require.Len(t, extSnapshotter.state, 3)
+	}
+
+	// incremental snapshots can't be restored through state sync
+	snapshot, err := store.Get(5, types.FormatDelta)
+	require.NoError(t, err)
+	err = manager.Restore(*snapshot)
+	require.ErrorIs(t, err, types.ErrUnknownFormat)
+
+	// nor are they listed for state sync
+	list, err := manager.List()
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+	for _, snapshot := range list {
+		require.NotEqual(t, types.FormatDelta, snapshot.Format, "height %d", snapshot.Height)
+	}
+
+	// the chain of the retained incremental snapshot is retained
+	pruned, err := manager.Prune(1)
+	require.NoError(t, err)
+	require.EqualValues(t, 3, pruned)
+
+	// without the intermediate heights, a full snapshot is taken
+	source.versions[6] = append(source.versions[5], []byte{6})
+	delete(source.versions, 5)
+	snapshot, err = manager.Create(6)
+	require.NoError(t, err)
+	require.Equal(t, types.CurrentFormat, snapshot.Format)
+}
+
 func TestManager_TakeError(t *testing.T) {
 	snapshotter := &mockErrorSnapshotter{}
 	store, err := snapshots.NewStore(db.NewMemDB(), GetTempDir(t))
@@ -112,6 +112,53 @@ func (s *Store) GetLatest() (*types.Snapshot, error) {
 	return snapshot, errors.Wrap(err, "failed to find latest snapshot")
 }
 
+// Chain returns the snapshots needed to restore the given snapshot, in order of restoration: the
+// full snapshot it is based on, followed by the incremental snapshots up to the snapshot itself.
+func (s *Store) Chain(snapshot *types.Snapshot) ([]*types.Snapshot, error) {
+	chain := []*types.Snapshot{snapshot}
+	for snapshot.Format == types.FormatDelta {
+		base, err := s.getAtHeight(snapshot.Metadata.BaseHeight)
+		if err != nil {
+			return nil, err
+		}
+		if base == nil {
+			return nil, errors.Wrapf(types.ErrInvalidMetadata,
+				"base snapshot at height %v of snapshot at height %v not found", snapshot.Metadata.BaseHeight, snapshot.Height)
+		}
+		if base.Height >= snapshot.Height {
+			return nil, errors.Wrapf(types.ErrInvalidMetadata,
+				"base snapshot height %v is not lower than snapshot height %v", base.Height, snapshot.Height)
+		}
+		chain = append(chain, base)
+		snapshot = base
+	}
+
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain, nil
+}
+
+// getAtHeight fetches the snapshot at the given height, of the lowest format if there are several.
+func (s *Store) getAtHeight(height uint64) (*types.Snapshot, error) {
+	iter, err := s.db.Iterator(encodeKey(height, 0), encodeKey(height, math.MaxUint32))
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to find snapshot for height %v", height)
+	}
+	defer iter.Close()
+
+	var snapshot *types.Snapshot
+	if iter.Valid() {
+		snapshot = &types.Snapshot{}
+		err := proto.Unmarshal(iter.Value(), snapshot)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode snapshot metadata for height %v", height)
+		}
+	}
+	err = iter.Error()
+	return snapshot, errors.Wrapf(err, "failed to find snapshot for height %v", height)
+}
+
 // List lists snapshots, in reverse order (newest first).
 func (s *Store) List() ([]*types.Snapshot, error) {
 	iter, err := s.db.ReverseIterator(encodeKey(0, 0), encodeKey(uint64(math.MaxUint64), math.MaxUint32))
@@ -188,7 +235,8 @@ func (s *Store) loadChunkFile(height uint64, format, chunk uint32) (io.ReadClose
 	return os.Open(path)
 }
 
-// Prune removes old snapshots. The given number of most recent heights (regardless of format) are retained.
+// Prune removes old snapshots. The given number of most recent heights (regardless of format) are
+// retained, as well as the snapshots the incremental snapshots among them are based on.
 func (s *Store) Prune(retain uint32) (uint64, error) {
 	iter, err := s.db.ReverseIterator(encodeKey(0, 0), encodeKey(uint64(math.MaxUint64), math.MaxUint32))
 	if err != nil {
@@ -199,13 +247,25 @@ func (s *Store) Prune(retain uint32) (uint64, error) {
 	pruned := uint64(0)
 	prunedHeights := make(map[uint64]bool)
 	skip := make(map[uint64]bool)
+	bases := make(map[uint64]bool)
 	for ; iter.Valid(); iter.Next() {
 		height, format, err := decodeKey(iter.Key())
 		if err != nil {
 			return 0, errors.Wrap(err, "failed to prune snapshots")
 		}
-		if skip[height] || uint32(len(skip)) < retain {
+		if !skip[height] && uint32(len(skip)) < retain {
 			skip[height] = true
+		}
+		if skip[height] || bases[height] {
+			// Snapshots are iterated from the newest, so the base of a retained incremental
+			// snapshot is always seen after it.
+			if format == types.FormatDelta {
+				snapshot := &types.Snapshot{}
+				if err := proto.Unmarshal(iter.Value(), snapshot); err != nil {
+					return 0, errors.Wrap(err, "failed to decode snapshot metadata")
+				}
+				bases[snapshot.Metadata.BaseHeight] = true
+			}
 			continue
 		}
 		err = s.Delete(height, format)
@@ -231,6 +291,25 @@ func (s *Store) Prune(retain uint32) (uint64, error) {
 // Save saves a snapshot to disk, returning it.
 func (s *Store) Save(
 	height uint64, format uint32, chunks <-chan io.ReadCloser,
+) (*types.Snapshot, error) {
+	return s.save(height, format, 0, chunks)
+}
+
+// SaveDelta saves an incremental snapshot of the changes since the snapshot at the base height
+// to disk, returning it.
+func (s *Store) SaveDelta(
+	height, base uint64, chunks <-chan io.ReadCloser,
+) (*types.Snapshot, error) {
+	if base >= height {
+		DrainChunks(chunks)
+		return nil, errors.Wrapf(storetypes.ErrLogic,
+			"incremental snapshot height %v must be greater than its base height %v", height, base)
+	}
+	return s.save(height, types.FormatDelta, base, chunks)
+}
+
+func (s *Store) save(
+	height uint64, format uint32, base uint64, chunks <-chan io.ReadCloser,
 ) (*types.Snapshot, error) {
 	defer DrainChunks(chunks)
 	if height == 0 {
@@ -263,6 +342,9 @@ func (s *Store) Save(
 	snapshot := &types.Snapshot{
 		Height: height,
 		Format: format,
+		Metadata: types.Metadata{
+			BaseHeight: base,
+		},
 	}
 
 	dirCreated := false
@@ -16,4 +16,8 @@ var (
 
 	// ErrInvalidSnapshotVersion is returned when the snapshot version is invalid
 	ErrInvalidSnapshotVersion = errors.New("invalid snapshot version")
+
+	// ErrDeltaHashMismatch is returned when the state restored from an incremental snapshot
+	// does not match the commit hash recorded in the snapshot.
+	ErrDeltaHashMismatch = errors.New("incremental snapshot hash verification failed")
 )
@@ -4,3 +4,8 @@ package types
 // must be identical across all nodes for a given height, so this must be bumped when the binary
 // snapshot output changes.
 const CurrentFormat uint32 = 3
+
+// FormatDelta is the format of incremental snapshots, which record the changes of each version
+// since the snapshot at Metadata.BaseHeight instead of the full state. They can only be restored
+// on top of the state of their base snapshot. Format 4 is the segmented format of store/v2.
+const FormatDelta uint32 = 5
@@ -8,6 +8,10 @@ type SnapshotOptions struct {
 
 	// KeepRecent defines how many snapshots to keep in heights.
 	KeepRecent uint32
+
+	// MaxDeltas defines how many incremental snapshots are taken after each full snapshot.
+	// Incremental snapshots are disabled if 0.
+	MaxDeltas uint32
 }
 
 func NewSnapshotOptions(interval uint64, keepRecent uint32) SnapshotOptions {
@@ -16,3 +20,10 @@ func NewSnapshotOptions(interval uint64, keepRecent uint32) SnapshotOptions {
 		KeepRecent: keepRecent,
 	}
 }
+
+// WithMaxDeltas returns the options with the given number of incremental
+// snapshots taken after each full snapshot.
+func (o SnapshotOptions) WithMaxDeltas(maxDeltas uint32) SnapshotOptions {
+	o.MaxDeltas = maxDeltas
+	return o
+}
@@ -26,6 +26,23 @@ type Snapshotter interface {
 	Restore(height uint64, format uint32, protoReader protoio.Reader) (SnapshotItem, error)
 }
 
+// DeltaSnapshotter is a Snapshotter which can also create and restore incremental snapshots, in
+// FormatDelta, of the changes between the state at a base height and the state at a later height.
+type DeltaSnapshotter interface {
+	Snapshotter
+
+	// CanSnapshotDelta returns whether the changes of all the heights since the base height up to
+	// the given height are still available.
+	CanSnapshotDelta(base, height uint64) bool
+
+	// SnapshotDelta writes the changes of the heights in (base, height] into the protobuf writer.
+	SnapshotDelta(base, height uint64, protoWriter protoio.Writer) error
+
+	// RestoreDelta applies the changes of an incremental snapshot to the state at the base height,
+	// taking the reader of protobuf message stream as input, and returns the next snapshot item.
+	RestoreDelta(base, height uint64, protoReader protoio.Reader) (SnapshotItem, error)
+}
+
 // ExtensionPayloadReader read extension payloads,
 // it returns io.EOF when reached either end of stream or the extension boundaries.
 type ExtensionPayloadReader = func() ([]byte, error)
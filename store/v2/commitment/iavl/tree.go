@@ -14,8 +14,9 @@ import (
 )
 
 var (
-	_ commitment.Tree      = (*IavlTree)(nil)
-	_ store.PausablePruner = (*IavlTree)(nil)
+	_ commitment.Tree          = (*IavlTree)(nil)
+	_ commitment.ChangesetTree = (*IavlTree)(nil)
+	_ store.PausablePruner     = (*IavlTree)(nil)
 )
 
 // IavlTree is a wrapper around iavl.MutableTree.
@@ -139,6 +140,26 @@ func (t *IavlTree) Import(version uint64) (commitment.Importer, error) {
 	}, nil
 }
 
+// VersionExists returns true if the given version exists in the tree.
+func (t *IavlTree) VersionExists(version uint64) bool {
+	return t.tree.VersionExists(int64(version))
+}
+
+// TraverseChangesets calls fn with the changes of each version in [start, end].
+func (t *IavlTree) TraverseChangesets(start, end uint64, fn func(version uint64, changes corestore.KVPairs) error) error {
+	return t.tree.TraverseStateChanges(int64(start), int64(end), func(version int64, changeSet *iavl.ChangeSet) error {
+		changes := make(corestore.KVPairs, len(changeSet.Pairs))
+		for i, pair := range changeSet.Pairs {
+			changes[i] = corestore.KVPair{
+				Key:    pair.Key,
+				Value:  pair.Value,
+				Remove: pair.Delete,
+			}
+		}
+		return fn(uint64(version), changes)
+	})
+}
+
 // Close closes the iavl tree.
 func (t *IavlTree) Close() error {
 	return t.tree.Close()
@@ -0,0 +1,52 @@
+package smt
+
+import (
+	"encoding/binary"
+
+	"cosmossdk.io/store/v2/commitment"
+	snapshotstypes "cosmossdk.io/store/v2/snapshots/types"
+)
+
+// Exporter exports the leaves of a version of the tree, in the order of their
+// positions in the tree. The inner nodes only depend on the leaves, so they are
+// not exported.
+type Exporter struct {
+	tree  *Tree
+	stack []*node
+}
+
+// Next returns the next leaf of the tree as an item of height 0.
+func (e *Exporter) Next() (*snapshotstypes.SnapshotIAVLItem, error) {
+	for len(e.stack) > 0 {
+		n := e.stack[len(e.stack)-1]
+		e.stack = e.stack[:len(e.stack)-1]
+
+		if !n.inner {
+			return &snapshotstypes.SnapshotIAVLItem{
+				Key:     n.key,
+				Value:   n.value,
+				Version: int64(binary.BigEndian.Uint64(n.nodeKey)),
+				Height:  0,
+			}, nil
+		}
+
+		for i := 1; i >= 0; i-- {
+			child, err := e.tree.child(n, i)
+			if err != nil {
+				return nil, err
+			}
+			if child != nil {
+				e.stack = append(e.stack, child)
+			}
+		}
+	}
+
+	return nil, commitment.ErrorExportDone
+}
+
+// Close closes the exporter.
+func (e *Exporter) Close() error {
+	e.stack = nil
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package smt
+
+import (
+	"fmt"
+
+	snapshotstypes "cosmossdk.io/store/v2/snapshots/types"
+)
+
+// Importer imports the leaves exported by an Exporter into an empty tree.
+type Importer struct {
+	tree    *Tree
+	version uint64
+}
+
+// Add adds the given leaf to the importer.
+func (i *Importer) Add(item *snapshotstypes.SnapshotIAVLItem) error {
+	if item.Height != 0 {
+		return fmt.Errorf("unexpected node of height %d, only leaves are imported", item.Height)
+	}
+
+	return i.tree.Set(item.Key, item.Value)
+}
+
+// Commit commits the imported leaves as the version of the importer.
+func (i *Importer) Commit() error {
+	if err := i.tree.SetInitialVersion(i.version); err != nil {
+		return err
+	}
+
+	_, version, err := i.tree.Commit()
+	if err != nil {
+		return err
+	}
+	if version != i.version {
+		return fmt.Errorf("imported version %d, expected %d", version, i.version)
+	}
+
+	return nil
+}
+
+// Close closes the importer.
+func (i *Importer) Close() error {
+	return nil
+}
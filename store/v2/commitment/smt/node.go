@@ -0,0 +1,191 @@
+package smt
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+const (
+	hashSize    = sha256.Size
+	nodeKeySize = 8 + 4 // version + nonce
+
+	leafPrefix  byte = 0
+	innerPrefix byte = 1
+)
+
+// emptyHash is the hash of an empty subtree, i.e. the EmptyChild of
+// ics23.SmtSpec.
+var emptyHash = make([]byte, hashSize)
+
+// node is a node of the tree. Leaves hold a key/value pair, inner nodes hold
+// two children, either of which may be an empty subtree. A subtree with a
+// single leaf is always the leaf itself, so inner nodes have at least two
+// leaves below them.
+//
+// Saved nodes are immutable and identified by their node key, the version at
+// which they were saved followed by a nonce. Nodes which are not saved yet
+// belong to the working tree only and are updated in place.
+type node struct {
+	nodeKey []byte
+	hash    []byte
+
+	// leaf
+	key, value []byte
+	path       []byte // sha256(key), the position of the leaf in the tree
+
+	// inner: for each child, the loaded node, its node key and hash if it is
+	// not loaded yet, or none of them if the child is empty
+	inner       bool
+	children    [2]*node
+	childKeys   [2][]byte
+	childHashes [2][]byte
+}
+
+func newLeaf(key, value []byte) *node {
+	path := sha256.Sum256(key)
+	return &node{key: key, value: value, path: path[:]}
+}
+
+// newInner returns an inner node with the given children, nil meaning empty.
+func newInner(left, right *node) *node {
+	return &node{inner: true, children: [2]*node{left, right}}
+}
+
+func (n *node) isSaved() bool {
+	return n.nodeKey != nil
+}
+
+// isEmpty returns true if the child i of the inner node is an empty subtree.
+func (n *node) isEmpty(i int) bool {
+	return n.children[i] == nil && n.childKeys[i] == nil
+}
+
+// setChild replaces the child i of an unsaved inner node, nil meaning empty.
+func (n *node) setChild(i int, child *node) {
+	n.children[i] = child
+	n.childKeys[i] = nil
+	n.childHashes[i] = nil
+	n.hash = nil
+}
+
+// childHash returns the hash of the child i of the inner node, computing it if
+// the child was updated.
+func (n *node) childHash(i int) []byte {
+	switch {
+	case n.children[i] != nil:
+		return n.children[i].computeHash()
+	case n.childHashes[i] != nil:
+		return n.childHashes[i]
+	default:
+		return emptyHash
+	}
+}
+
+// computeHash returns the hash of the node, computing the hashes of the updated
+// nodes of its subtree if needed. The hashes follow ics23.SmtSpec:
+//
+//	leaf:  sha256(0x00 || sha256(key) || sha256(value))
+//	inner: sha256(0x01 || left || right)
+func (n *node) computeHash() []byte {
+	if n.hash != nil {
+		return n.hash
+	}
+
+	h := sha256.New()
+	if n.inner {
+		h.Write([]byte{innerPrefix})
+		h.Write(n.childHash(0))
+		h.Write(n.childHash(1))
+	} else {
+		valueHash := sha256.Sum256(n.value)
+		h.Write([]byte{leafPrefix})
+		h.Write(n.path)
+		h.Write(valueHash[:])
+	}
+	n.hash = h.Sum(nil)
+
+	return n.hash
+}
+
+// encode encodes a saved node, whose hash and children hashes are computed:
+//
+//	leaf:  0x00 || hash || uvarint(len(key)) || key || value
+//	inner: 0x01 || hash || (0x00 | 0x01 || nodeKey || hash) for each child
+func (n *node) encode() []byte {
+	if !n.inner {
+		buf := make([]byte, 0, 1+hashSize+binary.MaxVarintLen64+len(n.key)+len(n.value))
+		buf = append(buf, leafPrefix)
+		buf = append(buf, n.hash...)
+		buf = binary.AppendUvarint(buf, uint64(len(n.key)))
+		buf = append(buf, n.key...)
+		return append(buf, n.value...)
+	}
+
+	buf := make([]byte, 0, 1+hashSize+2*(1+nodeKeySize+hashSize))
+	buf = append(buf, innerPrefix)
+	buf = append(buf, n.hash...)
+	for i := 0; i < 2; i++ {
+		if n.isEmpty(i) {
+			buf = append(buf, 0)
+			continue
+		}
+		childKey, childHash := n.childKeys[i], n.childHashes[i]
+		if n.children[i] != nil {
+			childKey, childHash = n.children[i].nodeKey, n.children[i].hash
+		}
+		buf = append(buf, 1)
+		buf = append(buf, childKey...)
+		buf = append(buf, childHash...)
+	}
+
+	return buf
+}
+
+func decodeNode(nodeKey, bz []byte) (*node, error) {
+	if len(bz) < 1+hashSize {
+		return nil, fmt.Errorf("invalid node %X: too short", nodeKey)
+	}
+	n := &node{nodeKey: nodeKey, hash: bz[1 : 1+hashSize]}
+	prefix, bz := bz[0], bz[1+hashSize:]
+
+	switch prefix {
+	case leafPrefix:
+		keyLen, read := binary.Uvarint(bz)
+		if read <= 0 || uint64(len(bz)-read) < keyLen {
+			return nil, fmt.Errorf("invalid leaf %X: bad key length", nodeKey)
+		}
+		n.key = bz[read : read+int(keyLen)]
+		n.value = bz[read+int(keyLen):]
+		path := sha256.Sum256(n.key)
+		n.path = path[:]
+
+	case innerPrefix:
+		n.inner = true
+		for i := 0; i < 2; i++ {
+			if len(bz) < 1 {
+				return nil, fmt.Errorf("invalid inner node %X: too short", nodeKey)
+			}
+			if bz[0] == 0 {
+				bz = bz[1:]
+				continue
+			}
+			if len(bz) < 1+nodeKeySize+hashSize {
+				return nil, fmt.Errorf("invalid inner node %X: too short", nodeKey)
+			}
+			n.childKeys[i] = bz[1 : 1+nodeKeySize]
+			n.childHashes[i] = bz[1+nodeKeySize : 1+nodeKeySize+hashSize]
+			bz = bz[1+nodeKeySize+hashSize:]
+		}
+
+	default:
+		return nil, fmt.Errorf("invalid node %X: unknown prefix %d", nodeKey, prefix)
+	}
+
+	return n, nil
+}
+
+// bit returns the bit of the path at the given depth, most significant first.
+func bit(path []byte, depth int) int {
+	return int(path[depth/8]>>(7-depth%8)) & 1
+}
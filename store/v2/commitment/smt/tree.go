@@ -0,0 +1,664 @@
+package smt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	ics23 "github.com/cosmos/ics23/go"
+
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/store/v2/commitment"
+	"cosmossdk.io/store/v2/proof"
+)
+
+var (
+	_ commitment.Tree       = (*Tree)(nil)
+	_ commitment.ProofTyper = (*Tree)(nil)
+)
+
+const (
+	rootPrefix   = 'r' // r<version> -> 0x00 if the tree is empty, 0x01<nodeKey> otherwise
+	nodePrefix   = 'n' // n<nodeKey> -> node
+	orphanPrefix = 'o' // o<version><nodeKey> -> nil, the nodes removed from the tree at version
+)
+
+// Tree is a versioned, compact sparse Merkle tree implementing
+// commitment.Tree. Keys are positioned in the tree by their SHA-256 hash, and a
+// subtree holding a single key is replaced by the leaf of the key, so the depth
+// of the tree is logarithmic in the number of keys. The root hash of the tree
+// only depends on its key/value pairs, and its proofs follow ics23.SmtSpec.
+//
+// Each version is a root in a persistent tree: the nodes of a version are
+// shared with the previous versions, and the nodes removed from the tree at a
+// version are recorded as orphans to be deleted when the versions using them
+// are pruned.
+type Tree struct {
+	db corestore.KVStoreWithBatch
+
+	version        uint64
+	initialVersion uint64
+	hash           []byte
+
+	// root is the root of the working tree, nil if the tree is empty
+	root *node
+	// orphans are the node keys of the saved nodes removed from the working tree
+	orphans [][]byte
+}
+
+// NewTree creates a new Tree instance storing its nodes in db. The latest
+// version is loaded by LoadVersion.
+func NewTree(db corestore.KVStoreWithBatch) *Tree {
+	return &Tree{db: db, hash: emptyHash}
+}
+
+// Set sets the given key-value pair in the tree.
+func (t *Tree) Set(key, value []byte) error {
+	if value == nil {
+		return errors.New("value cannot be nil")
+	}
+
+	leaf := newLeaf(key, value)
+	root, err := t.insert(t.root, 0, leaf)
+	if err != nil {
+		return err
+	}
+	t.root = root
+
+	return nil
+}
+
+func (t *Tree) insert(n *node, depth int, leaf *node) (*node, error) {
+	if n == nil {
+		return leaf, nil
+	}
+
+	if !n.inner {
+		if !bytes.Equal(n.key, leaf.key) {
+			return t.split(n, leaf, depth)
+		}
+		if bytes.Equal(n.value, leaf.value) {
+			return n, nil
+		}
+		t.orphan(n)
+		return leaf, nil
+	}
+
+	i := bit(leaf.path, depth)
+	child, err := t.child(n, i)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := t.insert(child, depth+1, leaf)
+	if err != nil || updated == child {
+		return n, err
+	}
+	child = updated
+
+	n = t.mutable(n)
+	n.setChild(i, child)
+	return n, nil
+}
+
+// split returns the subtree holding the two leaves, which are at the same
+// position at the given depth.
+func (t *Tree) split(a, b *node, depth int) (*node, error) {
+	if depth >= 8*hashSize {
+		return nil, fmt.Errorf("keys %X and %X have the same hash", a.key, b.key)
+	}
+
+	i, j := bit(a.path, depth), bit(b.path, depth)
+	if i != j {
+		n := newInner(nil, nil)
+		n.setChild(i, a)
+		n.setChild(j, b)
+		return n, nil
+	}
+
+	child, err := t.split(a, b, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	n := newInner(nil, nil)
+	n.setChild(i, child)
+	return n, nil
+}
+
+// Remove removes the given key from the tree.
+func (t *Tree) Remove(key []byte) error {
+	path := newLeaf(key, nil).path
+	root, _, err := t.remove(t.root, 0, key, path)
+	if err != nil {
+		return err
+	}
+	t.root = root
+
+	return nil
+}
+
+func (t *Tree) remove(n *node, depth int, key, path []byte) (*node, bool, error) {
+	if n == nil {
+		return nil, false, nil
+	}
+
+	if !n.inner {
+		if !bytes.Equal(n.key, key) {
+			return n, false, nil
+		}
+		t.orphan(n)
+		return nil, true, nil
+	}
+
+	i := bit(path, depth)
+	child, err := t.child(n, i)
+	if err != nil {
+		return nil, false, err
+	}
+	child, removed, err := t.remove(child, depth+1, key, path)
+	if err != nil || !removed {
+		return n, false, err
+	}
+
+	// a subtree holding a single leaf is replaced by the leaf
+	sibling, err := t.child(n, 1-i)
+	if err != nil {
+		return nil, false, err
+	}
+	switch {
+	case child == nil && (sibling == nil || !sibling.inner):
+		t.orphan(n)
+		return sibling, true, nil
+	case sibling == nil && !child.inner:
+		t.orphan(n)
+		return child, true, nil
+	}
+
+	n = t.mutable(n)
+	n.setChild(i, child)
+	return n, true, nil
+}
+
+// child returns the child i of the inner node, loading it if needed, or nil if
+// the child is empty.
+func (t *Tree) child(n *node, i int) (*node, error) {
+	if n.children[i] == nil && n.childKeys[i] != nil {
+		child, err := t.getNode(n.childKeys[i])
+		if err != nil {
+			return nil, err
+		}
+		n.children[i] = child
+	}
+
+	return n.children[i], nil
+}
+
+// mutable returns a node of the working tree which can be updated in place,
+// copying the node if it is saved.
+func (t *Tree) mutable(n *node) *node {
+	if !n.isSaved() {
+		return n
+	}
+
+	t.orphan(n)
+	return &node{
+		inner:       true,
+		children:    n.children,
+		childKeys:   n.childKeys,
+		childHashes: n.childHashes,
+	}
+}
+
+func (t *Tree) orphan(n *node) {
+	if n.isSaved() {
+		t.orphans = append(t.orphans, n.nodeKey)
+	}
+}
+
+// GetLatestVersion returns the latest version of the tree.
+func (t *Tree) GetLatestVersion() uint64 {
+	return t.version
+}
+
+// Hash returns the hash of the latest saved version of the tree.
+func (t *Tree) Hash() []byte {
+	return t.hash
+}
+
+// WorkingHash returns the working hash of the tree.
+func (t *Tree) WorkingHash() []byte {
+	if t.root == nil {
+		return emptyHash
+	}
+	return t.root.computeHash()
+}
+
+// LoadVersion loads the state at the given version, or at the latest version
+// if 0, and deletes all the later versions.
+func (t *Tree) LoadVersion(version uint64) error {
+	latestVersion, err := t.getLatestVersion()
+	if err != nil {
+		return err
+	}
+	if version == 0 {
+		version = latestVersion
+	}
+	if version == 0 {
+		t.version, t.root, t.hash, t.orphans = 0, nil, emptyHash, nil
+		return nil
+	}
+
+	root, err := t.getRoot(version)
+	if err != nil {
+		return err
+	}
+
+	if version < latestVersion {
+		if err := t.deleteVersionsFrom(version + 1); err != nil {
+			return err
+		}
+	}
+
+	t.version, t.root, t.orphans = version, root, nil
+	t.hash = t.WorkingHash()
+
+	return nil
+}
+
+// deleteVersionsFrom deletes the roots and nodes saved from the given version,
+// and restores the nodes orphaned from the version.
+func (t *Tree) deleteVersionsFrom(version uint64) error {
+	var keys [][]byte
+	for _, prefix := range []byte{rootPrefix, nodePrefix, orphanPrefix} {
+		prefixKeys, err := t.collectKeys(versionKey(prefix, version), []byte{prefix + 1})
+		if err != nil {
+			return err
+		}
+		keys = append(keys, prefixKeys...)
+	}
+
+	return t.deleteKeys(keys)
+}
+
+// Commit commits the current state to the tree.
+func (t *Tree) Commit() ([]byte, uint64, error) {
+	version := t.version + 1
+	if t.version == 0 && t.initialVersion > 1 {
+		version = t.initialVersion
+	}
+
+	batch := t.db.NewBatch()
+	defer batch.Close()
+
+	hash := t.WorkingHash()
+	rootValue := []byte{0}
+	if t.root != nil {
+		var nonce uint32
+		if err := saveNode(batch, t.root, version, &nonce); err != nil {
+			return nil, 0, err
+		}
+		rootValue = append([]byte{1}, t.root.nodeKey...)
+	}
+	if err := batch.Set(versionKey(rootPrefix, version), rootValue); err != nil {
+		return nil, 0, err
+	}
+	for _, nodeKey := range t.orphans {
+		if err := batch.Set(append(versionKey(orphanPrefix, version), nodeKey...), []byte{}); err != nil {
+			return nil, 0, err
+		}
+	}
+	if err := batch.Write(); err != nil {
+		return nil, 0, err
+	}
+
+	// release the loaded nodes, the working tree is reloaded on demand
+	if t.root != nil {
+		root, err := decodeNode(t.root.nodeKey, t.root.encode())
+		if err != nil {
+			return nil, 0, err
+		}
+		t.root = root
+	}
+	t.version, t.hash, t.orphans = version, hash, nil
+
+	return hash, version, nil
+}
+
+// saveNode writes the unsaved nodes of the subtree to the batch, children first
+// so that their node keys are known when writing their parents.
+func saveNode(batch corestore.Batch, n *node, version uint64, nonce *uint32) error {
+	if n.isSaved() {
+		return nil
+	}
+
+	if n.inner {
+		for _, child := range n.children {
+			if child != nil {
+				if err := saveNode(batch, child, version, nonce); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	*nonce++
+	n.nodeKey = make([]byte, nodeKeySize)
+	binary.BigEndian.PutUint64(n.nodeKey, version)
+	binary.BigEndian.PutUint32(n.nodeKey[8:], *nonce)
+	n.computeHash()
+
+	return batch.Set(append([]byte{nodePrefix}, n.nodeKey...), n.encode())
+}
+
+// SetInitialVersion sets the initial version of the database.
+func (t *Tree) SetInitialVersion(version uint64) error {
+	t.initialVersion = version
+	return nil
+}
+
+// GetProof returns a proof for the given key and version.
+func (t *Tree) GetProof(version uint64, key []byte) (*ics23.CommitmentProof, error) {
+	root, err := t.getRoot(version)
+	if err != nil {
+		return nil, err
+	}
+	if root == nil {
+		return nil, errors.New("cannot generate the proof of an empty tree")
+	}
+
+	path := newLeaf(key, nil).path
+	left, leaf, right, err := t.neighbors(root, path)
+	if err != nil {
+		return nil, err
+	}
+
+	if leaf != nil {
+		exist, err := t.existenceProof(root, leaf)
+		if err != nil {
+			return nil, err
+		}
+		return &ics23.CommitmentProof{Proof: &ics23.CommitmentProof_Exist{Exist: exist}}, nil
+	}
+
+	nonexist := &ics23.NonExistenceProof{Key: key}
+	if left != nil {
+		if nonexist.Left, err = t.existenceProof(root, left); err != nil {
+			return nil, err
+		}
+	}
+	if right != nil {
+		if nonexist.Right, err = t.existenceProof(root, right); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ics23.CommitmentProof{Proof: &ics23.CommitmentProof_Nonexist{Nonexist: nonexist}}, nil
+}
+
+// neighbors returns the leaf at the given path if any, or the leaves before
+// and after the path otherwise.
+func (t *Tree) neighbors(root *node, path []byte) (left, leaf, right *node, err error) {
+	// the closest non-empty subtrees before and after the path
+	var before, after *node
+
+	n := root
+	for depth := 0; n != nil && n.inner; depth++ {
+		i := bit(path, depth)
+		sibling, err := t.child(n, 1-i)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		if sibling != nil {
+			if i == 0 {
+				after = sibling
+			} else {
+				before = sibling
+			}
+		}
+		if n, err = t.child(n, i); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	if n != nil {
+		switch bytes.Compare(n.path, path) {
+		case 0:
+			return nil, n, nil, nil
+		case -1:
+			left = n
+		default:
+			right = n
+		}
+	}
+
+	if left == nil && before != nil {
+		if left, err = t.edgeLeaf(before, 1); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+	if right == nil && after != nil {
+		if right, err = t.edgeLeaf(after, 0); err != nil {
+			return nil, nil, nil, err
+		}
+	}
+
+	return left, nil, right, nil
+}
+
+// edgeLeaf returns the leftmost leaf of the subtree if side is 0, or its
+// rightmost leaf if side is 1.
+func (t *Tree) edgeLeaf(n *node, side int) (*node, error) {
+	for n.inner {
+		child, err := t.child(n, side)
+		if err != nil {
+			return nil, err
+		}
+		if child == nil {
+			if child, err = t.child(n, 1-side); err != nil {
+				return nil, err
+			}
+		}
+		n = child
+	}
+
+	return n, nil
+}
+
+// existenceProof returns the ics23 existence proof of the leaf.
+func (t *Tree) existenceProof(root, leaf *node) (*ics23.ExistenceProof, error) {
+	var path []*ics23.InnerOp
+
+	n := root
+	for depth := 0; n.inner; depth++ {
+		i := bit(leaf.path, depth)
+		op := &ics23.InnerOp{Hash: ics23.HashOp_SHA256, Prefix: []byte{innerPrefix}}
+		if i == 0 {
+			op.Suffix = n.childHash(1)
+		} else {
+			op.Prefix = append(op.Prefix, n.childHash(0)...)
+		}
+		path = append(path, op)
+
+		var err error
+		if n, err = t.child(n, i); err != nil {
+			return nil, err
+		}
+	}
+	if !bytes.Equal(n.key, leaf.key) {
+		return nil, fmt.Errorf("key %X not found", leaf.key)
+	}
+
+	// the path of the proof goes from the leaf to the root
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+
+	return &ics23.ExistenceProof{
+		Key:   leaf.key,
+		Value: leaf.value,
+		Leaf:  ics23.SmtSpec.LeafSpec,
+		Path:  path,
+	}, nil
+}
+
+// ProofType implements commitment.ProofTyper.
+func (t *Tree) ProofType() string {
+	return proof.ProofOpSMTCommitment
+}
+
+// Get returns the value of the given key at the given version, or nil if the
+// key does not exist.
+func (t *Tree) Get(version uint64, key []byte) ([]byte, error) {
+	root, err := t.getRoot(version)
+	if err != nil {
+		return nil, err
+	}
+
+	path := newLeaf(key, nil).path
+	n := root
+	for depth := 0; n != nil && n.inner; depth++ {
+		if n, err = t.child(n, bit(path, depth)); err != nil {
+			return nil, err
+		}
+	}
+	if n == nil || !bytes.Equal(n.key, key) {
+		return nil, nil
+	}
+
+	return n.value, nil
+}
+
+// Prune prunes all versions up to and including the provided version, deleting
+// the nodes which are not used by the remaining versions.
+func (t *Tree) Prune(version uint64) error {
+	if version >= t.version {
+		return fmt.Errorf("cannot prune version %d, the latest version is %d", version, t.version)
+	}
+
+	// the nodes orphaned at a version are used by the previous versions only
+	orphanKeys, err := t.collectKeys([]byte{orphanPrefix}, versionKey(orphanPrefix, version+2))
+	if err != nil {
+		return err
+	}
+	rootKeys, err := t.collectKeys([]byte{rootPrefix}, versionKey(rootPrefix, version+1))
+	if err != nil {
+		return err
+	}
+
+	keys := append(orphanKeys, rootKeys...)
+	for _, orphanKey := range orphanKeys {
+		keys = append(keys, append([]byte{nodePrefix}, orphanKey[1+8:]...))
+	}
+
+	return t.deleteKeys(keys)
+}
+
+// Export exports the tree exporter at the given version.
+func (t *Tree) Export(version uint64) (commitment.Exporter, error) {
+	root, err := t.getRoot(version)
+	if err != nil {
+		return nil, err
+	}
+
+	e := &Exporter{tree: t}
+	if root != nil {
+		e.stack = []*node{root}
+	}
+
+	return e, nil
+}
+
+// Import imports the tree importer at the given version. The tree must be
+// empty.
+func (t *Tree) Import(version uint64) (commitment.Importer, error) {
+	if t.version != 0 || t.root != nil {
+		return nil, errors.New("cannot import into a non-empty tree")
+	}
+
+	return &Importer{tree: t, version: version}, nil
+}
+
+// Close closes the tree, the database is closed by its owner.
+func (t *Tree) Close() error {
+	return nil
+}
+
+func (t *Tree) getLatestVersion() (uint64, error) {
+	itr, err := t.db.ReverseIterator([]byte{rootPrefix}, []byte{rootPrefix + 1})
+	if err != nil {
+		return 0, err
+	}
+	defer itr.Close()
+
+	if !itr.Valid() {
+		return 0, itr.Error()
+	}
+
+	return binary.BigEndian.Uint64(itr.Key()[1:]), nil
+}
+
+// getRoot returns the root of the given version, or nil if the tree is empty
+// at this version.
+func (t *Tree) getRoot(version uint64) (*node, error) {
+	bz, err := t.db.Get(versionKey(rootPrefix, version))
+	if err != nil {
+		return nil, err
+	}
+	if len(bz) == 0 {
+		return nil, fmt.Errorf("version %d does not exist", version)
+	}
+	if bz[0] == 0 {
+		return nil, nil
+	}
+
+	return t.getNode(bz[1:])
+}
+
+func (t *Tree) getNode(nodeKey []byte) (*node, error) {
+	bz, err := t.db.Get(append([]byte{nodePrefix}, nodeKey...))
+	if err != nil {
+		return nil, err
+	}
+	if bz == nil {
+		return nil, fmt.Errorf("node %X not found", nodeKey)
+	}
+
+	return decodeNode(nodeKey, bz)
+}
+
+// collectKeys returns the keys in [start, end). The keys are deleted once the
+// iterator is closed, as a domain must not be written while it is iterated.
+func (t *Tree) collectKeys(start, end []byte) ([][]byte, error) {
+	itr, err := t.db.Iterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer itr.Close()
+
+	var keys [][]byte
+	for ; itr.Valid(); itr.Next() {
+		keys = append(keys, bytes.Clone(itr.Key()))
+	}
+
+	return keys, itr.Error()
+}
+
+func (t *Tree) deleteKeys(keys [][]byte) error {
+	batch := t.db.NewBatch()
+	defer batch.Close()
+
+	for _, key := range keys {
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return batch.Write()
+}
+
+func versionKey(prefix byte, version uint64) []byte {
+	key := make([]byte, 1+8)
+	key[0] = prefix
+	binary.BigEndian.PutUint64(key[1:], version)
+	return key
+}
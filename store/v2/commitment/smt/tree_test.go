@@ -0,0 +1,229 @@
+package smt
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	ics23 "github.com/cosmos/ics23/go"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+
+	"cosmossdk.io/core/log"
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/store/v2/commitment"
+	dbm "cosmossdk.io/store/v2/db"
+	"cosmossdk.io/store/v2/proof"
+)
+
+func TestCommitterSuite(t *testing.T) {
+	s := &commitment.CommitStoreTestSuite{
+		NewStore: func(db corestore.KVStoreWithBatch, storeKeys []string, logger log.Logger) (*commitment.CommitStore, error) {
+			multiTrees := make(map[string]commitment.Tree)
+			for _, storeKey := range storeKeys {
+				prefixDB := dbm.NewPrefixDB(db, []byte(storeKey))
+				multiTrees[storeKey] = NewTree(prefixDB)
+			}
+			return commitment.NewCommitStore(multiTrees, db, logger)
+		},
+	}
+
+	suite.Run(t, s)
+}
+
+func TestTree(t *testing.T) {
+	tree := NewTree(dbm.NewMemDB())
+	require.NoError(t, tree.LoadVersion(0))
+	require.Equal(t, uint64(0), tree.GetLatestVersion())
+	require.Equal(t, emptyHash, tree.WorkingHash())
+
+	// write a batch of version 1
+	require.NoError(t, tree.Set([]byte("key1"), []byte("value1")))
+	require.NoError(t, tree.Set([]byte("key2"), []byte("value2")))
+	require.NoError(t, tree.Set([]byte("key3"), []byte("value3")))
+
+	workingHash := tree.WorkingHash()
+	commitHash, version, err := tree.Commit()
+	require.NoError(t, err)
+	require.Equal(t, uint64(1), version)
+	require.Equal(t, workingHash, commitHash)
+	require.Equal(t, commitHash, tree.Hash())
+
+	bz, err := tree.Get(1, []byte("key1"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value1"), bz)
+	_, err = tree.Get(2, []byte("key1"))
+	require.Error(t, err)
+
+	// write a batch of version 2
+	require.NoError(t, tree.Set([]byte("key4"), []byte("value4")))
+	require.NoError(t, tree.Set([]byte("key2"), []byte("value2'")))
+	require.NoError(t, tree.Remove([]byte("key1")))
+	require.NoError(t, tree.Remove([]byte("unknown")))
+	version2Hash, version, err := tree.Commit()
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), version)
+
+	// both versions are readable
+	bz, err = tree.Get(1, []byte("key2"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2"), bz)
+	bz, err = tree.Get(2, []byte("key2"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value2'"), bz)
+	bz, err = tree.Get(2, []byte("key1"))
+	require.NoError(t, err)
+	require.Nil(t, bz)
+
+	// the proofs of both versions verify against their root hashes
+	p, err := tree.GetProof(1, []byte("key1"))
+	require.NoError(t, err)
+	require.True(t, ics23.VerifyMembership(ics23.SmtSpec, commitHash, p, []byte("key1"), []byte("value1")))
+	p, err = tree.GetProof(2, []byte("key1"))
+	require.NoError(t, err)
+	require.True(t, ics23.VerifyNonMembership(ics23.SmtSpec, version2Hash, p, []byte("key1")))
+
+	// reloading the tree at version 2 deletes version 3
+	require.NoError(t, tree.Set([]byte("key5"), []byte("value5")))
+	_, _, err = tree.Commit()
+	require.NoError(t, err)
+	require.NoError(t, tree.Prune(1))
+	_, err = tree.Get(1, []byte("key2"))
+	require.Error(t, err)
+	require.NoError(t, tree.LoadVersion(2))
+	require.Equal(t, uint64(2), tree.GetLatestVersion())
+	require.Equal(t, version2Hash, tree.Hash())
+	_, err = tree.Get(3, []byte("key5"))
+	require.Error(t, err)
+
+	// the reloaded tree is writable
+	require.NoError(t, tree.Set([]byte("key6"), []byte("value6")))
+	_, version, err = tree.Commit()
+	require.NoError(t, err)
+	require.Equal(t, uint64(3), version)
+	bz, err = tree.Get(3, []byte("key6"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value6"), bz)
+
+	require.NoError(t, tree.Close())
+}
+
+func TestTreeHistoryIndependence(t *testing.T) {
+	keys := make([][]byte, 200)
+	for i := range keys {
+		keys[i] = []byte(fmt.Sprintf("key%03d", i))
+	}
+
+	// the root hash only depends on the key/value pairs, not on the order in
+	// which they were written or on the versions
+	expected := NewTree(dbm.NewMemDB())
+	for _, key := range keys[:100] {
+		require.NoError(t, expected.Set(key, key))
+	}
+
+	tree := NewTree(dbm.NewMemDB())
+	r := rand.New(rand.NewSource(0))
+	for _, i := range r.Perm(len(keys)) {
+		require.NoError(t, tree.Set(keys[i], []byte("value")))
+		if i%7 == 0 {
+			_, _, err := tree.Commit()
+			require.NoError(t, err)
+		}
+	}
+	for _, i := range r.Perm(len(keys)) {
+		if i < 100 {
+			require.NoError(t, tree.Set(keys[i], keys[i]))
+		} else {
+			require.NoError(t, tree.Remove(keys[i]))
+		}
+	}
+	require.Equal(t, expected.WorkingHash(), tree.WorkingHash())
+
+	hash, version, err := tree.Commit()
+	require.NoError(t, err)
+
+	// every key has a valid existence or nonexistence proof
+	for i, key := range keys {
+		p, err := tree.GetProof(version, key)
+		require.NoError(t, err)
+		if i < 100 {
+			require.True(t, ics23.VerifyMembership(ics23.SmtSpec, hash, p, key, key), "key %s", key)
+		} else {
+			require.True(t, ics23.VerifyNonMembership(ics23.SmtSpec, hash, p, key), "key %s", key)
+		}
+	}
+
+	// removing all the keys empties the tree
+	for _, key := range keys {
+		require.NoError(t, tree.Remove(key))
+	}
+	require.Equal(t, emptyHash, tree.WorkingHash())
+	_, version, err = tree.Commit()
+	require.NoError(t, err)
+	_, err = tree.GetProof(version, keys[0])
+	require.Error(t, err)
+}
+
+func TestTreePruning(t *testing.T) {
+	db := dbm.NewMemDB()
+	tree := NewTree(db)
+
+	for v := 1; v <= 10; v++ {
+		for i := 0; i < 20; i++ {
+			require.NoError(t, tree.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d-%d", i, v))))
+		}
+		_, _, err := tree.Commit()
+		require.NoError(t, err)
+	}
+	require.Error(t, tree.Prune(10))
+	require.NoError(t, tree.Prune(9))
+
+	// only the nodes of the latest version remain, as many as in a tree
+	// written at once
+	countNodes := func(db corestore.KVStoreWithBatch) int {
+		itr, err := db.Iterator([]byte{nodePrefix}, []byte{nodePrefix + 1})
+		require.NoError(t, err)
+		defer itr.Close()
+		nodes := 0
+		for ; itr.Valid(); itr.Next() {
+			n, err := decodeNode(itr.Key()[1:], itr.Value())
+			require.NoError(t, err)
+			if !n.inner {
+				require.Contains(t, string(n.value), "-10")
+			}
+			nodes++
+		}
+		return nodes
+	}
+	expectedDB := dbm.NewMemDB()
+	expected := NewTree(expectedDB)
+	for i := 0; i < 20; i++ {
+		require.NoError(t, expected.Set([]byte(fmt.Sprintf("key%d", i)), []byte(fmt.Sprintf("value%d-10", i))))
+	}
+	_, _, err := expected.Commit()
+	require.NoError(t, err)
+	require.Equal(t, expected.Hash(), tree.Hash())
+	require.Equal(t, countNodes(expectedDB), countNodes(db))
+
+	for i := 0; i < 20; i++ {
+		bz, err := tree.Get(10, []byte(fmt.Sprintf("key%d", i)))
+		require.NoError(t, err)
+		require.Equal(t, []byte(fmt.Sprintf("value%d-10", i)), bz)
+	}
+}
+
+func TestTreeCommitmentOp(t *testing.T) {
+	tree := NewTree(dbm.NewMemDB())
+	require.NoError(t, tree.Set([]byte("key"), []byte("value")))
+	require.NoError(t, tree.Set([]byte("other"), []byte("value")))
+	hash, version, err := tree.Commit()
+	require.NoError(t, err)
+
+	p, err := tree.GetProof(version, []byte("key"))
+	require.NoError(t, err)
+	op, err := proof.NewCommitmentOp(tree.ProofType(), []byte("key"), p)
+	require.NoError(t, err)
+	roots, err := op.Run([][]byte{[]byte("value")})
+	require.NoError(t, err)
+	require.Equal(t, hash, roots[0])
+}
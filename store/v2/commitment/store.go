@@ -1,27 +1,36 @@
 package commitment
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io"
 	"math"
+	"runtime"
+	"sort"
+	"time"
 
 	protoio "github.com/cosmos/gogoproto/io"
+	"golang.org/x/sync/errgroup"
 
 	"cosmossdk.io/core/log"
 	corestore "cosmossdk.io/core/store"
 	"cosmossdk.io/store/v2"
 	"cosmossdk.io/store/v2/internal"
 	"cosmossdk.io/store/v2/internal/conv"
+	"cosmossdk.io/store/v2/metrics"
 	"cosmossdk.io/store/v2/proof"
 	"cosmossdk.io/store/v2/snapshots"
 	snapshotstypes "cosmossdk.io/store/v2/snapshots/types"
 )
 
 var (
-	_ store.Committer             = (*CommitStore)(nil)
-	_ snapshots.CommitSnapshotter = (*CommitStore)(nil)
-	_ store.PausablePruner        = (*CommitStore)(nil)
+	_ store.Committer              = (*CommitStore)(nil)
+	_ snapshots.CommitSnapshotter  = (*CommitStore)(nil)
+	_ snapshots.SegmentSnapshotter = (*CommitStore)(nil)
+	_ snapshots.DeltaSnapshotter   = (*CommitStore)(nil)
+	_ store.PausablePruner         = (*CommitStore)(nil)
+	_ store.ChangesetReader        = (*CommitStore)(nil)
 )
 
 // CommitStore is a wrapper around multiple Tree objects mapped by a unique store
@@ -29,59 +38,153 @@ var (
 // can construct a CommitStore with one or more store keys. It is expected that a
 // RootStore use a CommitStore as an abstraction to handle multiple store keys
 // and trees.
+//
+// Trees are independent of each other, so they are written, hashed and committed
+// concurrently by a bounded pool of workers. The resulting commit info is always
+// assembled in the order of the store keys so that it does not depend on the
+// scheduling of the workers.
 type CommitStore struct {
 	logger     log.Logger
 	metadata   *MetadataStore
 	multiTrees map[string]Tree
+
+	// storeKeys are the sorted store keys of multiTrees
+	storeKeys []string
+
+	// concurrency is the maximum number of trees processed at the same time
+	concurrency int
+
+	// telemetry reflects a telemetry agent responsible for emitting metrics (if any)
+	telemetry metrics.StoreMetrics
 }
 
-// NewCommitStore creates a new CommitStore instance.
+// NewCommitStore creates a new CommitStore instance. By default, up to
+// runtime.NumCPU() trees are processed concurrently, see SetConcurrency.
 func NewCommitStore(trees map[string]Tree, db corestore.KVStoreWithBatch, logger log.Logger) (*CommitStore, error) {
+	storeKeys := make([]string, 0, len(trees))
+	for storeKey := range trees {
+		storeKeys = append(storeKeys, storeKey)
+	}
+	sort.Strings(storeKeys)
+
 	return &CommitStore{
-		logger:     logger,
-		multiTrees: trees,
-		metadata:   NewMetadataStore(db),
+		logger:      logger,
+		multiTrees:  trees,
+		metadata:    NewMetadataStore(db),
+		storeKeys:   storeKeys,
+		concurrency: runtime.NumCPU(),
 	}, nil
 }
 
+// SetConcurrency sets the maximum number of trees which are written, hashed and
+// committed concurrently. A value of 1 processes the trees sequentially, and
+// values < 1 remove the limit.
+func (c *CommitStore) SetConcurrency(concurrency int) {
+	c.concurrency = concurrency
+}
+
+// SetMetrics sets the telemetry handler used to measure the time spent writing
+// and committing each tree.
+func (c *CommitStore) SetMetrics(m metrics.StoreMetrics) {
+	c.telemetry = m
+}
+
+// forEachTree calls fn concurrently, with at most c.concurrency calls running at
+// the same time, for each of the given store keys and their index in storeKeys.
+// It returns the first error returned by fn, if any.
+func (c *CommitStore) forEachTree(storeKeys []string, fn func(i int, storeKey string, tree Tree) error) error {
+	if len(storeKeys) == 1 {
+		return fn(0, storeKeys[0], c.multiTrees[storeKeys[0]])
+	}
+
+	eg := new(errgroup.Group)
+	if c.concurrency > 0 {
+		eg.SetLimit(c.concurrency)
+	}
+
+	for i, storeKey := range storeKeys {
+		i, storeKey := i, storeKey
+		eg.Go(func() error {
+			return fn(i, storeKey, c.multiTrees[storeKey])
+		})
+	}
+
+	return eg.Wait()
+}
+
+// commitStoreKeys returns the sorted store keys of the trees which are part of
+// the commit info, i.e. all of them except the memory stores.
+func (c *CommitStore) commitStoreKeys() []string {
+	storeKeys := make([]string, 0, len(c.storeKeys))
+	for _, storeKey := range c.storeKeys {
+		if !internal.IsMemoryStoreKey(storeKey) {
+			storeKeys = append(storeKeys, storeKey)
+		}
+	}
+
+	return storeKeys
+}
+
+func (c *CommitStore) measureSince(start time.Time, keys ...string) {
+	if c.telemetry != nil {
+		c.telemetry.MeasureSince(start, keys...)
+	}
+}
+
 func (c *CommitStore) WriteChangeset(cs *corestore.Changeset) error {
+	// group the changes by tree, preserving their order, so that each tree is
+	// written by a single worker
+	changes := make(map[string][]corestore.StateChanges, len(cs.Changes))
+	storeKeys := make([]string, 0, len(cs.Changes))
 	for _, pairs := range cs.Changes {
-
 		key := conv.UnsafeBytesToStr(pairs.Actor)
 
-		tree, ok := c.multiTrees[key]
-		if !ok {
+		if _, ok := c.multiTrees[key]; !ok {
 			return fmt.Errorf("store key %s not found in multiTrees", key)
 		}
-		for _, kv := range pairs.StateChanges {
-			if kv.Remove {
-				if err := tree.Remove(kv.Key); err != nil {
+		if _, ok := changes[key]; !ok {
+			storeKeys = append(storeKeys, key)
+		}
+		changes[key] = append(changes[key], pairs)
+	}
+
+	return c.forEachTree(storeKeys, func(_ int, storeKey string, tree Tree) error {
+		defer c.measureSince(time.Now(), "commit_store", "write_changeset", storeKey)
+
+		for _, pairs := range changes[storeKey] {
+			for _, kv := range pairs.StateChanges {
+				if kv.Remove {
+					if err := tree.Remove(kv.Key); err != nil {
+						return err
+					}
+				} else if err := tree.Set(kv.Key, kv.Value); err != nil {
 					return err
 				}
-			} else if err := tree.Set(kv.Key, kv.Value); err != nil {
-				return err
 			}
 		}
-	}
 
-	return nil
+		return nil
+	})
 }
 
 func (c *CommitStore) WorkingCommitInfo(version uint64) *proof.CommitInfo {
-	storeInfos := make([]proof.StoreInfo, 0, len(c.multiTrees))
-	for storeKey, tree := range c.multiTrees {
-		if internal.IsMemoryStoreKey(storeKey) {
-			continue
-		}
-		bz := []byte(storeKey)
-		storeInfos = append(storeInfos, proof.StoreInfo{
-			Name: bz,
+	storeKeys := c.commitStoreKeys()
+	storeInfos := make([]proof.StoreInfo, len(storeKeys))
+
+	// computing the working hash never fails
+	_ = c.forEachTree(storeKeys, func(i int, storeKey string, tree Tree) error {
+		defer c.measureSince(time.Now(), "commit_store", "working_hash", storeKey)
+
+		storeInfos[i] = proof.StoreInfo{
+			Name: []byte(storeKey),
 			CommitID: proof.CommitID{
 				Version: version,
 				Hash:    tree.WorkingHash(),
 			},
-		})
-	}
+		}
+
+		return nil
+	})
 
 	return &proof.CommitInfo{
 		Version:    version,
@@ -120,12 +223,12 @@ func (c *CommitStore) LoadVersion(targetVersion uint64) error {
 }
 
 func (c *CommitStore) Commit(version uint64) (*proof.CommitInfo, error) {
-	storeInfos := make([]proof.StoreInfo, 0, len(c.multiTrees))
+	storeKeys := c.commitStoreKeys()
+	storeInfos := make([]proof.StoreInfo, len(storeKeys))
+
+	if err := c.forEachTree(storeKeys, func(i int, storeKey string, tree Tree) error {
+		defer c.measureSince(time.Now(), "commit_store", "commit", storeKey)
 
-	for storeKey, tree := range c.multiTrees {
-		if internal.IsMemoryStoreKey(storeKey) {
-			continue
-		}
 		// If a commit event execution is interrupted, a new iavl store's version
 		// will be larger than the RMS's metadata, when the block is replayed, we
 		// should avoid committing that iavl store again.
@@ -136,20 +239,24 @@ func (c *CommitStore) Commit(version uint64) (*proof.CommitInfo, error) {
 		} else {
 			hash, cversion, err := tree.Commit()
 			if err != nil {
-				return nil, err
+				return fmt.Errorf("failed to commit store %s: %w", storeKey, err)
 			}
 			if cversion != version {
-				return nil, fmt.Errorf("commit version %d does not match the target version %d", cversion, version)
+				return fmt.Errorf("commit version %d does not match the target version %d", cversion, version)
 			}
 			commitID = proof.CommitID{
 				Version: version,
 				Hash:    hash,
 			}
 		}
-		storeInfos = append(storeInfos, proof.StoreInfo{
+		storeInfos[i] = proof.StoreInfo{
 			Name:     []byte(storeKey),
 			CommitID: commitID,
-		})
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
 	}
 
 	cInfo := &proof.CommitInfo{
@@ -192,6 +299,11 @@ func (c *CommitStore) GetProof(storeKey []byte, version uint64, key []byte) ([]p
 		return nil, fmt.Errorf("commit info not found for version %d", version)
 	}
 	commitOp := proof.NewIAVLCommitmentOp(key, iProof)
+	if typer, ok := tree.(ProofTyper); ok {
+		if commitOp, err = proof.NewCommitmentOp(typer.ProofType(), key, iProof); err != nil {
+			return nil, err
+		}
+	}
 	_, storeCommitmentOp, err := cInfo.GetStoreProof(storeKey)
 	if err != nil {
 		return nil, err
@@ -214,6 +326,17 @@ func (c *CommitStore) Get(storeKey []byte, version uint64, key []byte) ([]byte,
 	return bz, nil
 }
 
+// ExportTree returns an exporter of the tree of the given store key at the given
+// version.
+func (c *CommitStore) ExportTree(storeKey string, version uint64) (Exporter, error) {
+	tree, ok := c.multiTrees[storeKey]
+	if !ok {
+		return nil, fmt.Errorf("store %s not found", storeKey)
+	}
+
+	return tree.Export(version)
+}
+
 // Prune implements store.Pruner.
 func (c *CommitStore) Prune(version uint64) (ferr error) {
 	// prune the metadata
@@ -256,45 +379,48 @@ func (c *CommitStore) Snapshot(version uint64, protoWriter protoio.Writer) error
 	}
 
 	for storeKey, tree := range c.multiTrees {
-		// TODO: check the parallelism of this loop
-		if err := func() error {
-			exporter, err := tree.Export(version)
-			if err != nil {
-				return fmt.Errorf("failed to export tree for version %d: %w", version, err)
-			}
-			defer exporter.Close()
+		if err := c.exportTree(version, storeKey, tree, protoWriter); err != nil {
+			return err
+		}
+	}
 
-			err = protoWriter.WriteMsg(&snapshotstypes.SnapshotItem{
-				Item: &snapshotstypes.SnapshotItem_Store{
-					Store: &snapshotstypes.SnapshotStoreItem{
-						Name: storeKey,
-					},
-				},
-			})
-			if err != nil {
-				return fmt.Errorf("failed to write store name: %w", err)
-			}
+	return nil
+}
 
-			for {
-				item, err := exporter.Next()
-				if errors.Is(err, ErrorExportDone) {
-					break
-				} else if err != nil {
-					return fmt.Errorf("failed to get the next export node: %w", err)
-				}
+// exportTree writes the store item of the given store key followed by the nodes
+// of its tree at the given version.
+func (c *CommitStore) exportTree(version uint64, storeKey string, tree Tree, protoWriter protoio.Writer) error {
+	exporter, err := tree.Export(version)
+	if err != nil {
+		return fmt.Errorf("failed to export tree for version %d: %w", version, err)
+	}
+	defer exporter.Close()
 
-				if err = protoWriter.WriteMsg(&snapshotstypes.SnapshotItem{
-					Item: &snapshotstypes.SnapshotItem_IAVL{
-						IAVL: item,
-					},
-				}); err != nil {
-					return fmt.Errorf("failed to write iavl node: %w", err)
-				}
-			}
+	err = protoWriter.WriteMsg(&snapshotstypes.SnapshotItem{
+		Item: &snapshotstypes.SnapshotItem_Store{
+			Store: &snapshotstypes.SnapshotStoreItem{
+				Name: storeKey,
+			},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to write store name: %w", err)
+	}
 
-			return nil
-		}(); err != nil {
-			return err
+	for {
+		item, err := exporter.Next()
+		if errors.Is(err, ErrorExportDone) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("failed to get the next export node: %w", err)
+		}
+
+		if err = protoWriter.WriteMsg(&snapshotstypes.SnapshotItem{
+			Item: &snapshotstypes.SnapshotItem_IAVL{
+				IAVL: item,
+			},
+		}); err != nil {
+			return fmt.Errorf("failed to write iavl node: %w", err)
 		}
 	}
 
@@ -350,35 +476,8 @@ loop:
 			if importer == nil {
 				return snapshotstypes.SnapshotItem{}, fmt.Errorf("received IAVL node item before store item")
 			}
-			node := item.IAVL
-			if node.Height > int32(math.MaxInt8) {
-				return snapshotstypes.SnapshotItem{}, fmt.Errorf("node height %v cannot exceed %v",
-					item.IAVL.Height, math.MaxInt8)
-			}
-			// Protobuf does not differentiate between []byte{} and nil, but fortunately IAVL does
-			// not allow nil keys nor nil values for leaf nodes, so we can always set them to empty.
-			if node.Key == nil {
-				node.Key = []byte{}
-			}
-			if node.Height == 0 {
-				if node.Value == nil {
-					node.Value = []byte{}
-				}
-
-				// If the node is a leaf node, it will be written to the storage.
-				chStorage <- &corestore.StateChanges{
-					Actor: storeKey,
-					StateChanges: []corestore.KVPair{
-						{
-							Key:   node.Key,
-							Value: node.Value,
-						},
-					},
-				}
-			}
-			err := importer.Add(node)
-			if err != nil {
-				return snapshotstypes.SnapshotItem{}, fmt.Errorf("failed to add node to importer: %w", err)
+			if err := importNode(importer, storeKey, item.IAVL, chStorage); err != nil {
+				return snapshotstypes.SnapshotItem{}, err
 			}
 		default:
 			break loop
@@ -394,6 +493,345 @@ loop:
 	return snapshotItem, c.LoadVersion(version)
 }
 
+// importNode adds a node of the tree of the given store key to the importer,
+// passing the leaves to the storage state.
+func importNode(importer Importer, storeKey []byte, node *snapshotstypes.SnapshotIAVLItem, chStorage chan<- *corestore.StateChanges) error {
+	if node.Height > int32(math.MaxInt8) {
+		return fmt.Errorf("node height %v cannot exceed %v", node.Height, math.MaxInt8)
+	}
+	// Protobuf does not differentiate between []byte{} and nil, but fortunately IAVL does
+	// not allow nil keys nor nil values for leaf nodes, so we can always set them to empty.
+	if node.Key == nil {
+		node.Key = []byte{}
+	}
+	if node.Height == 0 {
+		if node.Value == nil {
+			node.Value = []byte{}
+		}
+
+		// If the node is a leaf node, it will be written to the storage.
+		chStorage <- &corestore.StateChanges{
+			Actor: storeKey,
+			StateChanges: []corestore.KVPair{
+				{
+					Key:   node.Key,
+					Value: node.Value,
+				},
+			},
+		}
+	}
+	if err := importer.Add(node); err != nil {
+		return fmt.Errorf("failed to add node to importer: %w", err)
+	}
+
+	return nil
+}
+
+// SnapshotStoreKeys implements snapshots.SegmentSnapshotter.
+func (c *CommitStore) SnapshotStoreKeys() []string {
+	return c.commitStoreKeys()
+}
+
+// SnapshotSegment implements snapshots.SegmentSnapshotter.
+func (c *CommitStore) SnapshotSegment(version uint64, storeKey string, protoWriter protoio.Writer) error {
+	if version == 0 {
+		return fmt.Errorf("the snapshot version must be greater than 0")
+	}
+
+	latestVersion, err := c.GetLatestVersion()
+	if err != nil {
+		return err
+	}
+	if version > latestVersion {
+		return fmt.Errorf("the snapshot version %d is greater than the latest version %d", version, latestVersion)
+	}
+
+	tree := c.multiTrees[storeKey]
+	if tree == nil {
+		return fmt.Errorf("store %s not found", storeKey)
+	}
+
+	return c.exportTree(version, storeKey, tree, protoWriter)
+}
+
+// RestoreSegment implements snapshots.SegmentSnapshotter. The segments of
+// distinct store keys may be restored concurrently.
+func (c *CommitStore) RestoreSegment(
+	version uint64,
+	storeKey string,
+	protoReader protoio.Reader,
+	chStorage chan<- *corestore.StateChanges,
+) error {
+	tree := c.multiTrees[storeKey]
+	if tree == nil {
+		return fmt.Errorf("store %s not found", storeKey)
+	}
+
+	var snapshotItem snapshotstypes.SnapshotItem
+	if err := protoReader.ReadMsg(&snapshotItem); err != nil {
+		return fmt.Errorf("invalid protobuf message: %w", err)
+	}
+	if item := snapshotItem.GetStore(); item == nil || item.Name != storeKey {
+		return fmt.Errorf("expected the store item of %s, got %v", storeKey, snapshotItem.Item)
+	}
+
+	importer, err := tree.Import(version)
+	if err != nil {
+		return fmt.Errorf("failed to import tree for version %d: %w", version, err)
+	}
+	defer importer.Close()
+
+	for {
+		snapshotItem = snapshotstypes.SnapshotItem{}
+		err := protoReader.ReadMsg(&snapshotItem)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return fmt.Errorf("invalid protobuf message: %w", err)
+		}
+
+		item := snapshotItem.GetIAVL()
+		if item == nil {
+			return fmt.Errorf("unexpected snapshot item %T in store %s", snapshotItem.Item, storeKey)
+		}
+		if err := importNode(importer, []byte(storeKey), item, chStorage); err != nil {
+			return err
+		}
+	}
+
+	if err := importer.Commit(); err != nil {
+		return fmt.Errorf("failed to commit importer: %w", err)
+	}
+
+	return nil
+}
+
+// FinalizeRestore implements snapshots.SegmentSnapshotter.
+func (c *CommitStore) FinalizeRestore(version uint64) error {
+	return c.LoadVersion(version)
+}
+
+// CanSnapshotDelta implements snapshots.DeltaSnapshotter. Tree versions are
+// pruned from the oldest, so the changes since the base version are available
+// as long as every tree implements ChangesetTree and still has the base version.
+func (c *CommitStore) CanSnapshotDelta(base, version uint64) bool {
+	latestVersion, err := c.GetLatestVersion()
+	if err != nil || base == 0 || base >= version || version > latestVersion {
+		return false
+	}
+
+	for _, storeKey := range c.commitStoreKeys() {
+		tree, ok := c.multiTrees[storeKey].(ChangesetTree)
+		if !ok || !tree.VersionExists(base) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// SnapshotDelta implements snapshots.DeltaSnapshotter. For each version in
+// (base, version], a delta item with the commit hash of the version is followed
+// by a store item for each store changed at the version, itself followed by a
+// change item for each changed key, in the order of the keys.
+func (c *CommitStore) SnapshotDelta(base, version uint64, protoWriter protoio.Writer) error {
+	if !c.CanSnapshotDelta(base, version) {
+		return fmt.Errorf("cannot snapshot the changes from version %d to %d", base, version)
+	}
+
+	storeKeys := c.commitStoreKeys()
+	for v := base + 1; v <= version; v++ {
+		cInfo, err := c.GetCommitInfo(v)
+		if err != nil {
+			return fmt.Errorf("failed to get commit info for version %d: %w", v, err)
+		}
+		if cInfo == nil {
+			return fmt.Errorf("commit info for version %d not found", v)
+		}
+		if err := protoWriter.WriteMsg(&snapshotstypes.SnapshotItem{
+			Item: &snapshotstypes.SnapshotItem_Delta{
+				Delta: &snapshotstypes.SnapshotDeltaItem{
+					Version: v,
+					Hash:    cInfo.Hash(),
+				},
+			},
+		}); err != nil {
+			return fmt.Errorf("failed to write delta item: %w", err)
+		}
+
+		for _, storeKey := range storeKeys {
+			tree := c.multiTrees[storeKey].(ChangesetTree)
+			if err := tree.TraverseChangesets(v, v, func(_ uint64, changes corestore.KVPairs) error {
+				return writeChanges(storeKey, changes, protoWriter)
+			}); err != nil {
+				return fmt.Errorf("failed to traverse the changes of store %s at version %d: %w", storeKey, v, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeChanges writes the store item of the given store key followed by its
+// changes, if any.
+func writeChanges(storeKey string, changes corestore.KVPairs, protoWriter protoio.Writer) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	if err := protoWriter.WriteMsg(&snapshotstypes.SnapshotItem{
+		Item: &snapshotstypes.SnapshotItem_Store{
+			Store: &snapshotstypes.SnapshotStoreItem{
+				Name: storeKey,
+			},
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to write store name: %w", err)
+	}
+
+	for _, change := range changes {
+		if err := protoWriter.WriteMsg(&snapshotstypes.SnapshotItem{
+			Item: &snapshotstypes.SnapshotItem_Change{
+				Change: &snapshotstypes.SnapshotChangeItem{
+					Key:    change.Key,
+					Value:  change.Value,
+					Delete: change.Remove,
+				},
+			},
+		}); err != nil {
+			return fmt.Errorf("failed to write change: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// RestoreDelta implements snapshots.DeltaSnapshotter. The changes of each
+// version are written to the trees and committed, and the resulting commit
+// hash is verified against the one recorded in the snapshot. As the shape of an
+// IAVL tree depends on the order of its writes, the hashes only match if the
+// changes were originally written in the order of the keys, as the state
+// changes of a block are.
+func (c *CommitStore) RestoreDelta(
+	base, version uint64,
+	protoReader protoio.Reader,
+	applyStorage func(version uint64, cs *corestore.Changeset) error,
+) (snapshotstypes.SnapshotItem, error) {
+	latestVersion, err := c.GetLatestVersion()
+	if err != nil {
+		return snapshotstypes.SnapshotItem{}, err
+	}
+	if latestVersion != base {
+		return snapshotstypes.SnapshotItem{}, fmt.Errorf("cannot restore the changes since version %d at version %d", base, latestVersion)
+	}
+
+	var (
+		snapshotItem snapshotstypes.SnapshotItem
+		cs           *corestore.Changeset
+		storeKey     []byte
+		current      uint64
+		hash         []byte
+	)
+	// commit commits the changes of the current version, if any.
+	commit := func() error {
+		if current == 0 {
+			return nil
+		}
+		if err := c.WriteChangeset(cs); err != nil {
+			return err
+		}
+		cInfo, err := c.Commit(current)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(cInfo.Hash(), hash) {
+			return fmt.Errorf("%w: version %d: expected %X, got %X", snapshotstypes.ErrDeltaHashMismatch, current, hash, cInfo.Hash())
+		}
+		return applyStorage(current, cs)
+	}
+
+loop:
+	for {
+		snapshotItem = snapshotstypes.SnapshotItem{}
+		err := protoReader.ReadMsg(&snapshotItem)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return snapshotstypes.SnapshotItem{}, fmt.Errorf("invalid protobuf message: %w", err)
+		}
+
+		switch item := snapshotItem.Item.(type) {
+		case *snapshotstypes.SnapshotItem_Delta:
+			if err := commit(); err != nil {
+				return snapshotstypes.SnapshotItem{}, err
+			}
+			if expected := max(current, base) + 1; item.Delta.Version != expected {
+				return snapshotstypes.SnapshotItem{}, fmt.Errorf("received the changes of version %d, expected %d", item.Delta.Version, expected)
+			}
+			current, hash = item.Delta.Version, item.Delta.Hash
+			cs, storeKey = corestore.NewChangeset(), nil
+
+		case *snapshotstypes.SnapshotItem_Store:
+			if current == 0 {
+				return snapshotstypes.SnapshotItem{}, fmt.Errorf("received store item before delta item")
+			}
+			if _, ok := c.multiTrees[item.Store.Name]; !ok {
+				return snapshotstypes.SnapshotItem{}, fmt.Errorf("store %s not found", item.Store.Name)
+			}
+			storeKey = []byte(item.Store.Name)
+
+		case *snapshotstypes.SnapshotItem_Change:
+			if storeKey == nil {
+				return snapshotstypes.SnapshotItem{}, fmt.Errorf("received change item before store item")
+			}
+			// Protobuf does not differentiate between []byte{} and nil, but IAVL does
+			// not allow nil values, so we can always set them to empty.
+			value := item.Change.Value
+			if value == nil && !item.Change.Delete {
+				value = []byte{}
+			}
+			cs.Add(storeKey, item.Change.Key, value, item.Change.Delete)
+
+		default:
+			break loop
+		}
+	}
+
+	if err := commit(); err != nil {
+		return snapshotstypes.SnapshotItem{}, err
+	}
+	if current != version {
+		return snapshotstypes.SnapshotItem{}, fmt.Errorf("restored the changes up to version %d, expected %d", current, version)
+	}
+
+	return snapshotItem, nil
+}
+
+// GetChangeset implements store.ChangesetReader. The changes of the memory
+// stores, which are not committed, are not part of the changeset.
+func (c *CommitStore) GetChangeset(version uint64) (*corestore.Changeset, error) {
+	cs := corestore.NewChangeset()
+	for _, storeKey := range c.commitStoreKeys() {
+		tree, ok := c.multiTrees[storeKey].(ChangesetTree)
+		if !ok {
+			return nil, fmt.Errorf("store %s does not keep the changes of its versions", storeKey)
+		}
+		if !tree.VersionExists(version) {
+			return nil, fmt.Errorf("version %d of store %s does not exist", version, storeKey)
+		}
+		if err := tree.TraverseChangesets(version, version, func(_ uint64, changes corestore.KVPairs) error {
+			if len(changes) > 0 {
+				cs.Changes = append(cs.Changes, corestore.StateChanges{Actor: []byte(storeKey), StateChanges: changes})
+			}
+			return nil
+		}); err != nil {
+			return nil, fmt.Errorf("failed to traverse the changes of store %s at version %d: %w", storeKey, version, err)
+		}
+	}
+
+	return cs, nil
+}
+
 func (c *CommitStore) GetCommitInfo(version uint64) (*proof.CommitInfo, error) {
 	return c.metadata.GetCommitInfo(version)
 }
@@ -4,9 +4,15 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"math"
+	"sort"
+	"strings"
 	"sync"
+	"time"
 
+	protoio "github.com/cosmos/gogoproto/io"
 	"github.com/stretchr/testify/suite"
+	"golang.org/x/sync/errgroup"
 
 	"cosmossdk.io/core/log"
 	corestore "cosmossdk.io/core/store"
@@ -123,6 +129,145 @@ func (s *CommitStoreTestSuite) TestStore_Snapshotter() {
 	}
 }
 
+func (s *CommitStoreTestSuite) TestStore_SegmentSnapshotter() {
+	storeKeys := []string{storeKey1, storeKey2}
+	commitStore, err := s.NewStore(dbm.NewMemDB(), storeKeys, log.NewNopLogger())
+	s.Require().NoError(err)
+
+	latestVersion := uint64(5)
+	kvCount := 10
+	for i := uint64(1); i <= latestVersion; i++ {
+		kvPairs := make(map[string]corestore.KVPairs)
+		for _, storeKey := range storeKeys {
+			for j := 0; j < kvCount; j++ {
+				key := []byte(fmt.Sprintf("key-%d-%d", i, j))
+				value := []byte(fmt.Sprintf("value-%s-%d-%d", storeKey, i, j))
+				kvPairs[storeKey] = append(kvPairs[storeKey], corestore.KVPair{Key: key, Value: value})
+			}
+		}
+		s.Require().NoError(commitStore.WriteChangeset(corestore.NewChangesetWithPairs(kvPairs)))
+		_, err = commitStore.Commit(i)
+		s.Require().NoError(err)
+	}
+	s.Require().Equal(storeKeys, commitStore.SnapshotStoreKeys())
+
+	// snapshot each store into its own segment
+	segments := make(map[string]*bytes.Buffer)
+	for _, storeKey := range storeKeys {
+		segments[storeKey] = new(bytes.Buffer)
+		s.Require().NoError(commitStore.SnapshotSegment(latestVersion, storeKey, protoio.NewDelimitedWriter(segments[storeKey])))
+	}
+	s.Require().Error(commitStore.SnapshotSegment(latestVersion+1, storeKey1, protoio.NewDelimitedWriter(new(bytes.Buffer))))
+
+	// restore the segments concurrently
+	targetStore, err := s.NewStore(dbm.NewMemDB(), storeKeys, log.NewNopLogger())
+	s.Require().NoError(err)
+	chStorage := make(chan *corestore.StateChanges, 100)
+	leaves := make(map[string]string)
+	done := make(chan struct{})
+	go func() {
+		for kv := range chStorage {
+			for _, pair := range kv.StateChanges {
+				leaves[fmt.Sprintf("%s_%s", kv.Actor, pair.Key)] = string(pair.Value)
+			}
+		}
+		close(done)
+	}()
+	eg := new(errgroup.Group)
+	for _, storeKey := range storeKeys {
+		storeKey := storeKey
+		eg.Go(func() error {
+			protoReader := protoio.NewDelimitedReader(segments[storeKey], math.MaxInt32)
+			return targetStore.RestoreSegment(latestVersion, storeKey, protoReader, chStorage)
+		})
+	}
+	s.Require().NoError(eg.Wait())
+	close(chStorage)
+	<-done
+	s.Require().NoError(targetStore.FinalizeRestore(latestVersion))
+
+	s.Require().Equal(len(storeKeys)*kvCount*int(latestVersion), len(leaves))
+	s.Require().Equal(fmt.Sprintf("value-%s-1-0", storeKey2), leaves[fmt.Sprintf("%s_key-1-0", storeKey2)])
+	targetVersion, err := targetStore.GetLatestVersion()
+	s.Require().NoError(err)
+	s.Require().Equal(latestVersion, targetVersion)
+	s.Require().Equal(commitStore.WorkingCommitInfo(latestVersion).Hash(), targetStore.WorkingCommitInfo(latestVersion).Hash())
+}
+
+func (s *CommitStoreTestSuite) TestStore_DeltaSnapshotter() {
+	storeKeys := []string{storeKey1, storeKey2}
+	commitStore, err := s.NewStore(dbm.NewMemDB(), storeKeys, log.NewNopLogger())
+	s.Require().NoError(err)
+
+	latestVersion := uint64(5)
+	kvCount := 10
+	for i := uint64(1); i <= latestVersion; i++ {
+		kvPairs := make(map[string]corestore.KVPairs)
+		// the last version has no changes
+		for j := 0; i < latestVersion && j < kvCount; j++ {
+			// overwrite and remove the keys of the previous version
+			key := []byte(fmt.Sprintf("key-%d", int(i)*kvCount/2+j))
+			value := []byte(fmt.Sprintf("value-%d-%d", i, j))
+			kvPairs[storeKey1] = append(kvPairs[storeKey1], corestore.KVPair{Key: key, Value: value})
+			if i > 1 && j%3 == 0 {
+				kvPairs[storeKey2] = append(kvPairs[storeKey2], corestore.KVPair{Key: []byte(fmt.Sprintf("key-%d-%d", i-1, j)), Remove: true})
+			}
+			kvPairs[storeKey2] = append(kvPairs[storeKey2], corestore.KVPair{Key: []byte(fmt.Sprintf("key-%d-%d", i, j)), Value: value})
+		}
+		// the changes are replayed in the order of the keys
+		for _, pairs := range kvPairs {
+			sort.Slice(pairs, func(a, b int) bool { return bytes.Compare(pairs[a].Key, pairs[b].Key) < 0 })
+		}
+		s.Require().NoError(commitStore.WriteChangeset(corestore.NewChangesetWithPairs(kvPairs)))
+		_, err = commitStore.Commit(i)
+		s.Require().NoError(err)
+	}
+	if !commitStore.CanSnapshotDelta(1, latestVersion) {
+		s.T().Skip("the trees do not keep the changes of their versions")
+	}
+	s.Require().False(commitStore.CanSnapshotDelta(latestVersion, latestVersion))
+	s.Require().False(commitStore.CanSnapshotDelta(1, latestVersion+1))
+
+	base := uint64(2)
+	full, delta := new(bytes.Buffer), new(bytes.Buffer)
+	s.Require().NoError(commitStore.Snapshot(base, protoio.NewDelimitedWriter(full)))
+	s.Require().NoError(commitStore.SnapshotDelta(base, latestVersion, protoio.NewDelimitedWriter(delta)))
+
+	// restore the full snapshot at the base version
+	targetStore, err := s.NewStore(dbm.NewMemDB(), storeKeys, log.NewNopLogger())
+	s.Require().NoError(err)
+	chStorage := make(chan *corestore.StateChanges, 1000)
+	_, err = targetStore.Restore(base, snapshotstypes.CurrentFormat, protoio.NewDelimitedReader(full, math.MaxInt32), chStorage)
+	s.Require().NoError(err)
+	close(chStorage)
+
+	// then the changes of the following versions
+	changesets := make(map[uint64]int)
+	_, err = targetStore.RestoreDelta(base, latestVersion, protoio.NewDelimitedReader(delta, math.MaxInt32), func(version uint64, cs *corestore.Changeset) error {
+		changesets[version] = cs.Size()
+		return nil
+	})
+	s.Require().NoError(err)
+	s.Require().Equal(map[uint64]int{3: kvCount + kvCount + 4, 4: kvCount + kvCount + 4, 5: 0}, changesets)
+
+	targetVersion, err := targetStore.GetLatestVersion()
+	s.Require().NoError(err)
+	s.Require().Equal(latestVersion, targetVersion)
+	for v := base + 1; v <= latestVersion; v++ {
+		expected, err := commitStore.GetCommitInfo(v)
+		s.Require().NoError(err)
+		actual, err := targetStore.GetCommitInfo(v)
+		s.Require().NoError(err)
+		s.Require().Equal(expected.Hash(), actual.Hash(), "version %d", v)
+	}
+
+	// the changes can only be restored on top of the base version
+	_, err = targetStore.RestoreDelta(base, latestVersion, protoio.NewDelimitedReader(delta, math.MaxInt32), func(uint64, *corestore.Changeset) error {
+		return nil
+	})
+	s.Require().Error(err)
+}
+
 func (s *CommitStoreTestSuite) TestStore_Pruning() {
 	storeKeys := []string{storeKey1, storeKey2}
 	pruneOpts := store.NewPruningOptionWithCustom(10, 5)
@@ -163,3 +308,67 @@ func (s *CommitStoreTestSuite) TestStore_Pruning() {
 		}
 	}
 }
+
+type measurements struct {
+	mtx  sync.Mutex
+	keys map[string]int
+}
+
+func (m *measurements) MeasureSince(_ time.Time, keys ...string) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.keys[strings.Join(keys, ".")]++
+}
+
+func (s *CommitStoreTestSuite) TestStore_ConcurrentCommit() {
+	storeKeys := make([]string, 8)
+	for i := range storeKeys {
+		storeKeys[i] = fmt.Sprintf("store%d", i)
+	}
+
+	sequential, err := s.NewStore(dbm.NewMemDB(), storeKeys, log.NewNopLogger())
+	s.Require().NoError(err)
+	sequential.SetConcurrency(1)
+
+	concurrent, err := s.NewStore(dbm.NewMemDB(), storeKeys, log.NewNopLogger())
+	s.Require().NoError(err)
+	concurrent.SetConcurrency(4)
+	m := &measurements{keys: map[string]int{}}
+	concurrent.SetMetrics(m)
+
+	latestVersion := uint64(10)
+	for i := uint64(1); i <= latestVersion; i++ {
+		cs := corestore.NewChangeset()
+		for _, storeKey := range storeKeys {
+			for j := 0; j < 10; j++ {
+				cs.Add([]byte(storeKey), []byte(fmt.Sprintf("key-%d-%d", i, j)), []byte(fmt.Sprintf("value-%d-%d", i, j)), false)
+			}
+			// the changes of a store must be applied in order
+			cs.Add([]byte(storeKey), []byte(fmt.Sprintf("key-%d-0", i)), nil, true)
+		}
+
+		s.Require().NoError(sequential.WriteChangeset(cs))
+		s.Require().NoError(concurrent.WriteChangeset(cs))
+		s.Require().Equal(sequential.WorkingCommitInfo(i), concurrent.WorkingCommitInfo(i))
+
+		expected, err := sequential.Commit(i)
+		s.Require().NoError(err)
+		actual, err := concurrent.Commit(i)
+		s.Require().NoError(err)
+		s.Require().Equal(expected.Hash(), actual.Hash())
+
+		// the commit info is assembled in the order of the store keys
+		for j, si := range actual.StoreInfos {
+			s.Require().Equal(storeKeys[j], string(si.Name))
+		}
+	}
+
+	for _, storeKey := range storeKeys {
+		s.Require().Equal(int(latestVersion), m.keys["commit_store.write_changeset."+storeKey])
+		s.Require().Equal(int(latestVersion), m.keys["commit_store.commit."+storeKey])
+	}
+
+	s.Require().ErrorContains(concurrent.WriteChangeset(corestore.NewChangesetWithPairs(
+		map[string]corestore.KVPairs{"unknown": {{Key: []byte("key"), Value: []byte("value")}}},
+	)), "store key unknown not found")
+}
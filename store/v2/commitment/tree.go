@@ -6,6 +6,7 @@ import (
 
 	ics23 "github.com/cosmos/ics23/go"
 
+	corestore "cosmossdk.io/core/store"
 	snapshotstypes "cosmossdk.io/store/v2/snapshots/types"
 )
 
@@ -42,6 +43,24 @@ type Tree interface {
 	io.Closer
 }
 
+// ProofTyper is optionally implemented by the trees whose proofs are not IAVL
+// proofs, it returns the proof.CommitmentOp type of their proofs, e.g.
+// proof.ProofOpSMTCommitment.
+type ProofTyper interface {
+	ProofType() string
+}
+
+// ChangesetTree is optionally implemented by the trees which keep the changes of
+// each of their versions, from which incremental snapshots are taken.
+type ChangesetTree interface {
+	// VersionExists returns true if the given version has not been pruned.
+	VersionExists(version uint64) bool
+
+	// TraverseChangesets calls fn with the changes of each version in
+	// [start, end], in the order of the keys.
+	TraverseChangesets(start, end uint64, fn func(version uint64, changes corestore.KVPairs) error) error
+}
+
 // Exporter is the interface that wraps the basic Export methods.
 type Exporter interface {
 	Next() (*snapshotstypes.SnapshotIAVLItem, error)
@@ -25,6 +25,15 @@ type VersionedDatabase interface {
 	io.Closer
 }
 
+// ChangesetReader is optionally implemented by the Committers which keep the
+// changes of each of their versions.
+type ChangesetReader interface {
+	// GetChangeset returns the changes committed at the given version, with the
+	// changes of each store in the order of the keys. It must error if the
+	// version does not exist or has been pruned.
+	GetChangeset(version uint64) (*corestore.Changeset, error)
+}
+
 // Committer defines an API for committing state.
 type Committer interface {
 	// WriteChangeset writes the changeset to the commitment state.
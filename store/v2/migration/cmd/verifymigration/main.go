@@ -0,0 +1,334 @@
+// verifymigration verifies offline that the state of a store/v1 data directory
+// was migrated correctly to the store/v2 state storage (SS) and state
+// commitment (SC) at a given height, and prints the verification report.
+//
+// Usage:
+//
+//	verifymigration -source-dir <home>/data -ss-dir <home>/data/ss/pebble [-sc-dir <dir>] [flags]
+//
+// The verification is checkpointed per store key in the -checkpoint-dir
+// directory, if set, so that an interrupted verification can be resumed.
+//
+// It exits with status 0 if the state was migrated correctly, 1 if the report
+// contains mismatches and 2 if the verification could not be performed.
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+
+	corelog "cosmossdk.io/core/log"
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/log"
+	"cosmossdk.io/store/v2/commitment"
+	"cosmossdk.io/store/v2/commitment/iavl"
+	dbm "cosmossdk.io/store/v2/db"
+	"cosmossdk.io/store/v2/migration"
+	"cosmossdk.io/store/v2/proof"
+	"cosmossdk.io/store/v2/storage"
+	"cosmossdk.io/store/v2/storage/pebbledb"
+	"cosmossdk.io/store/v2/storage/sqlite"
+)
+
+const (
+	exitOK       = 0
+	exitMismatch = 1
+	exitError    = 2
+
+	// v1StoreKeyPrefixFmt is the prefix of the IAVL trees in a store/v1 rootmulti db
+	v1StoreKeyPrefixFmt = "s/k:%s/"
+	// v1CommitInfoKeyFmt is the key of the commit info of a version in a store/v1 rootmulti db
+	v1CommitInfoKeyFmt = "s/%d"
+)
+
+func main() {
+	code, err := run(os.Args[1:], os.Stdout, log.NewLogger(os.Stderr))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+	}
+	os.Exit(code)
+}
+
+func run(args []string, out io.Writer, logger corelog.Logger) (int, error) {
+	flags := flag.NewFlagSet("verifymigration", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	var (
+		sourceDir     = flags.String("source-dir", "", "directory of the store/v1 db (required)")
+		sourceName    = flags.String("source-name", "application", "name of the store/v1 db")
+		scDir         = flags.String("sc-dir", "", "directory of the store/v2 SC db, the SC is not verified if empty")
+		scName        = flags.String("sc-name", "application", "name of the store/v2 SC db")
+		backend       = flags.String("backend", string(dbm.DBTypeGoLevelDB), "backend of the store/v1 and store/v2 SC dbs")
+		ssDir         = flags.String("ss-dir", "", "directory of the store/v2 SS (required)")
+		ssType        = flags.String("ss-type", "pebble", "type of the store/v2 SS: pebble or sqlite")
+		checkpointDir = flags.String("checkpoint-dir", "", "directory of the checkpoint db, the verification is not resumable if empty")
+		height        = flags.Uint64("height", 0, "migration height, defaults to the latest SS version")
+		storeKeys     = flags.String("store-keys", "", "comma separated store keys, defaults to the store keys of the SC")
+		jsonOutput    = flags.Bool("json", false, "print the report as JSON")
+	)
+	if err := flags.Parse(args); err != nil {
+		return exitError, err
+	}
+	if *sourceDir == "" || *ssDir == "" {
+		return exitError, errors.New("usage: verifymigration -source-dir <dir> -ss-dir <dir> [-sc-dir <dir>] [flags]")
+	}
+
+	var keys []string
+	if *storeKeys != "" {
+		keys = strings.Split(*storeKeys, ",")
+	}
+	if len(keys) == 0 && *scDir == "" {
+		return exitError, errors.New("-store-keys is required when -sc-dir is not set")
+	}
+
+	sourceDB, err := dbm.NewDB(dbm.DBType(*backend), *sourceName, *sourceDir, nil)
+	if err != nil {
+		return exitError, fmt.Errorf("failed to open source db: %w", err)
+	}
+	defer sourceDB.Close()
+
+	ss, err := openStateStorage(*ssType, *ssDir, logger)
+	if err != nil {
+		return exitError, err
+	}
+	defer ss.Close()
+
+	var sc *commitment.CommitStore
+	if *scDir != "" {
+		scDB, err := dbm.NewDB(dbm.DBType(*backend), *scName, *scDir, nil)
+		if err != nil {
+			return exitError, fmt.Errorf("failed to open SC db: %w", err)
+		}
+		defer scDB.Close()
+
+		if len(keys) == 0 {
+			if keys, err = commitStoreKeys(scDB, *height); err != nil {
+				return exitError, err
+			}
+		}
+		if sc, err = openCommitStore(scDB, keys, "%s", logger); err != nil {
+			return exitError, err
+		}
+	}
+
+	sourceTrees, err := openCommitStore(sourceDB, keys, v1StoreKeyPrefixFmt, logger)
+	if err != nil {
+		return exitError, err
+	}
+	source := v1Source{CommitStore: sourceTrees, db: sourceDB}
+
+	if *height == 0 {
+		if *height, err = ss.GetLatestVersion(); err != nil {
+			return exitError, fmt.Errorf("failed to get the latest SS version: %w", err)
+		}
+	}
+
+	var checkpointDB corestore.KVStoreWithBatch = dbm.NewMemDB()
+	if *checkpointDir != "" {
+		if checkpointDB, err = dbm.NewGoLevelDB("migration_verify", *checkpointDir, nil); err != nil {
+			return exitError, fmt.Errorf("failed to open checkpoint db: %w", err)
+		}
+	}
+	defer checkpointDB.Close()
+
+	report, err := migration.NewManager(checkpointDB, nil, ss, sc, logger).Verify(*height, source, keys)
+	if err != nil {
+		return exitError, err
+	}
+
+	if err := printReport(out, report, *jsonOutput); err != nil {
+		return exitError, err
+	}
+
+	if !report.OK() {
+		return exitMismatch, nil
+	}
+	return exitOK, nil
+}
+
+func openStateStorage(ssType, dir string, logger corelog.Logger) (*storage.StorageStore, error) {
+	var (
+		db  storage.Database
+		err error
+	)
+	switch ssType {
+	case "pebble":
+		db, err = pebbledb.New(dir)
+	case "sqlite":
+		db, err = sqlite.New(dir)
+	default:
+		return nil, fmt.Errorf("unsupported SS type: %s", ssType)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open SS: %w", err)
+	}
+
+	return storage.NewStorageStore(db, logger), nil
+}
+
+// openCommitStore opens the IAVL trees of the given store keys, which are
+// stored under the prefix built from prefixFmt and the store key. The trees are
+// only read and never loaded for overwriting.
+func openCommitStore(db corestore.KVStoreWithBatch, storeKeys []string, prefixFmt string, logger corelog.Logger) (*commitment.CommitStore, error) {
+	cfg := iavl.DefaultConfig()
+	cfg.SkipFastStorageUpgrade = true
+
+	trees := make(map[string]commitment.Tree, len(storeKeys))
+	for _, storeKey := range storeKeys {
+		prefixDB := dbm.NewPrefixDB(db, []byte(fmt.Sprintf(prefixFmt, storeKey)))
+		trees[storeKey] = iavl.NewIavlTree(prefixDB, logger, cfg)
+	}
+
+	return commitment.NewCommitStore(trees, db, logger)
+}
+
+// v1Source is a store/v1 rootmulti db, whose IAVL trees are read through a
+// commitment.CommitStore and whose commit info is read from the rootmulti keys.
+type v1Source struct {
+	*commitment.CommitStore
+	db corestore.KVStoreWithBatch
+}
+
+// GetCommitInfo returns the commit info of the given version, or nil if there
+// is none.
+func (s v1Source) GetCommitInfo(version uint64) (*proof.CommitInfo, error) {
+	bz, err := s.db.Get([]byte(fmt.Sprintf(v1CommitInfoKeyFmt, version)))
+	if err != nil || bz == nil {
+		return nil, err
+	}
+
+	commitInfo, err := decodeV1CommitInfo(bz)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode the commit info of version %d: %w", version, err)
+	}
+	return commitInfo, nil
+}
+
+// decodeV1CommitInfo decodes the protobuf encoded commit info of a store/v1
+// rootmulti store. Its timestamp is not decoded.
+func decodeV1CommitInfo(bz []byte) (*proof.CommitInfo, error) {
+	commitInfo := &proof.CommitInfo{}
+	err := rangeFields(bz, func(num protowire.Number, varint uint64, bytes []byte) error {
+		switch num {
+		case 1: // version
+			commitInfo.Version = varint
+		case 2: // store_infos
+			var storeInfo proof.StoreInfo
+			err := rangeFields(bytes, func(num protowire.Number, _ uint64, bytes []byte) error {
+				switch num {
+				case 1: // name
+					storeInfo.Name = bytes
+				case 2: // commit_id
+					return rangeFields(bytes, func(num protowire.Number, varint uint64, bytes []byte) error {
+						switch num {
+						case 1: // version
+							storeInfo.CommitID.Version = varint
+						case 2: // hash
+							storeInfo.CommitID.Hash = bytes
+						}
+						return nil
+					})
+				}
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			commitInfo.StoreInfos = append(commitInfo.StoreInfos, storeInfo)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return commitInfo, nil
+}
+
+// rangeFields calls fn with the number and the value of each varint and length
+// delimited field of a protobuf message. The other fields are skipped.
+func rangeFields(bz []byte, fn func(num protowire.Number, varint uint64, bytes []byte) error) error {
+	for len(bz) > 0 {
+		num, typ, n := protowire.ConsumeTag(bz)
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		bz = bz[n:]
+
+		var (
+			varint uint64
+			bytes  []byte
+		)
+		switch typ {
+		case protowire.VarintType:
+			varint, n = protowire.ConsumeVarint(bz)
+		case protowire.BytesType:
+			bytes, n = protowire.ConsumeBytes(bz)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, bz)
+		}
+		if n < 0 {
+			return protowire.ParseError(n)
+		}
+		bz = bz[n:]
+
+		if typ == protowire.VarintType || typ == protowire.BytesType {
+			if err := fn(num, varint, bytes); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// commitStoreKeys returns the store keys of the commit info of the SC at the
+// given version, or at its latest version if 0.
+func commitStoreKeys(db corestore.KVStoreWithBatch, version uint64) ([]string, error) {
+	metadata := commitment.NewMetadataStore(db)
+	if version == 0 {
+		var err error
+		if version, err = metadata.GetLatestVersion(); err != nil {
+			return nil, fmt.Errorf("failed to get the latest SC version: %w", err)
+		}
+	}
+
+	commitInfo, err := metadata.GetCommitInfo(version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the SC commit info: %w", err)
+	}
+	if commitInfo == nil {
+		return nil, fmt.Errorf("no SC commit info found for version %d, use -store-keys", version)
+	}
+
+	storeKeys := make([]string, 0, len(commitInfo.StoreInfos))
+	for _, si := range commitInfo.StoreInfos {
+		storeKeys = append(storeKeys, string(si.Name))
+	}
+	return storeKeys, nil
+}
+
+func printReport(out io.Writer, report *migration.VerificationReport, jsonOutput bool) error {
+	if jsonOutput {
+		enc := json.NewEncoder(out)
+		enc.SetIndent("", "  ")
+		return enc.Encode(report)
+	}
+
+	for _, store := range report.Stores {
+		status := "OK"
+		if !store.OK() {
+			status = "MISMATCH"
+		}
+		fmt.Fprintf(out, "%s: %s (%d keys)\n", store.StoreKey, status, store.Source.KeyCount)
+		for _, mismatch := range store.Mismatches {
+			fmt.Fprintf(out, "  %s\n", mismatch)
+		}
+	}
+
+	return nil
+}
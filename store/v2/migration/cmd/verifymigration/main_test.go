@@ -0,0 +1,120 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	corelog "cosmossdk.io/core/log"
+	corestore "cosmossdk.io/core/store"
+	dbm "cosmossdk.io/store/v2/db"
+	"cosmossdk.io/store/v2/proof"
+)
+
+func TestRun(t *testing.T) {
+	var (
+		logger    = corelog.NewNopLogger()
+		storeKeys = []string{"bank", "staking"}
+		sourceDir = t.TempDir()
+		scDir     = t.TempDir()
+		ssDir     = t.TempDir()
+		height    = uint64(5)
+	)
+
+	// write the same state to a store/v1 layout db, to the store/v2 SC and SS
+	sourceDB, err := dbm.NewGoLevelDB("application", sourceDir, nil)
+	require.NoError(t, err)
+	source, err := openCommitStore(sourceDB, storeKeys, v1StoreKeyPrefixFmt, logger)
+	require.NoError(t, err)
+
+	scDB, err := dbm.NewGoLevelDB("application", scDir, nil)
+	require.NoError(t, err)
+	sc, err := openCommitStore(scDB, storeKeys, "%s", logger)
+	require.NoError(t, err)
+
+	ss, err := openStateStorage("pebble", ssDir, logger)
+	require.NoError(t, err)
+
+	for version := uint64(1); version <= height; version++ {
+		cs := corestore.NewChangeset()
+		for _, storeKey := range storeKeys {
+			cs.Add([]byte(storeKey), []byte(fmt.Sprintf("key-%d", version)), []byte(fmt.Sprintf("value-%d", version)), false)
+		}
+		require.NoError(t, source.WriteChangeset(cs))
+		require.NoError(t, sc.WriteChangeset(cs))
+		_, err = source.Commit(version)
+		require.NoError(t, err)
+		_, err = sc.Commit(version)
+		require.NoError(t, err)
+		require.NoError(t, ss.ApplyChangeset(version, cs))
+	}
+	// the SS is opened by run
+	require.NoError(t, ss.Close())
+	require.NoError(t, scDB.Close())
+
+	args := []string{"-source-dir", sourceDir, "-sc-dir", scDir, "-ss-dir", ssDir, "-height", fmt.Sprint(height)}
+
+	// the commit info of the source is read from the store/v1 keys
+	commitInfo, err := source.GetCommitInfo(height)
+	require.NoError(t, err)
+	require.NoError(t, sourceDB.Close())
+
+	var out bytes.Buffer
+	code, err := run(args, &out, logger)
+	require.ErrorContains(t, err, "no source commit info")
+	require.Equal(t, exitError, code)
+
+	sourceDB, err = dbm.NewGoLevelDB("application", sourceDir, nil)
+	require.NoError(t, err)
+	require.NoError(t, sourceDB.Set([]byte(fmt.Sprintf(v1CommitInfoKeyFmt, height)), encodeV1CommitInfo(commitInfo)))
+	require.NoError(t, sourceDB.Close())
+
+	code, err = run(args, &out, logger)
+	require.NoError(t, err)
+	require.Equal(t, exitOK, code, out.String())
+	require.Equal(t, "bank: OK (5 keys)\nstaking: OK (5 keys)\n", out.String())
+
+	// corrupt the SS
+	ss, err = openStateStorage("pebble", ssDir, logger)
+	require.NoError(t, err)
+	require.NoError(t, ss.ApplyChangeset(height, corestore.NewChangesetWithPairs(
+		map[string]corestore.KVPairs{"staking": {{Key: []byte("key-1"), Remove: true}}},
+	)))
+	require.NoError(t, ss.Close())
+
+	out.Reset()
+	code, err = run(append(args, "-json"), &out, logger)
+	require.NoError(t, err)
+	require.Equal(t, exitMismatch, code)
+	require.Contains(t, out.String(), "state storage key count mismatch: source 5, migrated 4")
+	require.Contains(t, out.String(), fmt.Sprintf(`"source_commit_hash": "%s"`, base64.StdEncoding.EncodeToString(commitInfo.StoreInfos[0].CommitID.Hash)))
+
+	code, err = run([]string{"-source-dir", sourceDir}, &out, logger)
+	require.Error(t, err)
+	require.Equal(t, exitError, code)
+}
+
+// encodeV1CommitInfo encodes a commit info as a store/v1 rootmulti store does.
+func encodeV1CommitInfo(commitInfo *proof.CommitInfo) []byte {
+	bz := protowire.AppendTag(nil, 1, protowire.VarintType)
+	bz = protowire.AppendVarint(bz, commitInfo.Version)
+	for _, si := range commitInfo.StoreInfos {
+		commitID := protowire.AppendTag(nil, 1, protowire.VarintType)
+		commitID = protowire.AppendVarint(commitID, si.CommitID.Version)
+		commitID = protowire.AppendTag(commitID, 2, protowire.BytesType)
+		commitID = protowire.AppendBytes(commitID, si.CommitID.Hash)
+
+		storeInfo := protowire.AppendTag(nil, 1, protowire.BytesType)
+		storeInfo = protowire.AppendBytes(storeInfo, si.Name)
+		storeInfo = protowire.AppendTag(storeInfo, 2, protowire.BytesType)
+		storeInfo = protowire.AppendBytes(storeInfo, commitID)
+
+		bz = protowire.AppendTag(bz, 2, protowire.BytesType)
+		bz = protowire.AppendBytes(bz, storeInfo)
+	}
+	return bz
+}
@@ -48,6 +48,12 @@ type Manager struct {
 
 	chChangeset <-chan *VersionedChangeset
 	chDone      <-chan struct{}
+
+	// verifySource is the original store to verify the migrated state against,
+	// if the verification is enabled
+	verifySource       Source
+	verifyStoreKeys    []string
+	verificationReport *VerificationReport
 }
 
 // NewManager returns a new Manager.
@@ -83,6 +89,21 @@ func (m *Manager) Start(version uint64, chChangeset <-chan *VersionedChangeset,
 		return fmt.Errorf("failed to migrate state: %w", err)
 	}
 
+	if m.verifySource != nil {
+		report, err := m.Verify(version, m.verifySource, m.verifyStoreKeys)
+		if err != nil {
+			return fmt.Errorf("failed to verify migrated state: %w", err)
+		}
+
+		m.mtx.Lock()
+		m.verificationReport = report
+		m.mtx.Unlock()
+
+		if !report.OK() {
+			return fmt.Errorf("migrated state does not match the original state at version %d", version)
+		}
+	}
+
 	return m.Sync()
 }
 
@@ -112,3 +112,81 @@ func TestMigrateState(t *testing.T) {
 		})
 	}
 }
+
+func TestVerify(t *testing.T) {
+	for _, noCommitStore := range []bool{false, true} {
+		t.Run(fmt.Sprintf("Verify noCommitStore=%v", noCommitStore), func(t *testing.T) {
+			m, orgCommitStore := setupMigrationManager(t, noCommitStore)
+
+			toVersion := uint64(10)
+			keyCount := 10
+			for version := uint64(1); version <= toVersion; version++ {
+				cs := corestore.NewChangeset()
+				for _, storeKey := range storeKeys {
+					for i := 0; i < keyCount; i++ {
+						cs.Add([]byte(storeKey), []byte(fmt.Sprintf("key-%d-%d", version, i)), []byte(fmt.Sprintf("value-%d-%d", version, i)), false)
+					}
+				}
+				require.NoError(t, orgCommitStore.WriteChangeset(cs))
+				_, err := orgCommitStore.Commit(version)
+				require.NoError(t, err)
+			}
+
+			// migrate and verify the state with Start
+			m.EnableVerification(orgCommitStore, storeKeys)
+			chChangeset := make(chan *VersionedChangeset)
+			chDone := make(chan struct{})
+			close(chChangeset)
+			close(chDone)
+			require.NoError(t, m.Start(toVersion, chChangeset, chDone))
+
+			report := m.GetVerificationReport()
+			require.NotNil(t, report)
+			require.True(t, report.OK(), report)
+			require.Equal(t, toVersion, report.Height)
+			require.Len(t, report.Stores, len(storeKeys))
+			for i, storeReport := range report.Stores {
+				require.Equal(t, storeKeys[i], storeReport.StoreKey)
+				require.Equal(t, uint64(keyCount)*toVersion, storeReport.Source.KeyCount)
+				require.Equal(t, storeReport.Source, storeReport.StateStorage)
+				if noCommitStore {
+					require.Nil(t, storeReport.StateCommitment)
+					require.Nil(t, storeReport.CommitHash)
+				} else {
+					require.Equal(t, storeReport.Source, *storeReport.StateCommitment)
+					require.NotNil(t, storeReport.CommitHash)
+					require.Equal(t, storeReport.SourceCommitHash, storeReport.CommitHash)
+				}
+			}
+
+			// corrupt the migrated state storage
+			require.NoError(t, m.stateStorage.ApplyChangeset(toVersion, corestore.NewChangesetWithPairs(
+				map[string]corestore.KVPairs{"store1": {{Key: []byte("key-1-0"), Value: []byte("corrupted")}}},
+			)))
+
+			// the stores verified before are checkpointed
+			report, err := m.Verify(toVersion, orgCommitStore, storeKeys)
+			require.NoError(t, err)
+			require.True(t, report.OK())
+
+			// without the checkpoints, the corruption is detected
+			m.db = dbm.NewMemDB()
+			report, err = m.Verify(toVersion, orgCommitStore, storeKeys)
+			require.NoError(t, err)
+			require.False(t, report.OK())
+			require.False(t, report.Stores[0].OK())
+			require.Len(t, report.Stores[0].Mismatches, 1)
+			require.Contains(t, report.Stores[0].Mismatches[0], "state storage hash mismatch")
+			require.True(t, report.Stores[1].OK())
+
+			// the stores with mismatches are not checkpointed
+			report, err = m.Verify(toVersion, orgCommitStore, storeKeys)
+			require.NoError(t, err)
+			require.False(t, report.Stores[0].OK())
+
+			// the source must have a commit info at the verified height
+			_, err = m.Verify(toVersion+1, orgCommitStore, storeKeys)
+			require.ErrorContains(t, err, "no source commit info")
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package migration
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+
+	"cosmossdk.io/store/v2/commitment"
+	"cosmossdk.io/store/v2/proof"
+)
+
+const verifyCheckpointKeyFmt = "m/verify_%x/%s" // m/verify_<version>/<storeKey>
+
+// Source is the original store whose state is migrated. A store/v1 rootmulti
+// store can be verified by opening its IAVL trees in a commitment.CommitStore,
+// as both use the same tree layout.
+//
+// If the source also implements GetCommitInfo(version uint64) (*proof.CommitInfo, error),
+// the root hashes of the trees are compared as well, and the verification fails
+// if the source has no commit info at the verified height.
+type Source interface {
+	// ExportTree returns an exporter of the tree of the given store key at the
+	// given version.
+	ExportTree(storeKey string, version uint64) (commitment.Exporter, error)
+}
+
+// StoreDigest summarizes the key/value pairs of a store at a given version. Two
+// stores containing the same key/value pairs have the same digest.
+type StoreDigest struct {
+	// KeyCount is the number of keys in the store.
+	KeyCount uint64 `json:"key_count"`
+	// Hash is the SHA-256 hash of the length-prefixed keys and values of the
+	// store, in ascending key order.
+	Hash []byte `json:"hash"`
+}
+
+// StoreReport is the verification report of a single store key.
+type StoreReport struct {
+	StoreKey string `json:"store_key"`
+	// Source is the digest of the store in the original store.
+	Source StoreDigest `json:"source"`
+	// StateStorage is the digest of the store in the migrated state storage.
+	StateStorage StoreDigest `json:"state_storage"`
+	// StateCommitment is the digest of the store in the migrated state
+	// commitment, or nil if the state commitment is not migrated.
+	StateCommitment *StoreDigest `json:"state_commitment,omitempty"`
+	// SourceCommitHash and CommitHash are the root hashes of the tree in the
+	// original store and in the migrated state commitment, if both are known.
+	SourceCommitHash []byte `json:"source_commit_hash,omitempty"`
+	CommitHash       []byte `json:"commit_hash,omitempty"`
+	// Mismatches describes the differences between the original and the
+	// migrated store, it is empty if the store was migrated correctly.
+	Mismatches []string `json:"mismatches,omitempty"`
+}
+
+// OK returns true if the store was migrated correctly.
+func (r StoreReport) OK() bool {
+	return len(r.Mismatches) == 0
+}
+
+// VerificationReport is the report produced by Manager.Verify, comparing the
+// original store with the migrated one at the migration height.
+type VerificationReport struct {
+	Height uint64        `json:"height"`
+	Stores []StoreReport `json:"stores"`
+}
+
+// OK returns true if all the stores were migrated correctly.
+func (r *VerificationReport) OK() bool {
+	for _, store := range r.Stores {
+		if !store.OK() {
+			return false
+		}
+	}
+	return true
+}
+
+// EnableVerification makes Start verify the migrated state against the given
+// source once the whole state is migrated, before catching up the Changesets
+// committed in the meantime. If the verification fails, the migration is never
+// completed and the RootStore keeps using the original state commitment.
+func (m *Manager) EnableVerification(source Source, storeKeys []string) {
+	m.verifySource = source
+	m.verifyStoreKeys = storeKeys
+}
+
+// GetVerificationReport returns the report of the verification run by Start, or
+// nil if no verification was run yet.
+func (m *Manager) GetVerificationReport() *VerificationReport {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	return m.verificationReport
+}
+
+// Verify compares the state of the given store keys in the source with the
+// migrated state storage and state commitment at the given height, and returns
+// a report of the differences, if any. It returns an error only if the
+// comparison could not be performed. If no store keys are given, the store keys
+// of the migrated state commitment are verified.
+//
+// The report of each store is checkpointed in the migration db once it is
+// verified without mismatches, so that if Verify is interrupted, e.g. by a
+// crash, calling it again only verifies the remaining stores.
+func (m *Manager) Verify(height uint64, source Source, storeKeys []string) (*VerificationReport, error) {
+	var sourceCommitInfo *proof.CommitInfo
+	if getter, ok := source.(interface {
+		GetCommitInfo(version uint64) (*proof.CommitInfo, error)
+	}); ok {
+		var err error
+		if sourceCommitInfo, err = getter.GetCommitInfo(height); err != nil {
+			return nil, fmt.Errorf("failed to get source commit info: %w", err)
+		}
+		if sourceCommitInfo == nil {
+			return nil, fmt.Errorf("no source commit info found for version %d", height)
+		}
+	}
+
+	var commitInfo *proof.CommitInfo
+	if m.stateCommitment != nil {
+		var err error
+		if commitInfo, err = m.stateCommitment.GetCommitInfo(height); err != nil {
+			return nil, fmt.Errorf("failed to get commit info: %w", err)
+		}
+	}
+
+	if len(storeKeys) == 0 && commitInfo != nil {
+		for _, si := range commitInfo.StoreInfos {
+			storeKeys = append(storeKeys, string(si.Name))
+		}
+	}
+
+	report := &VerificationReport{Height: height}
+	for _, storeKey := range storeKeys {
+		checkpointKey := []byte(fmt.Sprintf(verifyCheckpointKeyFmt, encodeVersion(height), storeKey))
+		bz, err := m.db.Get(checkpointKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get verification checkpoint: %w", err)
+		}
+
+		var storeReport StoreReport
+		if bz != nil {
+			if err := json.Unmarshal(bz, &storeReport); err != nil {
+				return nil, fmt.Errorf("failed to unmarshal verification checkpoint: %w", err)
+			}
+			report.Stores = append(report.Stores, storeReport)
+			continue
+		}
+
+		storeReport, err = m.verifyStore(height, source, storeKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify store %s: %w", storeKey, err)
+		}
+		storeReport.compareCommitHashes(sourceCommitInfo, commitInfo)
+
+		// the stores with mismatches are verified again on the next run
+		if storeReport.OK() {
+			if bz, err = json.Marshal(storeReport); err != nil {
+				return nil, fmt.Errorf("failed to marshal verification checkpoint: %w", err)
+			}
+			if err := m.db.Set(checkpointKey, bz); err != nil {
+				return nil, fmt.Errorf("failed to write verification checkpoint: %w", err)
+			}
+		}
+
+		m.logger.Info("verified migrated store", "store_key", storeKey, "ok", storeReport.OK())
+		report.Stores = append(report.Stores, storeReport)
+	}
+
+	return report, nil
+}
+
+func (m *Manager) verifyStore(height uint64, source Source, storeKey string) (StoreReport, error) {
+	report := StoreReport{StoreKey: storeKey}
+
+	var err error
+	if report.Source, err = treeDigest(source, storeKey, height); err != nil {
+		return report, fmt.Errorf("failed to export source tree: %w", err)
+	}
+
+	if report.StateStorage, err = m.storageDigest(storeKey, height); err != nil {
+		return report, fmt.Errorf("failed to iterate state storage: %w", err)
+	}
+	report.compareDigests("state storage", report.StateStorage)
+
+	if m.stateCommitment != nil {
+		digest, err := treeDigest(m.stateCommitment, storeKey, height)
+		if err != nil {
+			return report, fmt.Errorf("failed to export state commitment tree: %w", err)
+		}
+		report.StateCommitment = &digest
+		report.compareDigests("state commitment", digest)
+	}
+
+	return report, nil
+}
+
+func (r *StoreReport) compareDigests(name string, digest StoreDigest) {
+	if r.Source.KeyCount != digest.KeyCount {
+		r.Mismatches = append(r.Mismatches, fmt.Sprintf("%s key count mismatch: source %d, migrated %d", name, r.Source.KeyCount, digest.KeyCount))
+	}
+	if !bytes.Equal(r.Source.Hash, digest.Hash) {
+		r.Mismatches = append(r.Mismatches, fmt.Sprintf("%s hash mismatch: source %X, migrated %X", name, r.Source.Hash, digest.Hash))
+	}
+}
+
+func (r *StoreReport) compareCommitHashes(sourceCommitInfo, commitInfo *proof.CommitInfo) {
+	if sourceCommitInfo == nil || commitInfo == nil {
+		return
+	}
+
+	r.SourceCommitHash = storeCommitHash(sourceCommitInfo, r.StoreKey)
+	r.CommitHash = storeCommitHash(commitInfo, r.StoreKey)
+	if !bytes.Equal(r.SourceCommitHash, r.CommitHash) {
+		r.Mismatches = append(r.Mismatches, fmt.Sprintf("commit hash mismatch: source %X, migrated %X", r.SourceCommitHash, r.CommitHash))
+	}
+}
+
+func encodeVersion(version uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, version)
+	return buf
+}
+
+func storeCommitHash(commitInfo *proof.CommitInfo, storeKey string) []byte {
+	for _, si := range commitInfo.StoreInfos {
+		if string(si.Name) == storeKey {
+			return si.CommitID.Hash
+		}
+	}
+	return nil
+}
+
+// treeDigest computes the digest of the leaves of the exported tree.
+func treeDigest(source Source, storeKey string, height uint64) (StoreDigest, error) {
+	exporter, err := source.ExportTree(storeKey, height)
+	if err != nil {
+		return StoreDigest{}, err
+	}
+	defer exporter.Close()
+
+	d := newDigester()
+	for {
+		item, err := exporter.Next()
+		if errors.Is(err, commitment.ErrorExportDone) {
+			break
+		} else if err != nil {
+			return StoreDigest{}, err
+		}
+
+		// only the leaves hold key/value pairs, and they are exported in
+		// ascending key order
+		if item.Height == 0 {
+			d.add(item.Key, item.Value)
+		}
+	}
+
+	return d.digest(), nil
+}
+
+// storageDigest computes the digest of the store in the state storage.
+func (m *Manager) storageDigest(storeKey string, height uint64) (StoreDigest, error) {
+	itr, err := m.stateStorage.Iterator([]byte(storeKey), height, nil, nil)
+	if err != nil {
+		return StoreDigest{}, err
+	}
+	defer itr.Close()
+
+	d := newDigester()
+	for ; itr.Valid(); itr.Next() {
+		d.add(itr.Key(), itr.Value())
+	}
+
+	return d.digest(), itr.Error()
+}
+
+type digester struct {
+	hash  hash.Hash
+	count uint64
+	buf   []byte
+}
+
+func newDigester() *digester {
+	return &digester{hash: sha256.New()}
+}
+
+func (d *digester) add(key, value []byte) {
+	d.buf = binary.AppendUvarint(d.buf[:0], uint64(len(key)))
+	d.buf = append(d.buf, key...)
+	d.buf = binary.AppendUvarint(d.buf, uint64(len(value)))
+	d.buf = append(d.buf, value...)
+	d.hash.Write(d.buf)
+	d.count++
+}
+
+func (d *digester) digest() StoreDigest {
+	return StoreDigest{KeyCount: d.count, Hash: d.hash.Sum(nil)}
+}
@@ -80,6 +80,35 @@ func NewSMTCommitmentOp(key []byte, proof *ics23.CommitmentProof) CommitmentOp {
 	}
 }
 
+// NewCommitmentOp returns the CommitmentOp of the given proof type.
+func NewCommitmentOp(typ string, key []byte, proof *ics23.CommitmentProof) (CommitmentOp, error) {
+	spec, err := proofSpec(typ)
+	if err != nil {
+		return CommitmentOp{}, err
+	}
+
+	return CommitmentOp{
+		Type:  typ,
+		Spec:  spec,
+		Key:   key,
+		Proof: proof,
+	}, nil
+}
+
+// proofSpec returns the ics23 spec of the given proof type.
+func proofSpec(typ string) (*ics23.ProofSpec, error) {
+	switch typ {
+	case ProofOpIAVLCommitment:
+		return ics23.IavlSpec, nil
+	case ProofOpSimpleMerkleCommitment:
+		return SimpleMerkleSpec, nil
+	case ProofOpSMTCommitment:
+		return ics23.SmtSpec, nil
+	default:
+		return nil, errors.Wrapf(storeerrors.ErrInvalidProof, "unknown proof type %s", typ)
+	}
+}
+
 func (op CommitmentOp) GetKey() []byte {
 	return op.Key
 }
@@ -0,0 +1,252 @@
+package proof
+
+import (
+	"bytes"
+	"fmt"
+
+	ics23 "github.com/cosmos/ics23/go"
+
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/errors"
+	storeerrors "cosmossdk.io/store/v2/errors"
+)
+
+// ProofGetter returns the ics23 proof of the existence or absence of a key in a
+// store tree.
+type ProofGetter func(key []byte) (*ics23.CommitmentProof, error)
+
+// MultiProof proves the values, or the absence, of several keys of a store
+// against the app hash with a single batch proof.
+type MultiProof struct {
+	// Type is the proof operation type of the store tree, e.g. ProofOpIAVLCommitment.
+	Type string
+	// Proof is the compressed ics23 batch proof of the keys against the root hash
+	// of the store tree.
+	Proof *ics23.CommitmentProof
+	// StoreProof proves the root hash of the store tree against the app hash.
+	StoreProof CommitmentOp
+}
+
+// NewMultiProof returns the proof of the given keys, using get to retrieve the
+// proof of each key in the store tree.
+func NewMultiProof(typ string, keys [][]byte, get ProofGetter, storeProof CommitmentOp) (*MultiProof, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("no keys to prove")
+	}
+
+	proofs := make([]*ics23.CommitmentProof, len(keys))
+	for i, key := range keys {
+		p, err := get(key)
+		if err != nil {
+			return nil, err
+		}
+		proofs[i] = p
+	}
+
+	batch, err := ics23.CombineProofs(proofs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to combine proofs: %w", err)
+	}
+
+	return &MultiProof{
+		Type:       typ,
+		Proof:      ics23.Compress(batch),
+		StoreProof: storeProof,
+	}, nil
+}
+
+// Verify verifies that the given pairs of the store are committed in the app
+// hash. A pair with a nil value asserts the absence of its key.
+func (p *MultiProof) Verify(appHash, storeKey []byte, pairs []corestore.KVPair) error {
+	spec, err := proofSpec(p.Type)
+	if err != nil {
+		return err
+	}
+
+	root, err := p.Proof.Calculate()
+	if err != nil {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "could not calculate root for proof: %v", err)
+	}
+
+	for _, pair := range pairs {
+		if pair.Value == nil {
+			if !ics23.VerifyNonMembership(spec, root, p.Proof, pair.Key) {
+				return errors.Wrapf(storeerrors.ErrInvalidProof, "proof did not verify absence of key: %X", pair.Key)
+			}
+		} else if !ics23.VerifyMembership(spec, root, p.Proof, pair.Key, pair.Value) {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "proof did not verify existence of key %X with given value %X", pair.Key, pair.Value)
+		}
+	}
+
+	return verifyStoreProof(p.StoreProof, appHash, storeKey, root)
+}
+
+// RangeProof proves the complete set of key/value pairs of a store in the range
+// [Start, End) against the app hash, i.e. that no key of the range is omitted.
+//
+// The keys of the range are proven to be adjacent in the store tree, and the
+// range is bounded by the proofs of its neighbors: Left, the greatest key before
+// Start, and Right, the smallest key from End. A missing neighbor means that the
+// range starts at Start, or respectively that there is no key from End, which is
+// proven by the position of the first, or last, key in the tree.
+//
+// Paginated ranges are proven by setting End to the first key of the next page.
+type RangeProof struct {
+	// Type is the proof operation type of the store tree, e.g. ProofOpIAVLCommitment.
+	Type string
+	// Start is the inclusive start of the range, nil meaning the first key of the store.
+	Start []byte
+	// End is the exclusive end of the range, nil meaning after the last key of the store.
+	End []byte
+	// Left is the existence proof of the greatest key before Start, if any and
+	// if Start is not itself in the store.
+	Left *ics23.ExistenceProof
+	// Entries are the existence proofs of the keys of the range, in ascending order.
+	Entries []*ics23.ExistenceProof
+	// Right is the existence proof of the smallest key from End, if any.
+	Right *ics23.ExistenceProof
+	// StoreProof proves the root hash of the store tree against the app hash.
+	StoreProof CommitmentOp
+}
+
+// NewRangeProof returns the proof of the range [start, end) of a store, given
+// the keys of the range in ascending order, using get to retrieve the proof of
+// each key in the store tree.
+func NewRangeProof(typ string, start, end []byte, keys [][]byte, get ProofGetter, storeProof CommitmentOp) (*RangeProof, error) {
+	p := &RangeProof{
+		Type:       typ,
+		Start:      start,
+		End:        end,
+		Entries:    make([]*ics23.ExistenceProof, len(keys)),
+		StoreProof: storeProof,
+	}
+
+	for i, key := range keys {
+		cp, err := get(key)
+		if err != nil {
+			return nil, err
+		}
+		if p.Entries[i] = cp.GetExist(); p.Entries[i] == nil {
+			return nil, fmt.Errorf("key %X of the range does not exist in the store tree", key)
+		}
+	}
+
+	if start != nil && (len(keys) == 0 || !bytes.Equal(keys[0], start)) {
+		cp, err := get(start)
+		if err != nil {
+			return nil, err
+		}
+		nonexist := cp.GetNonexist()
+		if nonexist == nil {
+			return nil, fmt.Errorf("start key %X exists in the store tree but not in the range", start)
+		}
+		p.Left = nonexist.Left
+	}
+
+	if end != nil {
+		cp, err := get(end)
+		if err != nil {
+			return nil, err
+		}
+		if p.Right = cp.GetExist(); p.Right == nil {
+			p.Right = cp.GetNonexist().GetRight()
+		}
+	}
+
+	return p, nil
+}
+
+// Verify verifies that the given pairs, in ascending key order, are all the
+// key/value pairs of the store in the range [p.Start, p.End) committed in the
+// app hash.
+func (p *RangeProof) Verify(appHash, storeKey []byte, pairs []corestore.KVPair) error {
+	spec, err := proofSpec(p.Type)
+	if err != nil {
+		return err
+	}
+
+	if spec.PrehashKeyBeforeComparison {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "range proofs are not supported by %s trees, whose keys are ordered by hash", p.Type)
+	}
+
+	if len(pairs) != len(p.Entries) {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "expected %d pairs, got %d", len(p.Entries), len(pairs))
+	}
+
+	// all the proofs, in ascending key order
+	seq := make([]*ics23.ExistenceProof, 0, len(p.Entries)+2)
+	if p.Left != nil {
+		seq = append(seq, p.Left)
+	}
+	seq = append(seq, p.Entries...)
+	if p.Right != nil {
+		seq = append(seq, p.Right)
+	}
+
+	// an empty sequence is only valid for an empty store
+	root := emptyHash()
+	if len(seq) > 0 {
+		if root, err = seq[0].Calculate(); err != nil {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "could not calculate root for proof: %v", err)
+		}
+	}
+
+	for i, pair := range pairs {
+		entry := p.Entries[i]
+		if !bytes.Equal(entry.Key, pair.Key) {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "expected key %X, got %X", entry.Key, pair.Key)
+		}
+		if bytes.Compare(pair.Key, p.Start) < 0 || (p.End != nil && bytes.Compare(pair.Key, p.End) >= 0) {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "key %X is out of range", pair.Key)
+		}
+		if err := entry.Verify(spec, root, pair.Key, pair.Value); err != nil {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "proof did not verify existence of key %X with given value %X: %v", pair.Key, pair.Value, err)
+		}
+	}
+
+	if p.Left != nil {
+		if p.Start == nil || bytes.Compare(p.Left.Key, p.Start) >= 0 {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "left key %X is not before the start of the range", p.Left.Key)
+		}
+		if err := p.Left.Verify(spec, root, p.Left.Key, p.Left.Value); err != nil {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "invalid left proof: %v", err)
+		}
+	} else if len(seq) > 0 && !bytes.Equal(seq[0].Key, p.Start) && !ics23.IsLeftMost(spec.InnerSpec, seq[0].Path) {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "missing keys before %X", seq[0].Key)
+	}
+
+	if p.Right != nil {
+		if p.End == nil || bytes.Compare(p.Right.Key, p.End) < 0 {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "right key %X is not after the end of the range", p.Right.Key)
+		}
+		if err := p.Right.Verify(spec, root, p.Right.Key, p.Right.Value); err != nil {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "invalid right proof: %v", err)
+		}
+	} else if len(seq) > 0 && !ics23.IsRightMost(spec.InnerSpec, seq[len(seq)-1].Path) {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "missing keys after %X", seq[len(seq)-1].Key)
+	}
+
+	for i := 1; i < len(seq); i++ {
+		if !ics23.IsLeftNeighbor(spec.InnerSpec, seq[i-1].Path, seq[i].Path) {
+			return errors.Wrapf(storeerrors.ErrInvalidProof, "missing keys between %X and %X", seq[i-1].Key, seq[i].Key)
+		}
+	}
+
+	return verifyStoreProof(p.StoreProof, appHash, storeKey, root)
+}
+
+func verifyStoreProof(storeProof CommitmentOp, appHash, storeKey, root []byte) error {
+	if !bytes.Equal(storeProof.Key, storeKey) {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "store proof is for store %s, not %s", storeProof.Key, storeKey)
+	}
+
+	roots, err := storeProof.Run([][]byte{root})
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(roots[0], appHash) {
+		return errors.Wrapf(storeerrors.ErrInvalidProof, "calculated app hash %X does not match %X", roots[0], appHash)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package proof
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	corestore "cosmossdk.io/core/store"
+)
+
+func TestRangeProofEmptyStore(t *testing.T) {
+	ci := &CommitInfo{
+		Version: 1,
+		StoreInfos: []StoreInfo{
+			{[]byte("empty"), CommitID{1, emptyHash()}, "iavl"},
+			{[]byte("other"), CommitID{1, []byte("hash")}, "iavl"},
+		},
+	}
+	appHash := ci.Hash()
+	_, storeProof, err := ci.GetStoreProof([]byte("empty"))
+	require.NoError(t, err)
+
+	p := &RangeProof{Type: ProofOpIAVLCommitment, StoreProof: *storeProof}
+	require.NoError(t, p.Verify(appHash, []byte("empty"), nil))
+	require.Error(t, p.Verify(appHash, []byte("other"), nil))
+	require.Error(t, p.Verify(appHash, []byte("empty"), []corestore.KVPair{{Key: []byte("key"), Value: []byte("value")}}))
+
+	// the empty proof of a store which is not empty must not verify
+	_, storeProof, err = ci.GetStoreProof([]byte("other"))
+	require.NoError(t, err)
+	p.StoreProof = *storeProof
+	require.Error(t, p.Verify(appHash, []byte("other"), nil))
+
+	p.Type = "unknown"
+	require.Error(t, p.Verify(appHash, []byte("other"), nil))
+}
+
+func TestNewMultiProofNoKeys(t *testing.T) {
+	_, err := NewMultiProof(ProofOpIAVLCommitment, nil, nil, CommitmentOp{})
+	require.Error(t, err)
+}
@@ -0,0 +1,230 @@
+package replay
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"cosmossdk.io/schema"
+	"cosmossdk.io/schema/appdata"
+	"cosmossdk.io/store/v2"
+)
+
+// Options defines the options for replaying the historical state of a
+// VersionedDatabase into an appdata.Listener.
+type Options struct {
+	// StoreKeys are the store keys to replay. The store key is used as the module
+	// name for the listener callbacks.
+	StoreKeys []string
+
+	// ModuleCodecs are the optional codecs used to decode the KV-pairs of a store
+	// key into object updates. Store keys without a codec only receive OnKVPair
+	// callbacks.
+	ModuleCodecs map[string]schema.ModuleCodec
+
+	// FromVersion is the first version to replay if the listener has not persisted
+	// any block yet. The whole state at FromVersion is emitted as the changes of
+	// the first replayed block. If it is 0, the replay starts from version 1.
+	FromVersion uint64
+
+	// ToVersion is the last version to replay. If it is 0, the replay stops at the
+	// latest version of the database.
+	ToVersion uint64
+}
+
+// Replay walks the versions of the database and emits the state changes of each
+// version to the listener, so that a listener attached late can catch up with the
+// historical state stored locally.
+//
+// The listener is initialized first and the lastBlockPersisted it returns is
+// honored: if it is positive, the replay resumes from the next version and the
+// state is diffed against the last persisted version. Each replayed version
+// results in a StartBlock, the OnKVPair (and OnObjectUpdate for the store keys
+// with a codec) callbacks of the keys which were set or removed at that version
+// and a final Commit.
+//
+// NOTE: The storage backends do not index the changes of a version, so each
+// version is computed by diffing the whole state of the store keys against the
+// previous version. The versions to replay must not have been pruned.
+func Replay(ctx context.Context, db store.VersionedDatabase, listener appdata.Listener, opts Options) error {
+	var lastBlockPersisted int64
+	if listener.Initialize != nil {
+		var err error
+		lastBlockPersisted, err = listener.Initialize(appdata.InitializationData{})
+		if err != nil {
+			return fmt.Errorf("failed to initialize listener: %w", err)
+		}
+	}
+
+	if listener.InitializeModuleSchema != nil {
+		// initialize the modules in a deterministic order
+		moduleNames := make([]string, 0, len(opts.ModuleCodecs))
+		for moduleName := range opts.ModuleCodecs {
+			moduleNames = append(moduleNames, moduleName)
+		}
+		sort.Strings(moduleNames)
+
+		for _, moduleName := range moduleNames {
+			if err := listener.InitializeModuleSchema(moduleName, opts.ModuleCodecs[moduleName].Schema); err != nil {
+				return fmt.Errorf("failed to initialize schema for module %s: %w", moduleName, err)
+			}
+		}
+	}
+
+	toVersion := opts.ToVersion
+	if toVersion == 0 {
+		latestVersion, err := db.GetLatestVersion()
+		if err != nil {
+			return fmt.Errorf("failed to get latest version: %w", err)
+		}
+		toVersion = latestVersion
+	}
+
+	fromVersion := opts.FromVersion
+	if fromVersion == 0 {
+		fromVersion = 1
+	}
+
+	// prevVersion is the version the first replayed version is diffed against,
+	// 0 meaning that the whole state is emitted.
+	var prevVersion uint64
+	if lastBlockPersisted > 0 {
+		prevVersion = uint64(lastBlockPersisted)
+		if prevVersion+1 > fromVersion {
+			fromVersion = prevVersion + 1
+		}
+	}
+
+	for version := fromVersion; version <= toVersion; version++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if err := replayVersion(db, listener, opts, prevVersion, version); err != nil {
+			return fmt.Errorf("failed to replay version %d: %w", version, err)
+		}
+
+		prevVersion = version
+	}
+
+	return nil
+}
+
+func replayVersion(db store.VersionedDatabase, listener appdata.Listener, opts Options, prevVersion, version uint64) error {
+	if listener.StartBlock != nil {
+		if err := listener.StartBlock(version); err != nil {
+			return err
+		}
+	}
+
+	for _, storeKey := range opts.StoreKeys {
+		codec, hasCodec := opts.ModuleCodecs[storeKey]
+		err := diffVersions(db, []byte(storeKey), prevVersion, version, func(key, value []byte, remove bool) error {
+			if listener.OnKVPair != nil {
+				if err := listener.OnKVPair(storeKey, key, value, remove); err != nil {
+					return err
+				}
+			}
+
+			if !hasCodec || codec.KVDecoder == nil || listener.OnObjectUpdate == nil {
+				return nil
+			}
+
+			update, ok, err := codec.KVDecoder(key, value)
+			if err != nil {
+				return err
+			}
+			if !ok {
+				return nil
+			}
+
+			return listener.OnObjectUpdate(storeKey, update)
+		})
+		if err != nil {
+			return fmt.Errorf("store key %s: %w", storeKey, err)
+		}
+	}
+
+	if listener.Commit != nil {
+		return listener.Commit()
+	}
+
+	return nil
+}
+
+// diffVersions calls fn, in key order, for each key of the store key whose value
+// differs between prevVersion and version. Removed keys are passed with a nil value.
+// If prevVersion is 0, every key existing at version is passed.
+func diffVersions(db store.VersionedDatabase, storeKey []byte, prevVersion, version uint64, fn func(key, value []byte, remove bool) error) (err error) {
+	cur, err := db.Iterator(storeKey, version, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, cur.Close())
+	}()
+
+	if prevVersion == 0 {
+		for ; cur.Valid(); cur.Next() {
+			if err := fn(cur.Key(), cur.Value(), false); err != nil {
+				return err
+			}
+		}
+		return cur.Error()
+	}
+
+	prev, err := db.Iterator(storeKey, prevVersion, nil, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		err = errors.Join(err, prev.Close())
+	}()
+
+	for cur.Valid() || prev.Valid() {
+		switch {
+		case !prev.Valid():
+			if err := fn(cur.Key(), cur.Value(), false); err != nil {
+				return err
+			}
+			cur.Next()
+
+		case !cur.Valid():
+			if err := fn(prev.Key(), nil, true); err != nil {
+				return err
+			}
+			prev.Next()
+
+		default:
+			switch c := bytes.Compare(cur.Key(), prev.Key()); {
+			case c < 0:
+				if err := fn(cur.Key(), cur.Value(), false); err != nil {
+					return err
+				}
+				cur.Next()
+
+			case c > 0:
+				if err := fn(prev.Key(), nil, true); err != nil {
+					return err
+				}
+				prev.Next()
+
+			default:
+				if value := cur.Value(); !bytes.Equal(value, prev.Value()) {
+					if err := fn(cur.Key(), value, false); err != nil {
+						return err
+					}
+				}
+				cur.Next()
+				prev.Next()
+			}
+		}
+	}
+
+	if err := cur.Error(); err != nil {
+		return err
+	}
+	return prev.Error()
+}
@@ -0,0 +1,152 @@
+package replay
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"cosmossdk.io/core/log"
+	corestore "cosmossdk.io/core/store"
+	"cosmossdk.io/schema"
+	"cosmossdk.io/schema/appdata"
+	"cosmossdk.io/store/v2/storage"
+	"cosmossdk.io/store/v2/storage/pebbledb"
+)
+
+var (
+	bankKey    = []byte("bank")
+	stakingKey = []byte("staking")
+)
+
+func newTestDB(t *testing.T) *storage.StorageStore {
+	t.Helper()
+
+	db, err := pebbledb.New(t.TempDir())
+	require.NoError(t, err)
+	db.SetSync(false)
+	ss := storage.NewStorageStore(db, log.NewNopLogger())
+	t.Cleanup(func() { require.NoError(t, ss.Close()) })
+
+	changesets := []*corestore.Changeset{
+		corestore.NewChangesetWithPairs(map[string]corestore.KVPairs{
+			string(bankKey):    {{Key: []byte("a"), Value: []byte("1")}, {Key: []byte("b"), Value: []byte("1")}},
+			string(stakingKey): {{Key: []byte("x"), Value: []byte("1")}},
+		}),
+		corestore.NewChangesetWithPairs(map[string]corestore.KVPairs{
+			string(bankKey): {{Key: []byte("a"), Value: []byte("2")}, {Key: []byte("b"), Remove: true}, {Key: []byte("c"), Value: []byte("1")}},
+		}),
+		corestore.NewChangesetWithPairs(map[string]corestore.KVPairs{
+			string(stakingKey): {{Key: []byte("x"), Remove: true}},
+		}),
+	}
+	for i, cs := range changesets {
+		require.NoError(t, ss.ApplyChangeset(uint64(i+1), cs))
+	}
+	require.NoError(t, ss.SetLatestVersion(uint64(len(changesets))))
+
+	return ss
+}
+
+type testListener struct {
+	lastBlockPersisted int64
+	events             []string
+}
+
+func (l *testListener) listener() appdata.Listener {
+	return appdata.Listener{
+		Initialize: func(appdata.InitializationData) (int64, error) {
+			return l.lastBlockPersisted, nil
+		},
+		InitializeModuleSchema: func(moduleName string, _ schema.ModuleSchema) error {
+			l.events = append(l.events, "schema "+moduleName)
+			return nil
+		},
+		StartBlock: func(height uint64) error {
+			l.events = append(l.events, fmt.Sprintf("start %d", height))
+			return nil
+		},
+		OnKVPair: func(moduleName string, key, value []byte, remove bool) error {
+			l.events = append(l.events, fmt.Sprintf("kv %s %s=%s %t", moduleName, key, value, remove))
+			return nil
+		},
+		OnObjectUpdate: func(moduleName string, update schema.ObjectUpdate) error {
+			l.events = append(l.events, fmt.Sprintf("object %s %v=%v %t", moduleName, update.Key, update.Value, update.Delete))
+			return nil
+		},
+		Commit: func() error {
+			l.events = append(l.events, "commit")
+			return nil
+		},
+	}
+}
+
+func TestReplay(t *testing.T) {
+	db := newTestDB(t)
+
+	bankCodec := schema.ModuleCodec{
+		Schema: schema.ModuleSchema{ObjectTypes: []schema.ObjectType{{
+			Name:        "balances",
+			KeyFields:   []schema.Field{{Name: "key", Kind: schema.StringKind}},
+			ValueFields: []schema.Field{{Name: "value", Kind: schema.StringKind}},
+		}}},
+		KVDecoder: func(key, value []byte) (schema.ObjectUpdate, bool, error) {
+			if value == nil {
+				return schema.ObjectUpdate{TypeName: "balances", Key: string(key), Delete: true}, true, nil
+			}
+			return schema.ObjectUpdate{TypeName: "balances", Key: string(key), Value: string(value)}, true, nil
+		},
+	}
+	opts := Options{
+		StoreKeys:    []string{"bank", "staking"},
+		ModuleCodecs: map[string]schema.ModuleCodec{"bank": bankCodec},
+	}
+
+	l := &testListener{lastBlockPersisted: -1}
+	require.NoError(t, Replay(context.Background(), db, l.listener(), opts))
+	require.Equal(t, []string{
+		"schema bank",
+		"start 1",
+		"kv bank a=1 false",
+		"object bank a=1 false",
+		"kv bank b=1 false",
+		"object bank b=1 false",
+		"kv staking x=1 false",
+		"commit",
+		"start 2",
+		"kv bank a=2 false",
+		"object bank a=2 false",
+		"kv bank b= true",
+		"object bank b=<nil> true",
+		"kv bank c=1 false",
+		"object bank c=1 false",
+		"commit",
+		"start 3",
+		"kv staking x= true",
+		"commit",
+	}, l.events)
+
+	// the replay resumes after the last block persisted by the listener
+	l = &testListener{lastBlockPersisted: 2}
+	require.NoError(t, Replay(context.Background(), db, l.listener(), Options{StoreKeys: []string{"bank", "staking"}}))
+	require.Equal(t, []string{
+		"start 3",
+		"kv staking x= true",
+		"commit",
+	}, l.events)
+
+	// the whole state is emitted for the first replayed version
+	l = &testListener{}
+	require.NoError(t, Replay(context.Background(), db, l.listener(), Options{StoreKeys: []string{"bank"}, FromVersion: 2, ToVersion: 2}))
+	require.Equal(t, []string{
+		"start 2",
+		"kv bank a=2 false",
+		"kv bank c=1 false",
+		"commit",
+	}, l.events)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.ErrorIs(t, Replay(ctx, db, (&testListener{}).listener(), opts), context.Canceled)
+}
@@ -10,6 +10,7 @@ import (
 	"cosmossdk.io/store/v2/commitment"
 	"cosmossdk.io/store/v2/commitment/iavl"
 	"cosmossdk.io/store/v2/commitment/mem"
+	"cosmossdk.io/store/v2/commitment/smt"
 	"cosmossdk.io/store/v2/db"
 	"cosmossdk.io/store/v2/internal"
 	"cosmossdk.io/store/v2/pruning"
@@ -29,6 +30,7 @@ const (
 	SSTypeRocks  SSType = 2
 	SCTypeIavl   SCType = 0
 	SCTypeIavlV2 SCType = 1
+	SCTypeSMT    SCType = 2
 )
 
 type FactoryOptions struct {
@@ -111,6 +113,8 @@ func CreateRootStore(opts *FactoryOptions) (store.RootStore, error) {
 				trees[key] = iavl.NewIavlTree(db.NewPrefixDB(opts.SCRawDB, []byte(key)), opts.Logger, opts.IavlConfig)
 			case SCTypeIavlV2:
 				return nil, fmt.Errorf("iavl v2 not supported")
+			case SCTypeSMT:
+				trees[key] = smt.NewTree(db.NewPrefixDB(opts.SCRawDB, []byte(key)))
 			}
 		}
 	}
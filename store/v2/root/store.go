@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	ics23 "github.com/cosmos/ics23/go"
 	"golang.org/x/sync/errgroup"
 
 	coreheader "cosmossdk.io/core/header"
@@ -100,6 +101,11 @@ func (s *Store) Close() (err error) {
 
 func (s *Store) SetMetrics(m metrics.Metrics) {
 	s.telemetry = m
+
+	// propagate the metrics to the SC backend, e.g. to measure each tree commit
+	if sc, ok := s.stateCommitment.(interface{ SetMetrics(metrics.StoreMetrics) }); ok {
+		sc.SetMetrics(m)
+	}
 }
 
 func (s *Store) SetInitialVersion(v uint64) error {
@@ -137,6 +143,16 @@ func (s *Store) GetStateCommitment() store.Committer {
 	return s.stateCommitment
 }
 
+// GetChangeset returns the changes committed at the given version, if the SC
+// backend implements store.ChangesetReader.
+func (s *Store) GetChangeset(version uint64) (*corestore.Changeset, error) {
+	reader, ok := s.stateCommitment.(store.ChangesetReader)
+	if !ok {
+		return nil, errors.New("the SC backend does not keep the changes of its versions")
+	}
+	return reader.GetChangeset(version)
+}
+
 // LastCommitID returns a CommitID based off of the latest internal CommitInfo.
 // If an internal CommitInfo is not set, a new one will be returned with only the
 // latest version set, which is based off of the SC view.
@@ -214,6 +230,119 @@ func (s *Store) Query(storeKey []byte, version uint64, key []byte, prove bool) (
 	return result, nil
 }
 
+func (s *Store) QueryKeys(storeKey []byte, version uint64, keys [][]byte, prove bool) (store.MultiQueryResult, error) {
+	if s.telemetry != nil {
+		now := time.Now()
+		defer s.telemetry.MeasureSince(now, "root_store", "query_keys")
+	}
+
+	result := store.MultiQueryResult{
+		Pairs:   make([]corestore.KVPair, len(keys)),
+		Version: version,
+	}
+	for i, key := range keys {
+		res, err := s.Query(storeKey, version, key, false)
+		if err != nil {
+			return store.MultiQueryResult{}, err
+		}
+		result.Pairs[i] = corestore.KVPair{Key: key, Value: res.Value}
+	}
+
+	if prove && len(keys) > 0 {
+		typ, get, storeProof, err := s.proofGetter(storeKey, version, keys[0])
+		if err != nil {
+			return store.MultiQueryResult{}, err
+		}
+		result.Proof, err = proof.NewMultiProof(typ, keys, get, storeProof)
+		if err != nil {
+			return store.MultiQueryResult{}, fmt.Errorf("failed to get SC store multi proof: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+func (s *Store) QueryRange(storeKey []byte, version uint64, start, end []byte, limit int, prove bool) (store.RangeQueryResult, error) {
+	if s.telemetry != nil {
+		now := time.Now()
+		defer s.telemetry.MeasureSince(now, "root_store", "query_range")
+	}
+
+	if s.isMigrating {
+		return store.RangeQueryResult{}, errors.New("range queries are not supported while migrating")
+	}
+
+	itr, err := s.stateStorage.Iterator(storeKey, version, start, end)
+	if err != nil {
+		return store.RangeQueryResult{}, fmt.Errorf("failed to query SS store: %w", err)
+	}
+	defer itr.Close()
+
+	result := store.RangeQueryResult{Version: version}
+	for ; itr.Valid(); itr.Next() {
+		if limit > 0 && len(result.Pairs) == limit {
+			// the range continues with the next page
+			result.NextKey = bytes.Clone(itr.Key())
+			break
+		}
+		result.Pairs = append(result.Pairs, corestore.KVPair{Key: bytes.Clone(itr.Key()), Value: bytes.Clone(itr.Value())})
+	}
+	if err := itr.Error(); err != nil {
+		return store.RangeQueryResult{}, fmt.Errorf("failed to query SS store: %w", err)
+	}
+
+	if prove {
+		keys := make([][]byte, len(result.Pairs))
+		for i, pair := range result.Pairs {
+			keys[i] = pair.Key
+		}
+		// a page of the range is proven as the range ending at the next page
+		if result.NextKey != nil {
+			end = result.NextKey
+		}
+
+		// any key of the store can be used to retrieve the proof of the store
+		// root against the commit info
+		proofKey := start
+		if proofKey == nil {
+			proofKey = []byte{}
+		}
+		typ, get, storeProof, err := s.proofGetter(storeKey, version, proofKey)
+		if err != nil {
+			return store.RangeQueryResult{}, err
+		}
+		result.Proof, err = proof.NewRangeProof(typ, start, end, keys, get, storeProof)
+		if err != nil {
+			return store.RangeQueryResult{}, fmt.Errorf("failed to get SC store range proof: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// proofGetter returns the proof type of the SC tree of the given store key, a
+// getter of the proofs of its keys and the proof of the tree root against the
+// commit info, retrieved along with the proof of the given key.
+func (s *Store) proofGetter(storeKey []byte, version uint64, key []byte) (string, proof.ProofGetter, proof.CommitmentOp, error) {
+	ops, err := s.stateCommitment.GetProof(storeKey, version, key)
+	if err != nil {
+		return "", nil, proof.CommitmentOp{}, fmt.Errorf("failed to get SC store proof: %w", err)
+	}
+	if len(ops) != 2 {
+		return "", nil, proof.CommitmentOp{}, fmt.Errorf("expected 2 SC store proof ops, got %d", len(ops))
+	}
+
+	get := func(key []byte) (*ics23.CommitmentProof, error) {
+		ops, err := s.stateCommitment.GetProof(storeKey, version, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get SC store proof: %w", err)
+		}
+		return ops[0].Proof, nil
+	}
+
+	return ops[0].Type, get, ops[1], nil
+}
+
 func (s *Store) LoadLatestVersion() error {
 	if s.telemetry != nil {
 		now := time.Now()
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	ics23 "github.com/cosmos/ics23/go"
 	"github.com/stretchr/testify/suite"
 
 	coreheader "cosmossdk.io/core/header"
@@ -231,6 +232,110 @@ func (s *RootStoreTestSuite) TestQueryProof() {
 	s.Require().Equal(expRoots[0], cInfo.Hash())
 }
 
+func (s *RootStoreTestSuite) TestQueryKeys() {
+	cs := corestore.NewChangeset()
+	cs.Add(testStoreKeyBytes, []byte("key1"), []byte("value1"), false)
+	cs.Add(testStoreKeyBytes, []byte("key3"), []byte("value3"), false)
+	cs.Add(testStoreKey2Bytes, []byte("key2"), []byte("value2"), false)
+	appHash, err := s.rootStore.Commit(cs)
+	s.Require().NoError(err)
+
+	keys := [][]byte{[]byte("key3"), []byte("key2"), []byte("key1")}
+	result, err := s.rootStore.QueryKeys(testStoreKeyBytes, 1, keys, true)
+	s.Require().NoError(err)
+	s.Require().Equal([]corestore.KVPair{
+		{Key: []byte("key3"), Value: []byte("value3")},
+		{Key: []byte("key2")},
+		{Key: []byte("key1"), Value: []byte("value1")},
+	}, result.Pairs)
+	s.Require().NoError(result.Proof.Verify(appHash, testStoreKeyBytes, result.Pairs))
+
+	// the proof must not verify tampered values, absences or stores
+	s.Require().Error(result.Proof.Verify(appHash, testStoreKeyBytes, []corestore.KVPair{{Key: []byte("key3"), Value: []byte("value1")}}))
+	s.Require().Error(result.Proof.Verify(appHash, testStoreKeyBytes, []corestore.KVPair{{Key: []byte("key1")}}))
+	s.Require().Error(result.Proof.Verify(appHash, testStoreKeyBytes, []corestore.KVPair{{Key: []byte("key2"), Value: []byte("value2")}}))
+	s.Require().Error(result.Proof.Verify(appHash, testStoreKey2Bytes, result.Pairs[:1]))
+}
+
+func (s *RootStoreTestSuite) TestQueryRange() {
+	cs := corestore.NewChangeset()
+	for i := 0; i < 10; i++ {
+		cs.Add(testStoreKeyBytes, []byte(fmt.Sprintf("key%02d", i*2)), []byte(fmt.Sprintf("value%02d", i*2)), false)
+	}
+	cs.Add(testStoreKey2Bytes, []byte("key"), []byte("value"), false)
+	appHash, err := s.rootStore.Commit(cs)
+	s.Require().NoError(err)
+
+	testCases := []struct {
+		name       string
+		start, end []byte
+		limit      int
+		expected   []string
+		nextKey    []byte
+	}{
+		{"full range", nil, nil, 0, []string{"00", "02", "04", "06", "08", "10", "12", "14", "16", "18"}, nil},
+		{"existing bounds", []byte("key04"), []byte("key10"), 0, []string{"04", "06", "08"}, nil},
+		{"absent bounds", []byte("key03"), []byte("key11"), 0, []string{"04", "06", "08", "10"}, nil},
+		{"empty range", []byte("key05"), []byte("key06"), 0, nil, nil},
+		{"before first key", []byte("a"), []byte("key01"), 0, []string{"00"}, nil},
+		{"after last key", []byte("key17"), []byte("z"), 0, []string{"18"}, nil},
+		{"first page", nil, nil, 3, []string{"00", "02", "04"}, []byte("key06")},
+		{"last page", []byte("key14"), nil, 3, []string{"14", "16", "18"}, nil},
+	}
+
+	for _, tc := range testCases {
+		s.Run(tc.name, func() {
+			result, err := s.rootStore.QueryRange(testStoreKeyBytes, 1, tc.start, tc.end, tc.limit, true)
+			s.Require().NoError(err)
+			s.Require().Equal(tc.nextKey, result.NextKey)
+			s.Require().Len(result.Pairs, len(tc.expected))
+			for i, suffix := range tc.expected {
+				s.Require().Equal([]byte("key"+suffix), result.Pairs[i].Key)
+				s.Require().Equal([]byte("value"+suffix), result.Pairs[i].Value)
+			}
+			s.Require().NoError(result.Proof.Verify(appHash, testStoreKeyBytes, result.Pairs))
+		})
+	}
+
+	result, err := s.rootStore.QueryRange(testStoreKeyBytes, 1, []byte("key03"), []byte("key11"), 0, true)
+	s.Require().NoError(err)
+	pairs := result.Pairs
+
+	// omitted keys must not verify
+	proofWithout := func(i int) *proof.RangeProof {
+		p := *result.Proof
+		p.Entries = append(append([]*ics23.ExistenceProof{}, p.Entries[:i]...), p.Entries[i+1:]...)
+		return &p
+	}
+	withoutPair := func(i int) []corestore.KVPair {
+		return append(append([]corestore.KVPair{}, pairs[:i]...), pairs[i+1:]...)
+	}
+	s.Require().Error(proofWithout(0).Verify(appHash, testStoreKeyBytes, withoutPair(0)))
+	s.Require().Error(proofWithout(1).Verify(appHash, testStoreKeyBytes, withoutPair(1)))
+	s.Require().Error(proofWithout(3).Verify(appHash, testStoreKeyBytes, withoutPair(3)))
+
+	// neither must a missing neighbor or a tampered value
+	noLeft := *result.Proof
+	noLeft.Left = nil
+	s.Require().Error(noLeft.Verify(appHash, testStoreKeyBytes, pairs))
+	noRight := *result.Proof
+	noRight.Right = nil
+	s.Require().Error(noRight.Verify(appHash, testStoreKeyBytes, pairs))
+	tampered := append([]corestore.KVPair{}, pairs...)
+	tampered[2] = corestore.KVPair{Key: tampered[2].Key, Value: []byte("tampered")}
+	s.Require().Error(result.Proof.Verify(appHash, testStoreKeyBytes, tampered))
+	s.Require().Error(result.Proof.Verify([]byte("app hash"), testStoreKeyBytes, pairs))
+
+	// the next page of a paginated range starts at the next key
+	result, err = s.rootStore.QueryRange(testStoreKeyBytes, 1, nil, nil, 4, true)
+	s.Require().NoError(err)
+	s.Require().NoError(result.Proof.Verify(appHash, testStoreKeyBytes, result.Pairs))
+	result, err = s.rootStore.QueryRange(testStoreKeyBytes, 1, result.NextKey, nil, 4, true)
+	s.Require().NoError(err)
+	s.Require().Equal([]byte("key08"), result.Pairs[0].Key)
+	s.Require().NoError(result.Proof.Verify(appHash, testStoreKeyBytes, result.Pairs))
+}
+
 func (s *RootStoreTestSuite) TestLoadVersion() {
 	// write and commit a few changesets
 	for v := 1; v <= 5; v++ {
@@ -340,6 +445,43 @@ func (s *RootStoreTestSuite) TestCommit() {
 	}
 }
 
+func (s *RootStoreTestSuite) TestGetChangeset() {
+	cs := corestore.NewChangeset()
+	cs.Add(testStoreKeyBytes, []byte("a"), []byte("1"), false)
+	cs.Add(testStoreKeyBytes, []byte("b"), []byte("1"), false)
+	cs.Add(testStoreKey2Bytes, []byte("c"), []byte("1"), false)
+	_, err := s.rootStore.Commit(cs)
+	s.Require().NoError(err)
+
+	cs = corestore.NewChangeset()
+	cs.Add(testStoreKeyBytes, []byte("b"), nil, true)
+	cs.Add(testStoreKeyBytes, []byte("a"), []byte("2"), false)
+	_, err = s.rootStore.Commit(cs)
+	s.Require().NoError(err)
+
+	reader, ok := s.rootStore.(interface {
+		GetChangeset(version uint64) (*corestore.Changeset, error)
+	})
+	s.Require().True(ok)
+
+	changeset, err := reader.GetChangeset(2)
+	s.Require().NoError(err)
+	// the changes of each store are in the order of the keys
+	s.Require().Equal([]corestore.StateChanges{
+		{Actor: testStoreKeyBytes, StateChanges: corestore.KVPairs{
+			{Key: []byte("a"), Value: []byte("2")},
+			{Key: []byte("b"), Remove: true},
+		}},
+	}, changeset.Changes)
+
+	changeset, err = reader.GetChangeset(1)
+	s.Require().NoError(err)
+	s.Require().Len(changeset.Changes, 2)
+
+	_, err = reader.GetChangeset(3)
+	s.Require().Error(err)
+}
+
 func (s *RootStoreTestSuite) TestStateAt() {
 	// write keys over multiple versions
 	for v := uint64(1); v <= 5; v++ {
@@ -6,7 +6,10 @@ import (
 	"compress/zlib"
 	"crypto/sha256"
 	"errors"
+	"fmt"
 	"io"
+	"sort"
+	"sync"
 	"testing"
 	"time"
 
@@ -155,12 +158,165 @@ func (m *mockCommitSnapshotter) SupportedFormats() []uint32 {
 	return []uint32{snapshotstypes.CurrentFormat}
 }
 
-type mockStorageSnapshotter struct{}
+type mockSegmentSnapshotter struct {
+	mockCommitSnapshotter
+
+	mtx       sync.Mutex
+	stores    map[string][][]byte
+	finalized bool
+}
+
+var _ snapshots.SegmentSnapshotter = (*mockSegmentSnapshotter)(nil)
+
+func (m *mockSegmentSnapshotter) SnapshotStoreKeys() []string {
+	storeKeys := make([]string, 0, len(m.stores))
+	for storeKey := range m.stores {
+		storeKeys = append(storeKeys, storeKey)
+	}
+	sort.Strings(storeKeys)
+	return storeKeys
+}
+
+func (m *mockSegmentSnapshotter) SnapshotSegment(height uint64, storeKey string, protoWriter protoio.Writer) error {
+	for _, item := range m.stores[storeKey] {
+		if err := snapshotstypes.WriteExtensionPayload(protoWriter, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockSegmentSnapshotter) RestoreSegment(
+	height uint64, storeKey string, protoReader protoio.Reader, chStorage chan<- *corestore.StateChanges,
+) error {
+	items := [][]byte{}
+	for {
+		var item snapshotstypes.SnapshotItem
+		err := protoReader.ReadMsg(&item)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return errorsmod.Wrap(err, "invalid protobuf message")
+		}
+		items = append(items, item.GetExtensionPayload().Payload)
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.stores == nil {
+		m.stores = make(map[string][][]byte)
+	}
+	m.stores[storeKey] = items
+	return nil
+}
+
+func (m *mockSegmentSnapshotter) FinalizeRestore(height uint64) error {
+	m.finalized = true
+	return nil
+}
+
+// mockDeltaSnapshotter is a commitment snapshotter whose state is a list of items only ever appended
+// to, the changes of a height being the items appended at the height.
+type mockDeltaSnapshotter struct {
+	mockCommitSnapshotter
+	// versions are the items of each height.
+	versions map[uint64][][]byte
+	// height is the restored height.
+	height uint64
+}
+
+var _ snapshots.DeltaSnapshotter = (*mockDeltaSnapshotter)(nil)
+
+func (m *mockDeltaSnapshotter) Snapshot(height uint64, protoWriter protoio.Writer) error {
+	for _, item := range m.versions[height] {
+		if err := snapshotstypes.WriteExtensionPayload(protoWriter, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockDeltaSnapshotter) Restore(
+	height uint64, format uint32, protoReader protoio.Reader, chStorage chan<- *corestore.StateChanges,
+) (snapshotstypes.SnapshotItem, error) {
+	item, err := m.mockCommitSnapshotter.Restore(height, format, protoReader, chStorage)
+	m.height = height
+	return item, err
+}
+
+func (m *mockDeltaSnapshotter) CanSnapshotDelta(base, height uint64) bool {
+	return m.versions[base] != nil && m.versions[height] != nil
+}
+
+func (m *mockDeltaSnapshotter) SnapshotDelta(base, height uint64, protoWriter protoio.Writer) error {
+	err := protoWriter.WriteMsg(&snapshotstypes.SnapshotItem{
+		Item: &snapshotstypes.SnapshotItem_Delta{
+			Delta: &snapshotstypes.SnapshotDeltaItem{Version: height},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, item := range m.versions[height][len(m.versions[base]):] {
+		if err := snapshotstypes.WriteExtensionPayload(protoWriter, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *mockDeltaSnapshotter) RestoreDelta(
+	base, height uint64, protoReader protoio.Reader, applyStorage func(version uint64, cs *corestore.Changeset) error,
+) (snapshotstypes.SnapshotItem, error) {
+	if m.height != base {
+		return snapshotstypes.SnapshotItem{}, fmt.Errorf("cannot restore the changes since %d at %d", base, m.height)
+	}
+
+	var item snapshotstypes.SnapshotItem
+	if err := protoReader.ReadMsg(&item); err != nil {
+		return snapshotstypes.SnapshotItem{}, err
+	}
+	if item.GetDelta().GetVersion() != height {
+		return snapshotstypes.SnapshotItem{}, fmt.Errorf("unexpected item %v", item)
+	}
+	cs := corestore.NewChangeset()
+	for {
+		item.Reset()
+		err := protoReader.ReadMsg(&item)
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return snapshotstypes.SnapshotItem{}, errorsmod.Wrap(err, "invalid protobuf message")
+		}
+		payload := item.GetExtensionPayload()
+		if payload == nil {
+			break
+		}
+		m.items = append(m.items, payload.Payload)
+		cs.Add([]byte("mock"), payload.Payload, payload.Payload, false)
+	}
+	m.height = height
+
+	return item, applyStorage(height, cs)
+}
+
+type mockStorageSnapshotter struct {
+	// changesets are the sizes of the changesets applied at each version.
+	changesets map[uint64]int
+}
 
 func (m *mockStorageSnapshotter) Restore(version uint64, chStorage <-chan *corestore.StateChanges) error {
 	return nil
 }
 
+func (m *mockStorageSnapshotter) ApplyChangeset(version uint64, cs *corestore.Changeset) error {
+	if m.changesets == nil {
+		m.changesets = make(map[uint64]int)
+	}
+	m.changesets[version] = cs.Size()
+	return nil
+}
+
 type mockErrorCommitSnapshotter struct{}
 
 var _ snapshots.CommitSnapshotter = (*mockErrorCommitSnapshotter)(nil)
@@ -8,9 +8,12 @@ import (
 	"io"
 	"math"
 	"os"
+	"runtime"
 	"sort"
 	"sync"
 
+	protoio "github.com/cosmos/gogoproto/io"
+
 	"cosmossdk.io/core/log"
 	corestore "cosmossdk.io/core/store"
 	errorsmod "cosmossdk.io/errors"
@@ -43,6 +46,10 @@ type Manager struct {
 
 	logger log.Logger
 
+	// restoreConcurrency is the maximum number of segments restored at the same
+	// time from a segmented snapshot
+	restoreConcurrency int
+
 	mtx               sync.Mutex
 	operation         operation
 	chRestore         chan<- uint32
@@ -75,7 +82,8 @@ const (
 
 var ErrOptsZeroSnapshotInterval = errors.New("snapshot-interval must not be 0")
 
-// NewManager creates a new manager.
+// NewManager creates a new manager. By default, up to runtime.NumCPU() segments
+// of a segmented snapshot are restored concurrently, see SetRestoreConcurrency.
 func NewManager(store *Store, opts SnapshotOptions, commitSnapshotter CommitSnapshotter, storageSnapshotter StorageSnapshotter, extensions map[string]ExtensionSnapshotter, logger log.Logger) *Manager {
 	if extensions == nil {
 		extensions = map[string]ExtensionSnapshotter{}
@@ -87,9 +95,16 @@ func NewManager(store *Store, opts SnapshotOptions, commitSnapshotter CommitSnap
 		storageSnapshotter: storageSnapshotter,
 		extensions:         extensions,
 		logger:             logger.With("module", "snapshot_manager"),
+		restoreConcurrency: runtime.NumCPU(),
 	}
 }
 
+// SetRestoreConcurrency sets the maximum number of segments which are restored
+// concurrently from a segmented snapshot. Values < 1 remove the limit.
+func (m *Manager) SetRestoreConcurrency(concurrency int) {
+	m.restoreConcurrency = concurrency
+}
+
 // RegisterExtensions register extension snapshotters to manager
 func (m *Manager) RegisterExtensions(extensions ...ExtensionSnapshotter) error {
 	if m.extensions == nil {
@@ -187,9 +202,26 @@ func (m *Manager) Create(height uint64) (*types.Snapshot, error) {
 
 	// Spawn goroutine to generate snapshot chunks and pass their io.ReadClosers through a channel
 	ch := make(chan io.ReadCloser)
-	go m.createSnapshot(height, ch)
+	if latest != nil && m.canSnapshotDelta(latest, height) {
+		go m.createDeltaSnapshot(latest.Height, height, ch)
+
+		return m.store.SaveDelta(height, latest.Height, ch)
+	}
+	format := m.opts.format()
+	switch format {
+	case types.CurrentFormat:
+		go m.createSnapshot(height, ch)
+	case types.FormatSegmented:
+		snapshotter, ok := m.commitSnapshotter.(SegmentSnapshotter)
+		if !ok {
+			return nil, errorsmod.Wrapf(types.ErrUnknownFormat, "commitment snapshotter does not support format %v", format)
+		}
+		go m.createSegmentedSnapshot(height, snapshotter, ch)
+	default:
+		return nil, errorsmod.Wrapf(types.ErrUnknownFormat, "snapshot format %v", format)
+	}
 
-	return m.store.Save(height, types.CurrentFormat, ch)
+	return m.store.Save(height, format, ch)
 }
 
 // createSnapshot do the heavy work of snapshotting after the validations of request are done
@@ -209,10 +241,67 @@ func (m *Manager) createSnapshot(height uint64, ch chan<- io.ReadCloser) {
 		streamWriter.CloseWithError(err)
 		return
 	}
+	if err := m.snapshotExtensions(height, streamWriter); err != nil {
+		streamWriter.CloseWithError(err)
+		return
+	}
+}
+
+// canSnapshotDelta returns whether an incremental snapshot of the changes since
+// the latest snapshot can be taken at the given height, without exceeding the
+// maximum number of incremental snapshots after a full one.
+func (m *Manager) canSnapshotDelta(latest *types.Snapshot, height uint64) bool {
+	if m.opts.MaxDeltas == 0 {
+		return false
+	}
+	snapshotter, ok := m.commitSnapshotter.(DeltaSnapshotter)
+	if !ok {
+		return false
+	}
+	if _, ok := m.storageSnapshotter.(DeltaStorageSnapshotter); !ok {
+		return false
+	}
+	chain, err := m.store.Chain(latest)
+	if err != nil {
+		m.logger.Error("failed to resolve the latest snapshot chain, taking a full snapshot", "height", latest.Height, "err", err)
+		return false
+	}
+	// the chain starts with a full snapshot
+	if uint32(len(chain)-1) >= m.opts.MaxDeltas {
+		return false
+	}
+	return snapshotter.CanSnapshotDelta(latest.Height, height)
+}
+
+// createDeltaSnapshot is like createSnapshot, but writes the changes since the
+// base height instead of the full commitment state.
+func (m *Manager) createDeltaSnapshot(base, height uint64, ch chan<- io.ReadCloser) {
+	streamWriter := NewStreamWriter(ch)
+	if streamWriter == nil {
+		return
+	}
+	defer func() {
+		if err := streamWriter.Close(); err != nil {
+			streamWriter.CloseWithError(err)
+		}
+	}()
+
+	if err := m.commitSnapshotter.(DeltaSnapshotter).SnapshotDelta(base, height, streamWriter); err != nil {
+		streamWriter.CloseWithError(err)
+		return
+	}
+	if err := m.snapshotExtensions(height, streamWriter); err != nil {
+		streamWriter.CloseWithError(err)
+		return
+	}
+}
+
+// snapshotExtensions writes the snapshots of the extensions, in the order of their names.
+func (m *Manager) snapshotExtensions(height uint64, protoWriter protoio.Writer) error {
 	for _, name := range m.sortedExtensionNames() {
 		extension := m.extensions[name]
 		// write extension metadata
-		err := streamWriter.WriteMsg(&types.SnapshotItem{
+		err := protoWriter.WriteMsg(&types.SnapshotItem{
 			Item: &types.SnapshotItem_Extension{
 				Extension: &types.SnapshotExtensionMeta{
 					Name:   name,
@@ -221,17 +310,17 @@ func (m *Manager) createSnapshot(height uint64, ch chan<- io.ReadCloser) {
 			},
 		})
 		if err != nil {
-			streamWriter.CloseWithError(err)
-			return
+			return err
 		}
 		payloadWriter := func(payload []byte) error {
-			return types.WriteExtensionPayload(streamWriter, payload)
+			return types.WriteExtensionPayload(protoWriter, payload)
 		}
 		if err := extension.SnapshotExtension(height, payloadWriter); err != nil {
-			streamWriter.CloseWithError(err)
-			return
+			return err
 		}
 	}
+
+	return nil
 }
 
 // CreateMigration creates a migration snapshot and writes it to the given writer.
@@ -266,8 +355,20 @@ func (m *Manager) EndMigration(commitSnapshotter CommitSnapshotter) {
 }
 
 // List lists snapshots, mirroring ABCI ListSnapshots. It can be concurrent with other operations.
+// Incremental snapshots are not listed, as they can't be restored through state sync.
 func (m *Manager) List() ([]*types.Snapshot, error) {
-	return m.store.List()
+	snapshots, err := m.store.List()
+	if err != nil {
+		return nil, err
+	}
+
+	listed := make([]*types.Snapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if snapshot.Format != types.FormatDelta {
+			listed = append(listed, snapshot)
+		}
+	}
+	return listed, nil
 }
 
 // LoadChunk loads a chunk into a byte slice, mirroring ABCI LoadChunk. It can be called
@@ -309,8 +410,9 @@ func (m *Manager) Restore(snapshot types.Snapshot) error {
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
-	// check multistore supported format preemptive
-	if snapshot.Format != types.CurrentFormat {
+	// check multistore supported format preemptive, incremental snapshots can only be restored
+	// locally on top of their base snapshot, see RestoreLocalSnapshot.
+	if !types.IsSupportedFormat(snapshot.Format) || snapshot.Format == types.FormatDelta {
 		return errorsmod.Wrapf(types.ErrUnknownFormat, "snapshot format %v", snapshot.Format)
 	}
 	if snapshot.Height == 0 {
@@ -338,7 +440,7 @@ func (m *Manager) Restore(snapshot types.Snapshot) error {
 	chChunks := m.loadChunkStream(snapshot.Height, snapshot.Format, chChunkIDs)
 
 	go func() {
-		err := m.doRestoreSnapshot(snapshot, chChunks)
+		err := m.doRestoreSnapshot(snapshot, chChunks, true)
 		chDone <- restoreDone{
 			complete: err == nil,
 			err:      err,
@@ -372,48 +474,112 @@ func (m *Manager) loadChunkStream(height uint64, format uint32, chunkIDs <-chan
 }
 
 // doRestoreSnapshot do the heavy work of snapshot restoration after preliminary checks on request have passed.
-func (m *Manager) doRestoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.ReadCloser) error {
+// The extensions are only restored if restoreExtensions is true, as restoring the snapshots of a chain only
+// needs the extensions of its last snapshot.
+func (m *Manager) doRestoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.ReadCloser, restoreExtensions bool) error {
 	dir := m.store.pathSnapshot(snapshot.Height, snapshot.Format)
 	if err := os.MkdirAll(dir, 0o750); err != nil {
 		return errorsmod.Wrapf(err, "failed to create snapshot directory %q", dir)
 	}
 
-	var nextItem types.SnapshotItem
+	switch snapshot.Format {
+	case types.FormatSegmented:
+		return m.doRestoreSegmentedSnapshot(snapshot, chChunks, restoreExtensions)
+	case types.FormatDelta:
+		return m.doRestoreDeltaSnapshot(snapshot, chChunks, restoreExtensions)
+	}
+
 	streamReader, err := NewStreamReader(chChunks)
 	if err != nil {
 		return err
 	}
 	defer streamReader.Close()
 
-	// payloadReader reads an extension payload for extension snapshotter, it returns `io.EOF` at extension boundaries.
-	payloadReader := func() ([]byte, error) {
-		nextItem.Reset()
-		if err := streamReader.ReadMsg(&nextItem); err != nil {
-			return nil, err
-		}
-		payload := nextItem.GetExtensionPayload()
-		if payload == nil {
-			return nil, io.EOF
+	chStorage, storageErrs := m.restoreStorage(snapshot.Height)
+	nextItem, err := m.commitSnapshotter.Restore(snapshot.Height, snapshot.Format, streamReader, chStorage)
+	close(chStorage)
+	if err != nil {
+		return errorsmod.Wrap(err, "multistore restore")
+	}
+
+	if restoreExtensions {
+		if err := m.restoreExtensions(snapshot.Height, streamReader, nextItem); err != nil {
+			return err
 		}
-		return payload.Payload, nil
 	}
 
-	// chStorage is the channel to pass the KV pairs to the storage snapshotter.
-	chStorage := make(chan *corestore.StateChanges, defaultStorageChannelBufferSize)
-	defer close(chStorage)
+	// wait for storage snapshotter to complete
+	if err := <-storageErrs; err != nil {
+		return errorsmod.Wrap(err, "storage snapshotter")
+	}
+
+	return nil
+}
+
+// doRestoreDeltaSnapshot restores an incremental snapshot on top of the state
+// at its base height.
+func (m *Manager) doRestoreDeltaSnapshot(snapshot types.Snapshot, chChunks <-chan io.ReadCloser, restoreExtensions bool) error {
+	snapshotter, ok := m.commitSnapshotter.(DeltaSnapshotter)
+	if !ok {
+		return errorsmod.Wrapf(types.ErrUnknownFormat, "commitment snapshotter does not support format %v", snapshot.Format)
+	}
+	storageSnapshotter, ok := m.storageSnapshotter.(DeltaStorageSnapshotter)
+	if !ok {
+		return errorsmod.Wrapf(types.ErrUnknownFormat, "storage snapshotter does not support format %v", snapshot.Format)
+	}
+
+	streamReader, err := NewStreamReader(chChunks)
+	if err != nil {
+		return err
+	}
+	defer streamReader.Close()
+
+	nextItem, err := snapshotter.RestoreDelta(snapshot.Metadata.BaseHeight, snapshot.Height, streamReader, storageSnapshotter.ApplyChangeset)
+	if err != nil {
+		return errorsmod.Wrap(err, "multistore delta restore")
+	}
+
+	if !restoreExtensions {
+		return nil
+	}
+	return m.restoreExtensions(snapshot.Height, streamReader, nextItem)
+}
 
+// restoreStorage starts the restoration of the storage state from the returned
+// channel, which must be closed once all the KV pairs are passed. The returned
+// error channel is closed once the restoration is complete.
+func (m *Manager) restoreStorage(height uint64) (chan<- *corestore.StateChanges, <-chan error) {
+	chStorage := make(chan *corestore.StateChanges, defaultStorageChannelBufferSize)
 	storageErrs := make(chan error, 1)
 	go func() {
 		defer close(storageErrs)
-		err := m.storageSnapshotter.Restore(snapshot.Height, chStorage)
+		err := m.storageSnapshotter.Restore(height, chStorage)
+		// drain the channel so that the commitment restoration does not block
+		// if the storage restoration failed
+		for range chStorage {
+		}
 		if err != nil {
 			storageErrs <- err
 		}
 	}()
 
-	nextItem, err = m.commitSnapshotter.Restore(snapshot.Height, snapshot.Format, streamReader, chStorage)
-	if err != nil {
-		return errorsmod.Wrap(err, "multistore restore")
+	return chStorage, storageErrs
+}
+
+// restoreExtensions restores the extensions from the snapshot reader, starting
+// with the given item, which is the metadata of the first extension if any.
+func (m *Manager) restoreExtensions(height uint64, protoReader protoio.Reader, nextItem types.SnapshotItem) error {
+	// payloadReader reads an extension payload for extension snapshotter, it returns `io.EOF` at extension boundaries.
+	payloadReader := func() ([]byte, error) {
+		nextItem.Reset()
+		if err := protoReader.ReadMsg(&nextItem); err != nil {
+			return nil, err
+		}
+		payload := nextItem.GetExtensionPayload()
+		if payload == nil {
+			return nil, io.EOF
+		}
+		return payload.Payload, nil
 	}
 
 	for {
@@ -433,7 +599,7 @@ func (m *Manager) doRestoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.
 			return errorsmod.Wrapf(types.ErrUnknownFormat, "format %v for extension %s", metadata.Format, metadata.Name)
 		}
 
-		if err := extension.RestoreExtension(snapshot.Height, metadata.Format, payloadReader); err != nil {
+		if err := extension.RestoreExtension(height, metadata.Format, payloadReader); err != nil {
 			return errorsmod.Wrapf(err, "extension %s restore", metadata.Name)
 		}
 
@@ -442,11 +608,6 @@ func (m *Manager) doRestoreSnapshot(snapshot types.Snapshot, chChunks <-chan io.
 		}
 	}
 
-	// wait for storage snapshotter to complete
-	if err := <-storageErrs; err != nil {
-		return errorsmod.Wrap(err, "storage snapshotter")
-	}
-
 	return nil
 }
 
@@ -514,9 +675,11 @@ func (m *Manager) RestoreChunk(chunk []byte) (bool, error) {
 	return false, nil
 }
 
-// RestoreLocalSnapshot restores app state from a local snapshot.
+// RestoreLocalSnapshot restores app state from a local snapshot. An incremental snapshot is
+// restored by restoring the full snapshot it is based on, followed by the incremental snapshots
+// up to it.
 func (m *Manager) RestoreLocalSnapshot(height uint64, format uint32) error {
-	snapshot, ch, err := m.store.Load(height, format)
+	snapshot, err := m.store.Get(height, format)
 	if err != nil {
 		return err
 	}
@@ -525,6 +688,11 @@ func (m *Manager) RestoreLocalSnapshot(height uint64, format uint32) error {
 		return fmt.Errorf("snapshot doesn't exist, height: %d, format: %d", height, format)
 	}
 
+	chain, err := m.store.Chain(snapshot)
+	if err != nil {
+		return err
+	}
+
 	m.mtx.Lock()
 	defer m.mtx.Unlock()
 
@@ -534,7 +702,16 @@ func (m *Manager) RestoreLocalSnapshot(height uint64, format uint32) error {
 	}
 	defer m.endLocked()
 
-	return m.doRestoreSnapshot(*snapshot, ch)
+	for i, snapshot := range chain {
+		_, ch, err := m.store.Load(snapshot.Height, snapshot.Format)
+		if err != nil {
+			return err
+		}
+		if err := m.doRestoreSnapshot(*snapshot, ch, i == len(chain)-1); err != nil {
+			return errorsmod.Wrapf(err, "restore snapshot at height %d, format %d", snapshot.Height, snapshot.Format)
+		}
+	}
+	return nil
 }
 
 // sortedExtensionNames sort extension names for deterministic iteration.
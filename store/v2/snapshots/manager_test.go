@@ -1,6 +1,8 @@
 package snapshots_test
 
 import (
+	"bytes"
+	"crypto/rand"
 	"errors"
 	"testing"
 
@@ -245,6 +247,84 @@ func TestManager_Restore(t *testing.T) {
 	}
 }
 
+func TestManager_Delta(t *testing.T) {
+	store := setupStore(t)
+	source := &mockDeltaSnapshotter{versions: make(map[uint64][][]byte)}
+	items := [][]byte{}
+	for height := uint64(4); height <= 8; height++ {
+		items = append(items, []byte{byte(height)})
+		source.versions[height] = items[:len(items):len(items)]
+	}
+	deltaOpts := snapshots.NewSnapshotOptions(1, 2)
+	deltaOpts.MaxDeltas = 2
+	manager := snapshots.NewManager(store, deltaOpts, source, &mockStorageSnapshotter{}, nil, log.NewNopLogger())
+	require.NoError(t, manager.RegisterExtensions(newExtSnapshotter(3)))
+
+	// a full snapshot is followed by up to MaxDeltas incremental snapshots
+	expected := []struct {
+		format uint32
+		base   uint64
+	}{
+		{types.CurrentFormat, 0},
+		{types.FormatDelta, 4},
+		{types.FormatDelta, 5},
+		{types.CurrentFormat, 0},
+		{types.FormatDelta, 7},
+	}
+	for i, e := range expected {
+		height := uint64(i + 4)
+		snapshot, err := manager.Create(height)
+		require.NoError(t, err)
+		require.Equal(t, e.format, snapshot.Format, "height %d", height)
+		require.Equal(t, e.base, snapshot.Metadata.BaseHeight, "height %d", height)
+	}
+
+	// an incremental snapshot is restored on top of its chain, and its changes
+	// are applied to the storage
+	for i, e := range expected {
+		height := uint64(i + 4)
+		target := &mockDeltaSnapshotter{}
+		storageSnapshotter := &mockStorageSnapshotter{}
+		extSnapshotter := newExtSnapshotter(0)
+		manager := snapshots.NewManager(store, deltaOpts, target, storageSnapshotter, nil, log.NewNopLogger())
+		require.NoError(t, manager.RegisterExtensions(extSnapshotter))
+
+		require.NoError(t, manager.RestoreLocalSnapshot(height, e.format))
+		require.Equal(t, source.versions[height], target.items)
+		require.Equal(t, height, target.height)
+		require.Len(t, extSnapshotter.state, 3)
+		for v := e.base + 1; e.base > 0 && v <= height; v++ {
+			require.Equal(t, 1, storageSnapshotter.changesets[v], "height %d", v)
+		}
+	}
+
+	// incremental snapshots can't be restored through state sync
+	snapshot, err := store.Get(8, types.FormatDelta)
+	require.NoError(t, err)
+	err = manager.Restore(*snapshot)
+	require.ErrorIs(t, err, types.ErrUnknownFormat)
+
+	// nor are they listed for state sync
+	list, err := manager.List()
+	require.NoError(t, err)
+	require.NotEmpty(t, list)
+	for _, snapshot := range list {
+		require.NotEqual(t, types.FormatDelta, snapshot.Format, "height %d", snapshot.Height)
+	}
+
+	// the chain of the retained incremental snapshot is retained
+	pruned, err := manager.Prune(1)
+	require.NoError(t, err)
+	require.EqualValues(t, 7, pruned)
+
+	// without the intermediate heights, a full snapshot is taken
+	source.versions[9] = append(source.versions[8], []byte{9})
+	delete(source.versions, 8)
+	snapshot, err = manager.Create(9)
+	require.NoError(t, err)
+	require.Equal(t, types.CurrentFormat, snapshot.Format)
+}
+
 func TestManager_TakeError(t *testing.T) {
 	snapshotter := &mockErrorCommitSnapshotter{}
 	store, err := snapshots.NewStore(t.TempDir())
@@ -254,3 +334,82 @@ func TestManager_TakeError(t *testing.T) {
 	_, err = manager.Create(1)
 	require.Error(t, err)
 }
+
+func TestManager_SegmentedSnapshot(t *testing.T) {
+	// a store spanning several chunks, a small one and an empty one
+	large := make([][]byte, 1100)
+	for i := range large {
+		large[i] = make([]byte, 10000)
+		_, err := rand.Read(large[i])
+		require.NoError(t, err)
+	}
+	source := &mockSegmentSnapshotter{stores: map[string][][]byte{
+		"a": large,
+		"b": {{1, 2, 3}, {4, 5, 6}},
+		"c": {},
+	}}
+	segmentedOpts := opts
+	segmentedOpts.Format = types.FormatSegmented
+	manager := snapshots.NewManager(setupStore(t), segmentedOpts, source, &mockStorageSnapshotter{}, nil, log.NewNopLogger())
+	require.NoError(t, manager.RegisterExtensions(newExtSnapshotter(10)))
+
+	snapshot, err := manager.Create(5)
+	require.NoError(t, err)
+	require.Equal(t, types.FormatSegmented, snapshot.Format)
+	require.Equal(t, uint32(5), snapshot.Chunks)
+	chunks := make([][]byte, snapshot.Chunks)
+	for i := range chunks {
+		chunks[i], err = manager.LoadChunk(snapshot.Height, snapshot.Format, uint32(i))
+		require.NoError(t, err)
+	}
+
+	// the segments are restored concurrently
+	restore := func(chunks [][]byte) (*mockSegmentSnapshotter, *extSnapshotter, error) {
+		target := &mockSegmentSnapshotter{}
+		extSnapshotter := newExtSnapshotter(0)
+		manager := snapshots.NewManager(setupStore(t), opts, target, &mockStorageSnapshotter{}, nil, log.NewNopLogger())
+		require.NoError(t, manager.RegisterExtensions(extSnapshotter))
+		manager.SetRestoreConcurrency(2)
+
+		err := manager.Restore(types.Snapshot{
+			Height:   snapshot.Height,
+			Format:   snapshot.Format,
+			Chunks:   uint32(len(chunks)),
+			Metadata: types.Metadata{ChunkHashes: checksums(chunks)},
+		})
+		require.NoError(t, err)
+		for i, chunk := range chunks {
+			done, err := manager.RestoreChunk(chunk)
+			if err != nil {
+				return nil, nil, err
+			}
+			require.Equal(t, i == len(chunks)-1, done)
+		}
+		return target, extSnapshotter, nil
+	}
+
+	target, extSnapshotter, err := restore(chunks)
+	require.NoError(t, err)
+	require.Equal(t, source.stores, target.stores)
+	require.True(t, target.finalized)
+	require.Len(t, extSnapshotter.state, 10)
+
+	// a segment whose hash does not match is rejected, even if the chunk hashes
+	// are valid
+	tampered := make([][]byte, len(chunks))
+	copy(tampered, chunks)
+	tampered[2] = bytes.Clone(chunks[2])
+	require.Equal(t, []byte{1, 'b', 0, 1}, tampered[2][:4])
+	tampered[2][4] ^= 0xff
+	_, _, err = restore(tampered)
+	require.ErrorIs(t, err, types.ErrSegmentHashMismatch)
+
+	// so is an incomplete segment
+	_, _, err = restore(append([][]byte{chunks[0]}, chunks[2:]...))
+	require.ErrorIs(t, err, types.ErrInvalidMetadata)
+
+	// and a snapshotter which does not support the format fails early
+	manager = snapshots.NewManager(setupStore(t), segmentedOpts, &mockCommitSnapshotter{}, &mockStorageSnapshotter{}, nil, log.NewNopLogger())
+	_, err = manager.Create(5)
+	require.ErrorIs(t, err, types.ErrUnknownFormat)
+}
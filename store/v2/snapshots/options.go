@@ -1,5 +1,7 @@
 package snapshots
 
+import "cosmossdk.io/store/v2/snapshots/types"
+
 // SnapshotOptions defines the snapshot strategy used when determining which
 // heights are snapshotted for state sync.
 type SnapshotOptions struct {
@@ -8,6 +10,15 @@ type SnapshotOptions struct {
 
 	// KeepRecent defines how many snapshots to keep in heights.
 	KeepRecent uint32
+
+	// Format defines the format of the snapshots taken, types.CurrentFormat if
+	// zero. The types.FormatSegmented format requires a SegmentSnapshotter.
+	Format uint32
+
+	// MaxDeltas defines how many incremental snapshots, in types.FormatDelta,
+	// are taken after each full snapshot. Incremental snapshots are disabled if
+	// 0, and require a DeltaSnapshotter.
+	MaxDeltas uint32
 }
 
 func NewSnapshotOptions(interval uint64, keepRecent uint32) SnapshotOptions {
@@ -16,3 +27,11 @@ func NewSnapshotOptions(interval uint64, keepRecent uint32) SnapshotOptions {
 		KeepRecent: keepRecent,
 	}
 }
+
+// format returns the format of the snapshots to take.
+func (o SnapshotOptions) format() uint32 {
+	if o.Format == 0 {
+		return types.CurrentFormat
+	}
+	return o.Format
+}
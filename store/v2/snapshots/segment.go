@@ -0,0 +1,387 @@
+package snapshots
+
+import (
+	"bytes"
+	"compress/zlib"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"hash"
+	"io"
+	"sync"
+
+	protoio "github.com/cosmos/gogoproto/io"
+	"golang.org/x/sync/errgroup"
+
+	corestore "cosmossdk.io/core/store"
+	errorsmod "cosmossdk.io/errors"
+	storeerrors "cosmossdk.io/store/v2/errors"
+	"cosmossdk.io/store/v2/snapshots/types"
+)
+
+// segmentHeader prefixes every chunk of a segmented snapshot. The snapshot is
+// the sequence of the segments of the store keys, in the order given by the
+// SegmentSnapshotter, followed by the segment of the extensions if any. The
+// payloads of the chunks of a segment form a stream of zlib-compressed delimited
+// protobuf SnapshotItems, as in the CurrentFormat snapshots.
+//
+// The header is encoded as:
+//
+//	uvarint(len(store)) || store || uvarint(index) || 0x00
+//	uvarint(len(store)) || store || uvarint(index) || 0x01 || hash
+//
+// the latter for the last chunk of the segment.
+type segmentHeader struct {
+	// Store is the store key of the segment, empty for the extensions segment.
+	Store string
+	// Index is the index of the chunk in the segment.
+	Index uint32
+	// Last is true for the last chunk of the segment.
+	Last bool
+	// Hash is the sha256 hash of the payloads of all the chunks of the segment,
+	// only set on the last chunk.
+	Hash []byte
+}
+
+func (h segmentHeader) marshal() []byte {
+	bz := make([]byte, 0, 2*binary.MaxVarintLen64+len(h.Store)+1+sha256.Size)
+	bz = binary.AppendUvarint(bz, uint64(len(h.Store)))
+	bz = append(bz, h.Store...)
+	bz = binary.AppendUvarint(bz, uint64(h.Index))
+	if !h.Last {
+		return append(bz, 0)
+	}
+	bz = append(bz, 1)
+	return append(bz, h.Hash...)
+}
+
+// unmarshalSegmentChunk decodes the header of a segment chunk and returns it
+// with the payload of the chunk.
+func unmarshalSegmentChunk(chunk []byte) (segmentHeader, []byte, error) {
+	var h segmentHeader
+	storeLen, n := binary.Uvarint(chunk)
+	if n <= 0 || uint64(len(chunk)-n) < storeLen {
+		return h, nil, errorsmod.Wrap(types.ErrInvalidMetadata, "invalid segment chunk header: bad store key")
+	}
+	h.Store = string(chunk[n : n+int(storeLen)])
+	chunk = chunk[n+int(storeLen):]
+
+	index, n := binary.Uvarint(chunk)
+	if n <= 0 || index > uint64(^uint32(0)) || len(chunk) == n {
+		return h, nil, errorsmod.Wrap(types.ErrInvalidMetadata, "invalid segment chunk header: bad index")
+	}
+	h.Index = uint32(index)
+	last := chunk[n]
+	chunk = chunk[n+1:]
+
+	switch last {
+	case 0:
+	case 1:
+		if len(chunk) < sha256.Size {
+			return h, nil, errorsmod.Wrap(types.ErrInvalidMetadata, "invalid segment chunk header: bad hash")
+		}
+		h.Last = true
+		h.Hash = chunk[:sha256.Size]
+		chunk = chunk[sha256.Size:]
+	default:
+		return h, nil, errorsmod.Wrap(types.ErrInvalidMetadata, "invalid segment chunk header: bad flag")
+	}
+
+	return h, chunk, nil
+}
+
+// segmentWriter splits the compressed stream of a segment into chunks of
+// snapshotChunkSize payload bytes, each prefixed with its segmentHeader.
+type segmentWriter struct {
+	ch     chan<- io.ReadCloser
+	store  string
+	buf    []byte
+	index  uint32
+	hasher hash.Hash
+}
+
+// Write implements io.Writer.
+func (w *segmentWriter) Write(p []byte) (int, error) {
+	n := len(p)
+	for len(p) > 0 {
+		// only flush a full chunk once there is more to write, so that the last
+		// chunk is never empty unless the segment is
+		if len(w.buf) == int(snapshotChunkSize) {
+			w.flush(false)
+		}
+		size := min(int(snapshotChunkSize)-len(w.buf), len(p))
+		w.buf = append(w.buf, p[:size]...)
+		p = p[size:]
+	}
+	return n, nil
+}
+
+// flush sends the buffered payload as a chunk.
+func (w *segmentWriter) flush(last bool) {
+	w.hasher.Write(w.buf)
+	header := segmentHeader{Store: w.store, Index: w.index, Last: last}
+	if last {
+		header.Hash = w.hasher.Sum(nil)
+	}
+	chunk := append(header.marshal(), w.buf...)
+	w.ch <- io.NopCloser(bytes.NewReader(chunk))
+	w.buf = w.buf[:0]
+	w.index++
+}
+
+// writeSegment writes the segment of the given store key, or of the extensions
+// if empty, with the items written by fn, to the chunk channel.
+func writeSegment(ch chan<- io.ReadCloser, store string, fn func(protoWriter protoio.Writer) error) error {
+	chunker := &segmentWriter{
+		ch:     ch,
+		store:  store,
+		buf:    make([]byte, 0, snapshotChunkSize),
+		hasher: sha256.New(),
+	}
+	zWriter, err := zlib.NewWriterLevel(chunker, snapshotCompressionLevel)
+	if err != nil {
+		return errorsmod.Wrap(err, "zlib failure")
+	}
+	protoWriter := protoio.NewDelimitedWriter(zWriter)
+	if err := fn(protoWriter); err != nil {
+		return err
+	}
+	// closes the zlib writer too
+	if err := protoWriter.Close(); err != nil {
+		return errorsmod.Wrap(err, "zlib failure")
+	}
+	chunker.flush(true)
+
+	return nil
+}
+
+// createSegmentedSnapshot writes a snapshot in the segmented format to the
+// chunk channel, one segment per store key followed by the extensions.
+func (m *Manager) createSegmentedSnapshot(height uint64, snapshotter SegmentSnapshotter, ch chan<- io.ReadCloser) {
+	defer close(ch)
+
+	err := func() error {
+		for _, storeKey := range snapshotter.SnapshotStoreKeys() {
+			if storeKey == "" {
+				return errorsmod.Wrap(storeerrors.ErrLogic, "empty store key")
+			}
+			err := writeSegment(ch, storeKey, func(protoWriter protoio.Writer) error {
+				return snapshotter.SnapshotSegment(height, storeKey, protoWriter)
+			})
+			if err != nil {
+				return errorsmod.Wrapf(err, "failed to snapshot store %s", storeKey)
+			}
+		}
+		if len(m.extensions) == 0 {
+			return nil
+		}
+		return writeSegment(ch, "", func(protoWriter protoio.Writer) error {
+			return m.snapshotExtensions(height, protoWriter)
+		})
+	}()
+	if err != nil {
+		// pass the error to the snapshot store through a failing chunk
+		pr, pw := io.Pipe()
+		ch <- pr
+		_ = pw.CloseWithError(err)
+	}
+}
+
+// segment is a segment being restored.
+type segment struct {
+	store  string
+	chunks chan io.ReadCloser
+	next   uint32
+	hasher hash.Hash
+}
+
+// doRestoreSegmentedSnapshot restores a snapshot in the segmented format.
+func (m *Manager) doRestoreSegmentedSnapshot(snapshot types.Snapshot, chChunks <-chan io.ReadCloser, restoreExtensions bool) error {
+	defer DrainChunks(chChunks)
+
+	snapshotter, ok := m.commitSnapshotter.(SegmentSnapshotter)
+	if !ok {
+		return errorsmod.Wrapf(types.ErrUnknownFormat, "commitment snapshotter does not support format %v", snapshot.Format)
+	}
+
+	chStorage, storageErrs := m.restoreStorage(snapshot.Height)
+	err := m.restoreSegments(snapshot.Height, snapshotter, chChunks, chStorage, restoreExtensions)
+	close(chStorage)
+	if err != nil {
+		return err
+	}
+
+	// wait for storage snapshotter to complete
+	if err := <-storageErrs; err != nil {
+		return errorsmod.Wrap(err, "storage snapshotter")
+	}
+
+	return nil
+}
+
+// restoreSegments dispatches the chunks to the restoration of their segment,
+// verifying the hash of each segment. The segments of the store keys are
+// restored concurrently, with at most m.restoreConcurrency running at the same
+// time, and the extensions once all of them are restored, if restoreExtensions
+// is true.
+func (m *Manager) restoreSegments(
+	height uint64,
+	snapshotter SegmentSnapshotter,
+	chChunks <-chan io.ReadCloser,
+	chStorage chan<- *corestore.StateChanges,
+	restoreExtensions bool,
+) error {
+	eg, ctx := errgroup.WithContext(context.Background())
+	if m.restoreConcurrency > 0 {
+		eg.SetLimit(m.restoreConcurrency)
+	}
+
+	var (
+		stores    sync.WaitGroup
+		current   *segment
+		restored  = make(map[string]bool)
+		finalized bool
+	)
+
+	// start starts the restoration of a new segment
+	start := func(store string) error {
+		if restored[""] {
+			return errorsmod.Wrap(types.ErrInvalidMetadata, "unexpected segment after the extensions")
+		}
+		if restored[store] {
+			return errorsmod.Wrapf(types.ErrInvalidMetadata, "duplicate segment %q", store)
+		}
+		restored[store] = true
+		seg := &segment{
+			store:  store,
+			chunks: make(chan io.ReadCloser, chunkBufferSize),
+			hasher: sha256.New(),
+		}
+		current = seg
+
+		if store == "" {
+			// the extensions are restored on top of the complete commitment state
+			stores.Wait()
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err := snapshotter.FinalizeRestore(height); err != nil {
+				return errorsmod.Wrap(err, "multistore restore")
+			}
+			finalized = true
+			eg.Go(func() error {
+				defer DrainChunks(seg.chunks)
+				if !restoreExtensions {
+					return nil
+				}
+				return m.restoreExtensionSegment(height, seg.chunks)
+			})
+			return nil
+		}
+
+		stores.Add(1)
+		eg.Go(func() error {
+			defer stores.Done()
+			defer DrainChunks(seg.chunks)
+			streamReader, err := NewStreamReader(seg.chunks)
+			if err != nil {
+				return err
+			}
+			defer streamReader.Close()
+			if err := snapshotter.RestoreSegment(height, store, streamReader, chStorage); err != nil {
+				return errorsmod.Wrapf(err, "store %s restore", store)
+			}
+			return nil
+		})
+		return nil
+	}
+
+	err := func() error {
+		for chunk := range chChunks {
+			bz, err := io.ReadAll(chunk)
+			_ = chunk.Close()
+			if err != nil {
+				return err
+			}
+			header, payload, err := unmarshalSegmentChunk(bz)
+			if err != nil {
+				return err
+			}
+
+			if current == nil {
+				if header.Index != 0 {
+					return errorsmod.Wrapf(types.ErrInvalidMetadata, "segment %q starts at chunk %d", header.Store, header.Index)
+				}
+				if err := start(header.Store); err != nil {
+					return err
+				}
+			} else if header.Store != current.store || header.Index != current.next {
+				return errorsmod.Wrapf(types.ErrInvalidMetadata, "expected chunk %d of segment %q, got chunk %d of segment %q",
+					current.next, current.store, header.Index, header.Store)
+			}
+
+			current.next++
+			current.hasher.Write(payload)
+			if header.Last {
+				if hash := current.hasher.Sum(nil); !bytes.Equal(hash, header.Hash) {
+					return errorsmod.Wrapf(types.ErrSegmentHashMismatch, "segment %q: expected %x, got %x", current.store, header.Hash, hash)
+				}
+			}
+
+			select {
+			case current.chunks <- io.NopCloser(bytes.NewReader(payload)):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+
+			if header.Last {
+				close(current.chunks)
+				current = nil
+			}
+		}
+
+		if current != nil {
+			return errorsmod.Wrapf(types.ErrInvalidMetadata, "segment %q is incomplete", current.store)
+		}
+		return nil
+	}()
+
+	// stop the restoration of an incomplete segment
+	if current != nil {
+		close(current.chunks)
+	}
+	werr := eg.Wait()
+	// the error of a failed restoration prevails over the cancellation it caused
+	if err != nil && !errors.Is(err, context.Canceled) {
+		return err
+	}
+	if werr != nil {
+		return werr
+	}
+
+	if !finalized {
+		if err := snapshotter.FinalizeRestore(height); err != nil {
+			return errorsmod.Wrap(err, "multistore restore")
+		}
+	}
+
+	return nil
+}
+
+// restoreExtensionSegment restores the extensions from the chunks of their
+// segment.
+func (m *Manager) restoreExtensionSegment(height uint64, chunks <-chan io.ReadCloser) error {
+	streamReader, err := NewStreamReader(chunks)
+	if err != nil {
+		return err
+	}
+	defer streamReader.Close()
+
+	var nextItem types.SnapshotItem
+	if err := streamReader.ReadMsg(&nextItem); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+
+	return m.restoreExtensions(height, streamReader, nextItem)
+}
@@ -51,3 +51,55 @@ type ExtensionSnapshotter interface {
 	// the payload reader returns `io.EOF` when reached the extension boundaries.
 	RestoreExtension(height uint64, format uint32, payloadReader ExtensionPayloadReader) error
 }
+
+// SegmentSnapshotter is implemented by the CommitSnapshotters which support the
+// segmented snapshot format, types.FormatSegmented, in which the commitment
+// state of each store key is written as an independent segment. The segments
+// are restored concurrently, so RestoreSegment must be safe for concurrent use
+// with distinct store keys.
+type SegmentSnapshotter interface {
+	CommitSnapshotter
+
+	// SnapshotStoreKeys returns the store keys to snapshot, one segment each.
+	SnapshotStoreKeys() []string
+
+	// SnapshotSegment writes a snapshot of the commitment state of the given
+	// store key at the given version.
+	SnapshotSegment(version uint64, storeKey string, protoWriter protoio.Writer) error
+
+	// RestoreSegment restores the commitment state of the given store key from
+	// the snapshot reader, until it returns io.EOF.
+	RestoreSegment(version uint64, storeKey string, protoReader protoio.Reader, chStorage chan<- *corestore.StateChanges) error
+
+	// FinalizeRestore completes the restoration once all the segments are
+	// restored.
+	FinalizeRestore(version uint64) error
+}
+
+// DeltaSnapshotter is implemented by the CommitSnapshotters which support
+// incremental snapshots, types.FormatDelta, which record the changes of each
+// version since a base version instead of the full commitment state.
+type DeltaSnapshotter interface {
+	CommitSnapshotter
+
+	// CanSnapshotDelta returns true if the changes of all the versions since
+	// the base version up to the given version are still available.
+	CanSnapshotDelta(base, version uint64) bool
+
+	// SnapshotDelta writes the changes of the versions in (base, version].
+	SnapshotDelta(base, version uint64, protoWriter protoio.Writer) error
+
+	// RestoreDelta applies the changes of each version read from the snapshot
+	// reader to the commitment state at the base version, and passes them to
+	// applyStorage once the version is committed.
+	RestoreDelta(base, version uint64, protoReader protoio.Reader, applyStorage func(version uint64, cs *corestore.Changeset) error) (types.SnapshotItem, error)
+}
+
+// DeltaStorageSnapshotter is implemented by the StorageSnapshotters which can
+// restore incremental snapshots, by applying the changeset of each version.
+type DeltaStorageSnapshotter interface {
+	StorageSnapshotter
+
+	// ApplyChangeset applies the given changeset at the given version.
+	ApplyChangeset(version uint64, cs *corestore.Changeset) error
+}
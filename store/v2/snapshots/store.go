@@ -124,6 +124,54 @@ func (s *Store) GetLatest() (*types.Snapshot, error) {
 	return snapshot, nil
 }
 
+// Chain returns the snapshots needed to restore the given snapshot, in order of restoration: the
+// full snapshot it is based on, followed by the incremental snapshots up to the snapshot itself.
+func (s *Store) Chain(snapshot *types.Snapshot) ([]*types.Snapshot, error) {
+	chain := []*types.Snapshot{snapshot}
+	for snapshot.Format == types.FormatDelta {
+		base, err := s.getAtHeight(snapshot.Metadata.BaseHeight)
+		if err != nil {
+			return nil, err
+		}
+		if base == nil {
+			return nil, errors.Wrapf(types.ErrInvalidMetadata,
+				"base snapshot at height %v of snapshot at height %v not found", snapshot.Metadata.BaseHeight, snapshot.Height)
+		}
+		if base.Height >= snapshot.Height {
+			return nil, errors.Wrapf(types.ErrInvalidMetadata,
+				"base snapshot height %v is not lower than snapshot height %v", base.Height, snapshot.Height)
+		}
+		chain = append(chain, base)
+		snapshot = base
+	}
+
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
+	}
+	return chain, nil
+}
+
+// getAtHeight fetches the snapshot at the given height, of the lowest format if there are several.
+func (s *Store) getAtHeight(height uint64) (*types.Snapshot, error) {
+	metadata, err := os.ReadDir(s.pathMetadataDir())
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list snapshot metadata")
+	}
+	// file system may not guarantee the order of the files, so we sort them lexically
+	sort.Slice(metadata, func(i, j int) bool { return metadata[i].Name() < metadata[j].Name() })
+
+	for _, entry := range metadata {
+		h, format, err := s.parseMetadataFilename(entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if h == height {
+			return s.Get(height, format)
+		}
+	}
+	return nil, nil
+}
+
 // List lists snapshots, in reverse order (newest first).
 func (s *Store) List() ([]*types.Snapshot, error) {
 	metadata, err := os.ReadDir(s.pathMetadataDir())
@@ -203,24 +251,39 @@ func (s *Store) loadChunkFile(height uint64, format, chunk uint32) (io.ReadClose
 	return os.Open(path)
 }
 
-// Prune removes old snapshots. The given number of most recent heights (regardless of format) are retained.
+// Prune removes old snapshots. The given number of most recent heights (regardless of format) are
+// retained, as well as the snapshots the incremental snapshots among them are based on.
 func (s *Store) Prune(retain uint32) (uint64, error) {
 	metadata, err := os.ReadDir(s.pathMetadataDir())
 	if err != nil {
 		return 0, errors.Wrap(err, "failed to list snapshot metadata")
 	}
+	// file system may not guarantee the order of the files, so we sort them lexically
+	sort.Slice(metadata, func(i, j int) bool { return metadata[i].Name() < metadata[j].Name() })
 
 	pruned := uint64(0)
 	prunedHeights := make(map[uint64]bool)
 	skip := make(map[uint64]bool)
+	bases := make(map[uint64]bool)
 	for i := len(metadata) - 1; i >= 0; i-- {
 		height, format, err := s.parseMetadataFilename(metadata[i].Name())
 		if err != nil {
 			return 0, err
 		}
 
-		if skip[height] || uint32(len(skip)) < retain {
+		if !skip[height] && uint32(len(skip)) < retain {
 			skip[height] = true
+		}
+		if skip[height] || bases[height] {
+			// Snapshots are iterated from the newest, so the base of a retained incremental
+			// snapshot is always seen after it.
+			if format == types.FormatDelta {
+				snapshot, err := s.Get(height, format)
+				if err != nil {
+					return 0, err
+				}
+				bases[snapshot.Metadata.BaseHeight] = true
+			}
 			continue
 		}
 		err = s.Delete(height, format)
@@ -246,6 +309,25 @@ func (s *Store) Prune(retain uint32) (uint64, error) {
 // Save saves a snapshot to disk, returning it.
 func (s *Store) Save(
 	height uint64, format uint32, chunks <-chan io.ReadCloser,
+) (*types.Snapshot, error) {
+	return s.save(height, format, 0, chunks)
+}
+
+// SaveDelta saves an incremental snapshot of the changes since the snapshot at the base height
+// to disk, returning it.
+func (s *Store) SaveDelta(
+	height, base uint64, chunks <-chan io.ReadCloser,
+) (*types.Snapshot, error) {
+	if base >= height {
+		DrainChunks(chunks)
+		return nil, errors.Wrapf(storeerrors.ErrLogic,
+			"incremental snapshot height %v must be greater than its base height %v", height, base)
+	}
+	return s.save(height, types.FormatDelta, base, chunks)
+}
+
+func (s *Store) save(
+	height uint64, format uint32, base uint64, chunks <-chan io.ReadCloser,
 ) (*types.Snapshot, error) {
 	defer DrainChunks(chunks)
 	if height == 0 {
@@ -269,6 +351,9 @@ func (s *Store) Save(
 	snapshot := &types.Snapshot{
 		Height: height,
 		Format: format,
+		Metadata: types.Metadata{
+			BaseHeight: base,
+		},
 	}
 
 	// create height directory or do nothing
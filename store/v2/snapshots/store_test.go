@@ -261,6 +261,56 @@ func TestStore_Prune(t *testing.T) {
 	assert.Empty(t, snapshots)
 }
 
+func TestStore_PruneDelta(t *testing.T) {
+	store := setupStore(t)
+	for _, heights := range [][2]uint64{{4, 3}, {5, 4}, {6, 5}} {
+		_, err := store.SaveDelta(heights[0], heights[1], makeChunks([][]byte{{byte(heights[0]), 5, 0}}))
+		require.NoError(t, err)
+	}
+	_, err := store.Save(7, 2, makeChunks([][]byte{{7, 2, 0}}))
+	require.NoError(t, err)
+	_, err = store.SaveDelta(8, 7, makeChunks([][]byte{{8, 5, 0}}))
+	require.NoError(t, err)
+
+	// the base of an incremental snapshot must be lower than it
+	_, err = store.SaveDelta(9, 9, makeChunks([][]byte{{9, 5, 0}}))
+	require.Error(t, err)
+
+	snapshot, err := store.Get(6, types.FormatDelta)
+	require.NoError(t, err)
+	chain, err := store.Chain(snapshot)
+	require.NoError(t, err)
+	heights := []uint64{}
+	for _, snapshot := range chain {
+		heights = append(heights, snapshot.Height)
+	}
+	assert.Equal(t, []uint64{3, 4, 5, 6}, heights)
+
+	// the heights of the chain of a retained incremental snapshot are retained
+	pruned, err := store.Prune(3)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, pruned)
+
+	snapshots, err := store.List()
+	require.NoError(t, err)
+	heights = []uint64{}
+	for _, snapshot := range snapshots {
+		heights = append(heights, snapshot.Height)
+	}
+	assert.Equal(t, []uint64{8, 7, 6, 5, 4, 3}, heights)
+
+	pruned, err = store.Prune(2)
+	require.NoError(t, err)
+	assert.EqualValues(t, 4, pruned)
+
+	// an incremental snapshot whose base was deleted can't be restored
+	require.NoError(t, store.Delete(7, 2))
+	snapshot, err = store.Get(8, types.FormatDelta)
+	require.NoError(t, err)
+	_, err = store.Chain(snapshot)
+	require.ErrorIs(t, err, types.ErrInvalidMetadata)
+}
+
 func TestStore_Save(t *testing.T) {
 	t.Parallel()
 	store := setupStore(t)
@@ -11,6 +11,13 @@ var (
 	// ErrChunkHashMismatch is returned when chunk hash verification failed.
 	ErrChunkHashMismatch = errors.New("chunk hash verification failed")
 
+	// ErrSegmentHashMismatch is returned when segment hash verification failed.
+	ErrSegmentHashMismatch = errors.New("segment hash verification failed")
+
+	// ErrDeltaHashMismatch is returned when the state restored from an incremental snapshot
+	// does not match the commit hash recorded in the snapshot.
+	ErrDeltaHashMismatch = errors.New("incremental snapshot hash verification failed")
+
 	// ErrInvalidMetadata is returned when the snapshot metadata is invalid.
 	ErrInvalidMetadata = errors.New("invalid snapshot metadata")
 
@@ -4,3 +4,19 @@ package types
 // must be identical across all nodes for a given height, so this must be bumped when the binary
 // snapshot output changes.
 const CurrentFormat uint32 = 3
+
+// FormatSegmented is the format of the snapshots in which the commitment state of each store
+// key is written as an independently restorable segment, so that the segments can be restored
+// concurrently. Every chunk belongs to a single segment and is prefixed with a header which
+// identifies it, and the last chunk of a segment carries the hash of the whole segment.
+const FormatSegmented uint32 = 4
+
+// FormatDelta is the format of incremental snapshots, which record the changes of each version
+// since the snapshot at Metadata.BaseHeight instead of the full state. They can only be restored
+// on top of the state of their base snapshot.
+const FormatDelta uint32 = 5
+
+// IsSupportedFormat returns true if snapshots of the given format can be restored.
+func IsSupportedFormat(format uint32) bool {
+	return format == CurrentFormat || format == FormatSegmented || format == FormatDelta
+}
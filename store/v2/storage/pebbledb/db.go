@@ -18,7 +18,7 @@ import (
 
 const (
 	VersionSize = 8
-	// PruneCommitBatchSize defines the size, in number of key/value pairs, to prune
+	// PruneCommitBatchSize defines the size, in number of keys, to prune
 	// in a single batch.
 	PruneCommitBatchSize = 50
 
@@ -52,6 +52,9 @@ type Database struct {
 func New(dataDir string) (*Database, error) {
 	opts := &pebble.Options{
 		Comparer: MVCCComparer,
+		// block properties are only written from this format major version onwards
+		FormatMajorVersion:      pebble.FormatBlockPropertyCollector,
+		BlockPropertyCollectors: []func() pebble.BlockPropertyCollector{newVersionIntervalCollector},
 	}
 	opts = opts.EnsureDefaults()
 
@@ -189,14 +192,27 @@ func (db *Database) Get(storeKey []byte, targetVersion uint64, key []byte) ([]by
 
 // Prune removes all versions of all keys that are <= the given version.
 //
-// Note, the implementation of this method is inefficient and can be potentially
-// time consuming given the size of the database and when the last pruning occurred
-// (if any). This is because the implementation iterates over all keys in the
-// database in order to delete them.
+// Rather than scanning the entire database, only the blocks and tables holding
+// versions written since the previous prune height are read, which is possible
+// thanks to the version interval property collected for every block and table.
+// For every key that has been written in that range, all of its versions that
+// are shadowed by its latest version <= the prune height, including the latest
+// one if it is a tombstone, are removed with a single range deletion. The space
+// is reclaimed by compactions, which drop whole tables covered by range
+// deletions without reading them. Pruning cost is thus proportional to the
+// amount of data written since the last prune, not to the size of the database.
 //
 // See: https://github.com/cockroachdb/cockroach/blob/33623e3ee420174a4fd3226d1284b03f0e3caaac/pkg/storage/mvcc.go#L3182
 func (db *Database) Prune(version uint64) error {
-	itr, err := db.storage.NewIter(&pebble.IterOptions{LowerBound: []byte("s/k:")})
+	if version < db.earliestVersion {
+		// already pruned
+		return nil
+	}
+
+	itr, err := db.storage.NewIter(&pebble.IterOptions{
+		LowerBound:      []byte("s/k:"),
+		PointKeyFilters: []pebble.BlockPropertyFilter{newVersionIntervalFilter(db.earliestVersion, version+1)},
+	})
 	if err != nil {
 		return err
 	}
@@ -206,17 +222,46 @@ func (db *Database) Prune(version uint64) error {
 	defer batch.Close()
 
 	var (
-		batchCounter                              int
-		prevKey, prevKeyPrefixed, prevPrefixedVal []byte
-		prevKeyVersion                            uint64
+		batchCounter   int
+		prevKey        []byte
+		prevKeyVersion uint64
+		prevTombstoned bool
 	)
 
-	for itr.First(); itr.Valid(); {
-		prefixedKey := slices.Clone(itr.Key())
+	// pruneKey deletes all the versions of prevKey which are shadowed by its
+	// latest version <= the prune height, and that version too if it is a tombstone.
+	pruneKey := func() error {
+		// keys which were not written since the last prune have already been pruned
+		if prevKey == nil || prevKeyVersion < db.earliestVersion {
+			return nil
+		}
+
+		end := prevKeyVersion
+		if prevTombstoned {
+			end++
+		}
+
+		if err := batch.DeleteRange(MVCCEncode(prevKey, 0), MVCCEncode(prevKey, end), nil); err != nil {
+			return err
+		}
+
+		batchCounter++
+		if batchCounter >= PruneCommitBatchSize {
+			if err := batch.Commit(&pebble.WriteOptions{Sync: db.sync}); err != nil {
+				return err
+			}
+
+			batchCounter = 0
+			batch.Reset()
+		}
 
-		keyBz, verBz, ok := SplitMVCCKey(prefixedKey)
+		return nil
+	}
+
+	for itr.First(); itr.Valid(); {
+		keyBz, verBz, ok := SplitMVCCKey(itr.Key())
 		if !ok {
-			return fmt.Errorf("invalid PebbleDB MVCC key: %s", prefixedKey)
+			return fmt.Errorf("invalid PebbleDB MVCC key: %s", itr.Key())
 		}
 
 		keyVersion, err := decodeUint64Ascending(verBz)
@@ -230,33 +275,23 @@ func (db *Database) Prune(version uint64) error {
 			continue
 		}
 
-		// Delete a key if another entry for that key exists a larger version than
-		// the original but <= to the prune height. We also delete a key if it has
-		// been tombstoned and its version is <= to the prune height.
-		if prevKeyVersion <= version && (bytes.Equal(prevKey, keyBz) || valTombstoned(prevPrefixedVal)) {
-			if err := batch.Delete(prevKeyPrefixed, nil); err != nil {
+		if !bytes.Equal(prevKey, keyBz) {
+			if err := pruneKey(); err != nil {
 				return err
 			}
-
-			batchCounter++
-			if batchCounter >= PruneCommitBatchSize {
-				if err := batch.Commit(&pebble.WriteOptions{Sync: db.sync}); err != nil {
-					return err
-				}
-
-				batchCounter = 0
-				batch.Reset()
-			}
 		}
 
 		prevKey = keyBz
 		prevKeyVersion = keyVersion
-		prevKeyPrefixed = prefixedKey
-		prevPrefixedVal = slices.Clone(itr.Value())
+		prevTombstoned = valTombstoned(itr.Value())
 
 		itr.Next()
 	}
 
+	if err := pruneKey(); err != nil {
+		return err
+	}
+
 	// commit any leftover delete ops in batch
 	if batchCounter > 0 {
 		if err := batch.Commit(&pebble.WriteOptions{Sync: db.sync}); err != nil {
@@ -0,0 +1,75 @@
+package pebbledb
+
+import (
+	"encoding/binary"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/cockroachdb/pebble/sstable"
+)
+
+// versionIntervalPropertyName is the name of the block property that records
+// the interval of MVCC versions contained in each data block and SSTable.
+const versionIntervalPropertyName = "ss_pebbledb_version_interval"
+
+// newVersionIntervalCollector returns a block property collector which records
+// the [lower, upper) interval of the MVCC versions of the keys written to each
+// block and table. It allows iterators to skip, using
+// newVersionIntervalFilter, all blocks and tables which do not contain any key
+// in a given version range without reading them.
+func newVersionIntervalCollector() pebble.BlockPropertyCollector {
+	return sstable.NewBlockIntervalCollector(versionIntervalPropertyName, &versionIntervalCollector{}, nil)
+}
+
+// newVersionIntervalFilter returns a block property filter which only keeps the
+// blocks and tables that may contain keys with a version in [lower, upper).
+// Tables written without the property, e.g. by a database opened without the
+// collector, are never skipped.
+func newVersionIntervalFilter(lower, upper uint64) pebble.BlockPropertyFilter {
+	return sstable.NewBlockIntervalFilter(versionIntervalPropertyName, lower, upper)
+}
+
+var _ sstable.DataBlockIntervalCollector = (*versionIntervalCollector)(nil)
+
+type versionIntervalCollector struct {
+	lower, upper uint64
+}
+
+func (c *versionIntervalCollector) Add(key sstable.InternalKey, _ []byte) error {
+	version, ok := mvccKeyVersion(key.UserKey)
+	if !ok {
+		// keys which are not versioned, such as the latest version and prune
+		// height keys, do not contribute to the interval
+		return nil
+	}
+
+	if c.lower == c.upper {
+		c.lower, c.upper = version, version+1
+		return nil
+	}
+
+	if version < c.lower {
+		c.lower = version
+	}
+	if version >= c.upper {
+		c.upper = version + 1
+	}
+
+	return nil
+}
+
+func (c *versionIntervalCollector) FinishDataBlock() (lower, upper uint64, err error) {
+	lower, upper = c.lower, c.upper
+	c.lower, c.upper = 0, 0
+	return lower, upper, nil
+}
+
+// mvccKeyVersion returns the version of an MVCC key without copying it, or
+// false if the key has no version.
+func mvccKeyVersion(key []byte) (uint64, bool) {
+	n := len(key) - 1
+	if n < 0 || int(key[n]) != 1+VersionSize || n < 1+VersionSize {
+		return 0, false
+	}
+
+	return binary.BigEndian.Uint64(key[n-VersionSize : n]), true
+}
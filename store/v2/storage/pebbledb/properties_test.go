@@ -0,0 +1,106 @@
+package pebbledb
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/cockroachdb/pebble"
+	"github.com/stretchr/testify/require"
+)
+
+// rawVersions returns the versions physically stored for every key of the store.
+func rawVersions(t *testing.T, db *Database, storeKey []byte, filters ...pebble.BlockPropertyFilter) map[string][]uint64 {
+	t.Helper()
+
+	itr, err := db.storage.NewIter(&pebble.IterOptions{LowerBound: []byte("s/k:"), PointKeyFilters: filters})
+	require.NoError(t, err)
+	defer itr.Close()
+
+	res := map[string][]uint64{}
+	for itr.First(); itr.Valid(); itr.Next() {
+		key, verBz, ok := SplitMVCCKey(itr.Key())
+		require.True(t, ok)
+		version, err := decodeUint64Ascending(verBz)
+		require.NoError(t, err)
+
+		k := string(key[len(storePrefix(storeKey)):])
+		res[k] = append(res[k], version)
+	}
+	require.NoError(t, itr.Error())
+
+	return res
+}
+
+func TestPrune_RangeDeletions(t *testing.T) {
+	dir := t.TempDir()
+	db, err := New(dir)
+	require.NoError(t, err)
+	db.SetSync(false)
+
+	storeKey := []byte("store1")
+
+	// "a" is written at every version, "b" is deleted at version 5 and "c" is only
+	// written at version 1
+	for v := uint64(1); v <= 10; v++ {
+		b, err := db.NewBatch(v)
+		require.NoError(t, err)
+		require.NoError(t, b.Set(storeKey, []byte("a"), []byte(fmt.Sprintf("a%d", v))))
+		switch {
+		case v < 5:
+			require.NoError(t, b.Set(storeKey, []byte("b"), []byte(fmt.Sprintf("b%d", v))))
+		case v == 5:
+			require.NoError(t, b.Delete(storeKey, []byte("b")))
+		}
+		if v == 1 {
+			require.NoError(t, b.Set(storeKey, []byte("c"), []byte("c1")))
+		}
+		require.NoError(t, b.Write())
+
+		// spread the versions over several tables
+		if v%3 == 0 {
+			require.NoError(t, db.storage.Flush())
+		}
+	}
+	require.NoError(t, db.storage.Flush())
+
+	// the version interval property allows skipping the tables without any key
+	// in the requested range
+	require.Empty(t, rawVersions(t, db, storeKey, newVersionIntervalFilter(11, 20)))
+
+	require.NoError(t, db.Prune(7))
+	require.NoError(t, db.storage.Compact([]byte("s/k:"), []byte("s/k;"), false))
+	require.Equal(t, map[string][]uint64{
+		"a": {7, 8, 9, 10},
+		"c": {1},
+	}, rawVersions(t, db, storeKey))
+
+	val, err := db.Get(storeKey, 8, []byte("c"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("c1"), val)
+	val, err = db.Get(storeKey, 8, []byte("b"))
+	require.NoError(t, err)
+	require.Nil(t, val)
+
+	// pruning an already pruned version is a no-op
+	require.NoError(t, db.Prune(3))
+	_, err = db.Get(storeKey, 7, []byte("a"))
+	require.Error(t, err)
+
+	require.NoError(t, db.Prune(9))
+	require.Equal(t, map[string][]uint64{
+		"a": {9, 10},
+		"c": {1},
+	}, rawVersions(t, db, storeKey))
+
+	// the prune height is persisted
+	require.NoError(t, db.Close())
+	db, err = New(dir)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = db.Get(storeKey, 9, []byte("a"))
+	require.Error(t, err)
+	val, err = db.Get(storeKey, 10, []byte("a"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("a10"), val)
+}
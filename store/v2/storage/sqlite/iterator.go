@@ -86,15 +86,12 @@ func newIterator(db *Database, storeKey []byte, targetVersion uint64, start, end
 		valid:     rows.Next(),
 	}
 	if !itr.valid {
-		itr.err = fmt.Errorf("iterator invalid: %w", sql.ErrNoRows)
+		// an empty domain is not an error, the iterator is just exhausted
 		return itr, nil
 	}
 
 	// read the first row
 	itr.parseRow()
-	if !itr.valid {
-		return itr, nil
-	}
 
 	return itr, nil
 }
@@ -154,8 +151,10 @@ func (itr *iterator) Next() {
 }
 
 func (itr *iterator) Error() error {
-	if err := itr.rows.Err(); err != nil {
-		return err
+	if itr.rows != nil {
+		if err := itr.rows.Err(); err != nil {
+			return err
+		}
 	}
 
 	return itr.err
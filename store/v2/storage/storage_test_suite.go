@@ -346,6 +346,41 @@ func (s *StorageTestSuite) TestDatabase_Iterator_RangedDeletes() {
 	s.Require().NoError(itr.Error())
 }
 
+func (s *StorageTestSuite) TestDatabase_Iterator_FirstKeyDeleted() {
+	db, err := s.NewDB(s.T().TempDir())
+	s.Require().NoError(err)
+	defer db.Close()
+
+	s.Require().NoError(db.ApplyChangeset(1, corestore.NewChangesetWithPairs(
+		map[string]corestore.KVPairs{
+			storeKey1: {
+				{Key: []byte("key001"), Value: []byte("value001"), Remove: false},
+				{Key: []byte("key002"), Value: []byte("value002"), Remove: false},
+			},
+		},
+	)))
+
+	s.Require().NoError(db.ApplyChangeset(2, corestore.NewChangesetWithPairs(
+		map[string]corestore.KVPairs{
+			storeKey1: {{Key: []byte("key001"), Remove: true}},
+		},
+	)))
+
+	itr, err := db.Iterator(storeKey1Bytes, 2, nil, nil)
+	s.Require().NoError(err)
+
+	defer itr.Close()
+
+	// the deleted key001 must be skipped even though it is the first key
+	var count int
+	for ; itr.Valid(); itr.Next() {
+		s.Require().Equal([]byte("key002"), itr.Key())
+		count++
+	}
+	s.Require().Equal(1, count)
+	s.Require().NoError(itr.Error())
+}
+
 func (s *StorageTestSuite) TestDatabase_IteratorMultiVersion() {
 	db, err := s.NewDB(s.T().TempDir())
 	s.Require().NoError(err)
@@ -31,6 +31,16 @@ type RootStore interface {
 	// and key tuple. Queries should be routed to the underlying SS engine.
 	Query(storeKey []byte, version uint64, key []byte, prove bool) (QueryResult, error)
 
+	// QueryKeys is analogous to Query but queries several keys at once. If prove
+	// is true, a single proof of all the keys, or of their absence, is returned.
+	QueryKeys(storeKey []byte, version uint64, keys [][]byte, prove bool) (MultiQueryResult, error)
+
+	// QueryRange returns, in ascending key order, the key/value pairs of the store
+	// in the range [start, end), with at most limit pairs if limit is positive. If
+	// prove is true, a proof that the pairs are all the pairs of the range, or of
+	// the page of the range, is returned.
+	QueryRange(storeKey []byte, version uint64, start, end []byte, limit int, prove bool) (RangeQueryResult, error)
+
 	// LoadVersion loads the RootStore to the given version.
 	LoadVersion(version uint64) error
 
@@ -107,3 +117,22 @@ type QueryResult struct {
 	Version  uint64
 	ProofOps []proof.CommitmentOp
 }
+
+// MultiQueryResult defines the response type to performing a multi-key query on
+// a RootStore. The pairs are in the order of the queried keys, with a nil value
+// for the absent keys.
+type MultiQueryResult struct {
+	Pairs   []corestore.KVPair
+	Version uint64
+	Proof   *proof.MultiProof
+}
+
+// RangeQueryResult defines the response type to performing a range query on a
+// RootStore. NextKey is the first key of the next page of the range, or nil if
+// the range is complete.
+type RangeQueryResult struct {
+	Pairs   []corestore.KVPair
+	NextKey []byte
+	Version uint64
+	Proof   *proof.RangeProof
+}
@@ -8,6 +8,7 @@ import (
 	"cosmossdk.io/collections"
 	collcodec "cosmossdk.io/collections/codec"
 	"cosmossdk.io/math"
+	"cosmossdk.io/schema"
 )
 
 var (
@@ -209,6 +210,16 @@ func (i intValueCodec) ValueType() string {
 	return Int
 }
 
+// SchemaCodec implements collcodec.HasSchemaCodec by representing the value as an integer.
+func (i intValueCodec) SchemaCodec() (collcodec.SchemaCodec[math.Int], error) {
+	return collcodec.SchemaCodec[math.Int]{
+		Fields: []schema.Field{{Kind: schema.IntegerKind}},
+		ToSchemaType: func(value math.Int) (any, error) {
+			return value.String(), nil
+		},
+	}, nil
+}
+
 type uintValueCodec struct{}
 
 func (i uintValueCodec) Encode(value math.Uint) ([]byte, error) {
@@ -245,6 +256,16 @@ func (i uintValueCodec) ValueType() string {
 	return Uint
 }
 
+// SchemaCodec implements collcodec.HasSchemaCodec by representing the value as an integer.
+func (i uintValueCodec) SchemaCodec() (collcodec.SchemaCodec[math.Uint], error) {
+	return collcodec.SchemaCodec[math.Uint]{
+		Fields: []schema.Field{{Kind: schema.IntegerKind}},
+		ToSchemaType: func(value math.Uint) (any, error) {
+			return value.String(), nil
+		},
+	}, nil
+}
+
 type timeKeyCodec struct{}
 
 func (timeKeyCodec) Encode(buffer []byte, key time.Time) (int, error) {
@@ -0,0 +1,426 @@
+package mempool
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/huandu/skiplist"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	_ Mempool     = (*FeeMarketMempool)(nil)
+	_ Snapshotter = (*FeeMarketMempool)(nil)
+	_ Iterator    = (*feeMarketIterator)(nil)
+)
+
+type (
+	// FeeMarketMempoolConfig defines the configuration used to configure the
+	// FeeMarketMempool.
+	FeeMarketMempoolConfig struct {
+		// TxFeeRate returns the fee per gas of a transaction, by which transactions
+		// are selected and evicted.
+		TxFeeRate func(ctx context.Context, tx sdk.Tx) (math.LegacyDec, error)
+
+		// MaxTx is the maximum number of transactions in the mempool, 0 for no
+		// limit.
+		MaxTx int
+
+		// MaxBytes is the maximum total size in bytes of the transactions in the
+		// mempool, 0 for no limit.
+		MaxBytes int64
+
+		// MinReplacementBump is the minimum increase, in percent, of the fee per
+		// gas of a transaction replacing the transaction of the same sender and
+		// nonce, e.g. 10 for a replacement paying at least 10% more per gas.
+		MinReplacementBump uint64
+
+		// TxTTL is the number of blocks after which a transaction expires, 0 for
+		// no expiration.
+		TxTTL int64
+
+		// SignerExtractor is an implementation which retrieves signer data from a sdk.Tx
+		SignerExtractor SignerExtractionAdapter
+	}
+
+	// FeeMarketMempool is a size-bounded mempool which selects transactions by
+	// fee per gas, the highest first, and by nonce within a sender. When full,
+	// the transactions with the lowest fee per gas are evicted in favor of the
+	// ones paying more. A transaction can be replaced by a transaction of the
+	// same sender and nonce paying a minimum bump of fee per gas, and expires
+	// after a number of blocks.
+	//
+	// A transaction evicted or expired is removed along with the later
+	// transactions of its sender, which cannot be included without it.
+	FeeMarketMempool struct {
+		mtx         sync.Mutex
+		cfg         FeeMarketMempoolConfig
+		txs         map[txKey]*feeMarketTx
+		senders     map[string]*skiplist.SkipList
+		feeIndex    *skiplist.SkipList
+		expiryIndex *skiplist.SkipList
+		bytes       int64
+		seq         uint64
+	}
+
+	// feeMarketTx is a transaction of the FeeMarketMempool along with its
+	// metadata.
+	feeMarketTx struct {
+		tx      sdk.Tx
+		key     txKey
+		feeRate math.LegacyDec
+		size    int64
+		height  int64
+		// seq is the insertion sequence of the transaction, which breaks the ties
+		// of fee rates and heights.
+		seq uint64
+	}
+)
+
+// NewDefaultTxFeeRate returns a fee rate function computing the fee per gas of
+// sdk.FeeTx transactions in the given denom.
+func NewDefaultTxFeeRate(denom string) func(context.Context, sdk.Tx) (math.LegacyDec, error) {
+	return func(_ context.Context, tx sdk.Tx) (math.LegacyDec, error) {
+		feeTx, ok := tx.(sdk.FeeTx)
+		if !ok {
+			return math.LegacyDec{}, fmt.Errorf("tx of type %T does not implement FeeTx", tx)
+		}
+		gas := feeTx.GetGas()
+		if gas == 0 {
+			return math.LegacyDec{}, fmt.Errorf("tx must have a gas limit")
+		}
+		return math.LegacyNewDecFromInt(feeTx.GetFee().AmountOf(denom)).QuoInt(math.NewIntFromUint64(gas)), nil
+	}
+}
+
+// DefaultFeeMarketMempoolConfig returns the configuration of a mempool without
+// limits, selecting transactions by their fee per gas in the given denom and
+// requiring replacements to pay 10% more per gas.
+func DefaultFeeMarketMempoolConfig(denom string) FeeMarketMempoolConfig {
+	return FeeMarketMempoolConfig{
+		TxFeeRate:          NewDefaultTxFeeRate(denom),
+		MinReplacementBump: 10,
+		SignerExtractor:    NewDefaultSignerExtractionAdapter(),
+	}
+}
+
+// NewFeeMarketMempool returns a new FeeMarketMempool.
+func NewFeeMarketMempool(cfg FeeMarketMempoolConfig) *FeeMarketMempool {
+	if cfg.SignerExtractor == nil {
+		cfg.SignerExtractor = NewDefaultSignerExtractionAdapter()
+	}
+	return &FeeMarketMempool{
+		cfg:     cfg,
+		txs:     make(map[txKey]*feeMarketTx),
+		senders: make(map[string]*skiplist.SkipList),
+		// the front of the fee index is the next transaction to evict: the lowest
+		// fee rate, the latest inserted first
+		feeIndex: skiplist.New(skiplist.GreaterThanFunc(func(a, b any) int {
+			ta, tb := a.(*feeMarketTx), b.(*feeMarketTx)
+			if !ta.feeRate.Equal(tb.feeRate) {
+				if ta.feeRate.LT(tb.feeRate) {
+					return -1
+				}
+				return 1
+			}
+			return skiplist.Uint64.Compare(tb.seq, ta.seq)
+		})),
+		expiryIndex: skiplist.New(skiplist.GreaterThanFunc(func(a, b any) int {
+			ta, tb := a.(*feeMarketTx), b.(*feeMarketTx)
+			if res := skiplist.Int64.Compare(ta.height, tb.height); res != 0 {
+				return res
+			}
+			return skiplist.Uint64.Compare(ta.seq, tb.seq)
+		})),
+	}
+}
+
+// Insert attempts to insert a Tx into the mempool, returning an error if
+// unsuccessful. Sender and nonce are derived from the transaction's first
+// signature, and the current block height from the sdk.Context of ctx.
+//
+// A transaction with the sender and nonce of a transaction in the mempool
+// replaces it if its fee per gas is higher by at least the minimum replacement
+// bump, else ErrTxReplacementUnderpriced is returned. When the mempool is full,
+// transactions with a lower fee per gas are evicted to make room, and if not
+// enough of them can be evicted ErrMempoolTxMaxCapacity is returned.
+func (mp *FeeMarketMempool) Insert(ctx context.Context, tx sdk.Tx) error {
+	sigs, err := mp.cfg.SignerExtractor.GetSigners(tx)
+	if err != nil {
+		return err
+	}
+	if len(sigs) == 0 {
+		return fmt.Errorf("tx must have at least one signer")
+	}
+	feeRate, err := mp.cfg.TxFeeRate(ctx, tx)
+	if err != nil {
+		return err
+	}
+	height := sdk.UnwrapSDKContext(ctx).BlockHeight()
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	mp.expire(height)
+
+	mtx := &feeMarketTx{
+		tx:      tx,
+		key:     txKey{address: sigs[0].Signer.String(), nonce: sigs[0].Sequence},
+		feeRate: feeRate,
+		size:    int64(len(tx.Bytes())),
+		height:  height,
+	}
+	if mp.cfg.MaxBytes > 0 && mtx.size > mp.cfg.MaxBytes {
+		return fmt.Errorf("%w: tx of %d bytes exceeds the max bytes %d", ErrMempoolTxMaxCapacity, mtx.size, mp.cfg.MaxBytes)
+	}
+
+	replaced, ok := mp.txs[mtx.key]
+	if ok {
+		minFeeRate := replaced.feeRate.MulInt64(int64(100 + mp.cfg.MinReplacementBump)).QuoInt64(100)
+		if feeRate.LT(minFeeRate) {
+			return fmt.Errorf("%w: fee per gas %s, want at least %s", ErrTxReplacementUnderpriced, feeRate, minFeeRate)
+		}
+		mp.remove(replaced)
+	}
+
+	evicted, err := mp.evictionSet(mtx)
+	if err != nil {
+		if replaced != nil {
+			mp.insert(replaced)
+		}
+		return err
+	}
+	for _, e := range evicted {
+		mp.remove(e)
+	}
+
+	mp.seq++
+	mtx.seq = mp.seq
+	mp.insert(mtx)
+	return nil
+}
+
+// evictionSet returns the transactions to evict to make room for the given
+// transaction, the lowest fee rates first along with the later transactions of
+// their senders. An error is returned if the transaction pays too little to make
+// room for it.
+func (mp *FeeMarketMempool) evictionSet(mtx *feeMarketTx) ([]*feeMarketTx, error) {
+	count, bytes := len(mp.txs)+1, mp.bytes+mtx.size
+	var evicted []*feeMarketTx
+	seen := make(map[txKey]bool)
+	for e := mp.feeIndex.Front(); mp.exceeds(count, bytes); e = e.Next() {
+		if e == nil {
+			return nil, ErrMempoolTxMaxCapacity
+		}
+		lowest := e.Value.(*feeMarketTx)
+		if seen[lowest.key] {
+			continue
+		}
+		if !mtx.feeRate.GT(lowest.feeRate) {
+			return nil, fmt.Errorf("%w: fee per gas %s must exceed %s", ErrMempoolTxMaxCapacity, mtx.feeRate, lowest.feeRate)
+		}
+		if lowest.key.address == mtx.key.address && lowest.key.nonce < mtx.key.nonce {
+			// the tx cannot be included without the evicted one
+			return nil, ErrMempoolTxMaxCapacity
+		}
+
+		for s := mp.senders[lowest.key.address].Get(lowest.key.nonce); s != nil; s = s.Next() {
+			t := s.Value.(*feeMarketTx)
+			if seen[t.key] {
+				continue
+			}
+			seen[t.key] = true
+			evicted = append(evicted, t)
+			count--
+			bytes -= t.size
+		}
+	}
+	return evicted, nil
+}
+
+func (mp *FeeMarketMempool) exceeds(count int, bytes int64) bool {
+	return (mp.cfg.MaxTx > 0 && count > mp.cfg.MaxTx) || (mp.cfg.MaxBytes > 0 && bytes > mp.cfg.MaxBytes)
+}
+
+// expire removes the transactions expired at the given height, along with the
+// later transactions of their senders.
+func (mp *FeeMarketMempool) expire(height int64) {
+	if mp.cfg.TxTTL <= 0 {
+		return
+	}
+	for e := mp.expiryIndex.Front(); e != nil; e = mp.expiryIndex.Front() {
+		oldest := e.Value.(*feeMarketTx)
+		if oldest.height+mp.cfg.TxTTL > height {
+			return
+		}
+		mp.removeWithSuccessors(oldest)
+	}
+}
+
+func (mp *FeeMarketMempool) removeWithSuccessors(mtx *feeMarketTx) {
+	var txs []*feeMarketTx
+	for s := mp.senders[mtx.key.address].Get(mtx.key.nonce); s != nil; s = s.Next() {
+		txs = append(txs, s.Value.(*feeMarketTx))
+	}
+	for _, t := range txs {
+		mp.remove(t)
+	}
+}
+
+func (mp *FeeMarketMempool) insert(mtx *feeMarketTx) {
+	senderTxs, ok := mp.senders[mtx.key.address]
+	if !ok {
+		senderTxs = skiplist.New(skiplist.Uint64)
+		mp.senders[mtx.key.address] = senderTxs
+	}
+	senderTxs.Set(mtx.key.nonce, mtx)
+	mp.feeIndex.Set(mtx, mtx)
+	mp.expiryIndex.Set(mtx, mtx)
+	mp.txs[mtx.key] = mtx
+	mp.bytes += mtx.size
+}
+
+func (mp *FeeMarketMempool) remove(mtx *feeMarketTx) {
+	senderTxs := mp.senders[mtx.key.address]
+	senderTxs.Remove(mtx.key.nonce)
+	if senderTxs.Len() == 0 {
+		delete(mp.senders, mtx.key.address)
+	}
+	mp.feeIndex.Remove(mtx)
+	mp.expiryIndex.Remove(mtx)
+	delete(mp.txs, mtx.key)
+	mp.bytes -= mtx.size
+}
+
+// Select returns an iterator over the transactions of the mempool, by fee per
+// gas, the highest first, and by nonce within a sender: the next transaction is
+// the one with the highest fee per gas among the next transactions of each
+// sender. The expired transactions are removed first, the current block height
+// being read from the sdk.Context of ctx. The passed in list of transactions
+// are ignored.
+//
+// NOTE: It is not safe to use this iterator while removing transactions from
+// the underlying mempool.
+func (mp *FeeMarketMempool) Select(ctx context.Context, _ [][]byte) Iterator {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	mp.expire(sdk.UnwrapSDKContext(ctx).BlockHeight())
+	return mp.doSelect()
+}
+
+// Snapshot implements Snapshotter, copying the transactions in the order of
+// Select. The expired transactions are not removed, and the ones Select has not
+// removed yet are copied.
+func (mp *FeeMarketMempool) Snapshot(_ context.Context) (Iterator, error) {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	return newSnapshotIterator(appendSnapshot(nil, mp.doSelect())), nil
+}
+
+// doSelect returns an iterator over the transactions of the mempool. The caller
+// must hold the lock.
+func (mp *FeeMarketMempool) doSelect() Iterator {
+	if len(mp.txs) == 0 {
+		return nil
+	}
+
+	iterator := &feeMarketIterator{}
+	for _, senderTxs := range mp.senders {
+		iterator.heads = append(iterator.heads, senderTxs.Front())
+	}
+	heap.Init(&iterator.heads)
+	return iterator.Next()
+}
+
+// CountTx returns the number of transactions in the mempool.
+func (mp *FeeMarketMempool) CountTx() int {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	return len(mp.txs)
+}
+
+// TotalBytes returns the total size in bytes of the transactions in the mempool.
+func (mp *FeeMarketMempool) TotalBytes() int64 {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	return mp.bytes
+}
+
+// Remove removes a transaction from the mempool, returning an error if the
+// transaction has no signer or was not found in the mempool. The later
+// transactions of its sender are kept.
+func (mp *FeeMarketMempool) Remove(tx sdk.Tx) error {
+	sigs, err := mp.cfg.SignerExtractor.GetSigners(tx)
+	if err != nil {
+		return err
+	}
+	if len(sigs) == 0 {
+		return fmt.Errorf("attempted to remove a tx with no signatures")
+	}
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	mtx, ok := mp.txs[txKey{address: sigs[0].Signer.String(), nonce: sigs[0].Sequence}]
+	if !ok {
+		return ErrTxNotFound
+	}
+	mp.remove(mtx)
+	return nil
+}
+
+// feeMarketIterator iterates over the transactions of a FeeMarketMempool, the
+// next one being the one with the highest fee per gas among the next
+// transactions of each sender.
+type feeMarketIterator struct {
+	heads   senderHeads
+	current *feeMarketTx
+}
+
+func (i *feeMarketIterator) Next() Iterator {
+	if len(i.heads) == 0 {
+		return nil
+	}
+
+	head := i.heads[0]
+	i.current = head.Value.(*feeMarketTx)
+	if next := head.Next(); next != nil {
+		i.heads[0] = next
+		heap.Fix(&i.heads, 0)
+	} else {
+		heap.Pop(&i.heads)
+	}
+	return i
+}
+
+func (i *feeMarketIterator) Tx() sdk.Tx {
+	return i.current.tx
+}
+
+// senderHeads is a max heap of the next transactions of each sender, by fee
+// per gas and then by insertion order.
+type senderHeads []*skiplist.Element
+
+func (h senderHeads) Len() int { return len(h) }
+
+func (h senderHeads) Less(i, j int) bool {
+	ti, tj := h[i].Value.(*feeMarketTx), h[j].Value.(*feeMarketTx)
+	if !ti.feeRate.Equal(tj.feeRate) {
+		return ti.feeRate.GT(tj.feeRate)
+	}
+	return ti.seq < tj.seq
+}
+
+func (h senderHeads) Swap(i, j int) { h[i], h[j] = h[j], h[i] }
+
+func (h *senderHeads) Push(x any) { *h = append(*h, x.(*skiplist.Element)) }
+
+func (h *senderHeads) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
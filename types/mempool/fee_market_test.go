@@ -0,0 +1,139 @@
+package mempool_test
+
+import (
+	"context"
+	"math/rand"
+
+	"cosmossdk.io/core/log"
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+// testFeeMarketConfig returns a config using the priority of the test txs as
+// their fee per gas.
+func testFeeMarketConfig() mempool.FeeMarketMempoolConfig {
+	return mempool.FeeMarketMempoolConfig{
+		TxFeeRate: func(_ context.Context, tx sdk.Tx) (math.LegacyDec, error) {
+			return math.LegacyNewDec(tx.(testTx).priority), nil
+		},
+		MinReplacementBump: 10,
+	}
+}
+
+func selectIDs(ctx context.Context, mp mempool.Mempool) []int {
+	var ids []int
+	for it := mp.Select(ctx, nil); it != nil; it = it.Next() {
+		ids = append(ids, it.Tx().(testTx).id)
+	}
+	return ids
+}
+
+func (s *MempoolTestSuite) TestFeeMarketTxOrder() {
+	ctx := sdk.NewContext(nil, false, log.NewNopLogger())
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 3)
+	sa, sb, sc := accounts[0].Address, accounts[1].Address, accounts[2].Address
+
+	mp := mempool.NewFeeMarketMempool(testFeeMarketConfig())
+	txs := []testTx{
+		{id: 0, priority: 5, nonce: 1, address: sa},
+		{id: 1, priority: 20, nonce: 2, address: sa},
+		{id: 2, priority: 10, nonce: 1, address: sb},
+		{id: 3, priority: 1, nonce: 2, address: sb},
+		{id: 4, priority: 10, nonce: 7, address: sc},
+		{id: 5, priority: 3, nonce: 8, address: sc},
+	}
+	for _, tx := range txs {
+		s.Require().NoError(mp.Insert(ctx, tx))
+	}
+	s.Require().Equal(len(txs), mp.CountTx())
+
+	// by fee per gas among the next txs of each sender, by insertion order on ties
+	s.Require().Equal([]int{2, 4, 0, 1, 5, 3}, selectIDs(ctx, mp))
+
+	s.Require().NoError(mp.Remove(txs[2]))
+	s.Require().ErrorIs(mp.Remove(txs[2]), mempool.ErrTxNotFound)
+	s.Require().Equal([]int{4, 0, 1, 5, 3}, selectIDs(ctx, mp))
+	s.Require().Equal(len(txs)-1, mp.CountTx())
+}
+
+func (s *MempoolTestSuite) TestFeeMarketReplacement() {
+	ctx := sdk.NewContext(nil, false, log.NewNopLogger())
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 1)
+	sa := accounts[0].Address
+
+	mp := mempool.NewFeeMarketMempool(testFeeMarketConfig())
+	s.Require().NoError(mp.Insert(ctx, testTx{id: 0, priority: 100, nonce: 1, address: sa}))
+	s.Require().NoError(mp.Insert(ctx, testTx{id: 1, priority: 100, nonce: 2, address: sa}))
+
+	// the replacement must pay at least 10% more per gas
+	err := mp.Insert(ctx, testTx{id: 2, priority: 109, nonce: 1, address: sa})
+	s.Require().ErrorIs(err, mempool.ErrTxReplacementUnderpriced)
+	s.Require().Equal([]int{0, 1}, selectIDs(ctx, mp))
+
+	s.Require().NoError(mp.Insert(ctx, testTx{id: 3, priority: 110, nonce: 1, address: sa}))
+	s.Require().Equal(2, mp.CountTx())
+	s.Require().Equal([]int{3, 1}, selectIDs(ctx, mp))
+}
+
+func (s *MempoolTestSuite) TestFeeMarketEviction() {
+	ctx := sdk.NewContext(nil, false, log.NewNopLogger())
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 3)
+	sa, sb, sc := accounts[0].Address, accounts[1].Address, accounts[2].Address
+
+	cfg := testFeeMarketConfig()
+	cfg.MaxTx = 4
+	cfg.MaxBytes = 100
+	mp := mempool.NewFeeMarketMempool(cfg)
+	s.Require().NoError(mp.Insert(ctx, testTx{id: 0, priority: 5, nonce: 1, address: sa, size: 10}))
+	s.Require().NoError(mp.Insert(ctx, testTx{id: 1, priority: 50, nonce: 2, address: sa, size: 10}))
+	s.Require().NoError(mp.Insert(ctx, testTx{id: 2, priority: 10, nonce: 1, address: sb, size: 10}))
+	s.Require().NoError(mp.Insert(ctx, testTx{id: 3, priority: 20, nonce: 1, address: sc, size: 10}))
+
+	// the lowest fee per gas is 5, which must be exceeded
+	err := mp.Insert(ctx, testTx{id: 4, priority: 5, nonce: 2, address: sc, size: 10})
+	s.Require().ErrorIs(err, mempool.ErrMempoolTxMaxCapacity)
+
+	// the tx of fee per gas 5 is evicted along with the later tx of its sender
+	s.Require().NoError(mp.Insert(ctx, testTx{id: 5, priority: 6, nonce: 2, address: sc, size: 10}))
+	s.Require().Equal([]int{3, 2, 5}, selectIDs(ctx, mp))
+	s.Require().Equal(int64(30), mp.TotalBytes())
+
+	// a tx larger than the max bytes is rejected
+	err = mp.Insert(ctx, testTx{id: 6, priority: 100, nonce: 3, address: sc, size: 101})
+	s.Require().ErrorIs(err, mempool.ErrMempoolTxMaxCapacity)
+
+	// the txs are evicted by fee per gas until the new tx fits
+	s.Require().NoError(mp.Insert(ctx, testTx{id: 7, priority: 15, nonce: 1, address: sa, size: 85}))
+	s.Require().Equal([]int{3, 7}, selectIDs(ctx, mp))
+	s.Require().Equal(int64(95), mp.TotalBytes())
+
+	// a tx is not evicted for a later tx of its sender
+	err = mp.Insert(ctx, testTx{id: 8, priority: 100, nonce: 2, address: sa, size: 10})
+	s.Require().ErrorIs(err, mempool.ErrMempoolTxMaxCapacity)
+	s.Require().Equal([]int{3, 7}, selectIDs(ctx, mp))
+}
+
+func (s *MempoolTestSuite) TestFeeMarketExpiration() {
+	ctx := sdk.NewContext(nil, false, log.NewNopLogger()).WithBlockHeight(1)
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 2)
+	sa, sb := accounts[0].Address, accounts[1].Address
+
+	cfg := testFeeMarketConfig()
+	cfg.TxTTL = 2
+	mp := mempool.NewFeeMarketMempool(cfg)
+	s.Require().NoError(mp.Insert(ctx, testTx{id: 0, priority: 10, nonce: 1, address: sa}))
+	s.Require().NoError(mp.Insert(ctx.WithBlockHeight(2), testTx{id: 1, priority: 10, nonce: 2, address: sa}))
+	s.Require().NoError(mp.Insert(ctx.WithBlockHeight(2), testTx{id: 2, priority: 5, nonce: 1, address: sb}))
+
+	s.Require().Equal([]int{0, 1, 2}, selectIDs(ctx.WithBlockHeight(2), mp))
+
+	// the tx inserted at height 1 expires along with the later tx of its sender
+	s.Require().Equal([]int{2}, selectIDs(ctx.WithBlockHeight(3), mp))
+	s.Require().Equal(1, mp.CountTx())
+
+	s.Require().Nil(mp.Select(ctx.WithBlockHeight(4), nil))
+	s.Require().Equal(0, mp.CountTx())
+}
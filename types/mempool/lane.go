@@ -0,0 +1,186 @@
+package mempool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	_ Mempool     = (*LaneMempool)(nil)
+	_ Snapshotter = (*LaneMempool)(nil)
+	_ Iterator    = (*laneIterator)(nil)
+)
+
+// ErrNoMatchingLane is returned when inserting a transaction matched by none of
+// the lanes of a LaneMempool.
+var ErrNoMatchingLane = errors.New("no lane matches the tx")
+
+// Lane is a mempool of the transactions it matches, whose transactions can use
+// a share of the space of a block.
+type Lane struct {
+	// Name is the name of the lane.
+	Name string
+
+	// Mempool holds the transactions of the lane.
+	Mempool Mempool
+
+	// Match reports whether a transaction belongs to the lane. A nil Match
+	// matches all transactions.
+	Match func(ctx context.Context, tx sdk.Tx) bool
+
+	// MaxTxBytesShare is the maximum share, in (0, 1], of the max tx bytes of a
+	// block the transactions of the lane can use. Nil for no maximum.
+	MaxTxBytesShare math.LegacyDec
+
+	// MaxGasShare is the maximum share, in (0, 1], of the max gas of a block the
+	// transactions of the lane can use. Nil for no maximum.
+	MaxGasShare math.LegacyDec
+}
+
+// LaneMempool is a mempool made of lanes, ordered by priority. A transaction
+// belongs to the first lane matching it, and the transactions are selected by
+// lane, the transactions of the first lane first, each lane ordering its own
+// transactions.
+type LaneMempool struct {
+	lanes []Lane
+}
+
+// NewLaneMempool returns a new LaneMempool made of the given lanes, by
+// priority. The last lane typically matches all transactions.
+func NewLaneMempool(lanes ...Lane) (*LaneMempool, error) {
+	if len(lanes) == 0 {
+		return nil, errors.New("lane mempool must have at least one lane")
+	}
+
+	names := make(map[string]bool, len(lanes))
+	for _, lane := range lanes {
+		if lane.Name == "" {
+			return nil, errors.New("lane must have a name")
+		}
+		if names[lane.Name] {
+			return nil, fmt.Errorf("duplicate lane %s", lane.Name)
+		}
+		names[lane.Name] = true
+
+		if lane.Mempool == nil {
+			return nil, fmt.Errorf("lane %s must have a mempool", lane.Name)
+		}
+		for _, share := range []math.LegacyDec{lane.MaxTxBytesShare, lane.MaxGasShare} {
+			if !share.IsNil() && (!share.IsPositive() || share.GT(math.LegacyOneDec())) {
+				return nil, fmt.Errorf("lane %s share %s must be in (0, 1]", lane.Name, share)
+			}
+		}
+	}
+
+	return &LaneMempool{lanes: lanes}, nil
+}
+
+// Lanes returns the lanes of the mempool, by priority.
+func (mp *LaneMempool) Lanes() []Lane {
+	return mp.lanes
+}
+
+// LaneIndex returns the index of the lane a transaction belongs to, or -1 if no
+// lane matches it.
+func (mp *LaneMempool) LaneIndex(ctx context.Context, tx sdk.Tx) int {
+	for i, lane := range mp.lanes {
+		if lane.Match == nil || lane.Match(ctx, tx) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Insert inserts a transaction into the mempool of the lane it belongs to.
+func (mp *LaneMempool) Insert(ctx context.Context, tx sdk.Tx) error {
+	i := mp.LaneIndex(ctx, tx)
+	if i < 0 {
+		return ErrNoMatchingLane
+	}
+	return mp.lanes[i].Mempool.Insert(ctx, tx)
+}
+
+// Select returns an iterator over the transactions of the lanes, lane by lane
+// by priority.
+func (mp *LaneMempool) Select(ctx context.Context, txs [][]byte) Iterator {
+	iterator := &laneIterator{ctx: ctx, txs: txs, lanes: mp.lanes, lane: -1}
+	return iterator.nextLane()
+}
+
+// Snapshot implements Snapshotter, copying the transactions of the lanes lane by
+// lane. The mempools of all the lanes must be Snapshotters.
+func (mp *LaneMempool) Snapshot(ctx context.Context) (Iterator, error) {
+	var txs []snapshotTx
+	for _, lane := range mp.lanes {
+		snapshotter, ok := lane.Mempool.(Snapshotter)
+		if !ok {
+			return nil, fmt.Errorf("mempool of lane %s of type %T cannot be snapshotted", lane.Name, lane.Mempool)
+		}
+		it, err := snapshotter.Snapshot(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to snapshot lane %s: %w", lane.Name, err)
+		}
+		// the iterator is over a copy, which can be read without a lock
+		txs = appendSnapshot(txs, it)
+	}
+	return newSnapshotIterator(txs), nil
+}
+
+// CountTx returns the number of transactions in all the lanes.
+func (mp *LaneMempool) CountTx() int {
+	count := 0
+	for _, lane := range mp.lanes {
+		count += lane.Mempool.CountTx()
+	}
+	return count
+}
+
+// Remove removes a transaction from the lane holding it. As the lane of a
+// transaction can depend on the state, the transaction is looked up in all the
+// lanes.
+func (mp *LaneMempool) Remove(tx sdk.Tx) error {
+	for _, lane := range mp.lanes {
+		err := lane.Mempool.Remove(tx)
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, ErrTxNotFound) {
+			return err
+		}
+	}
+	return ErrTxNotFound
+}
+
+// laneIterator iterates over the transactions of the lanes, lane by lane.
+type laneIterator struct {
+	ctx   context.Context
+	txs   [][]byte
+	lanes []Lane
+	lane  int
+	iter  Iterator
+}
+
+func (i *laneIterator) nextLane() Iterator {
+	for i.lane++; i.lane < len(i.lanes); i.lane++ {
+		if i.iter = i.lanes[i.lane].Mempool.Select(i.ctx, i.txs); i.iter != nil {
+			return i
+		}
+	}
+	return nil
+}
+
+func (i *laneIterator) Next() Iterator {
+	if i.iter = i.iter.Next(); i.iter != nil {
+		return i
+	}
+	return i.nextLane()
+}
+
+func (i *laneIterator) Tx() sdk.Tx {
+	return i.iter.Tx()
+}
@@ -0,0 +1,78 @@
+package mempool_test
+
+import (
+	"context"
+	"math/rand"
+
+	"cosmossdk.io/core/log"
+	"cosmossdk.io/math"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+func (s *MempoolTestSuite) TestLaneMempool() {
+	ctx := sdk.NewContext(nil, false, log.NewNopLogger())
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 2)
+	system, user := accounts[0].Address, accounts[1].Address
+
+	systemLane := mempool.Lane{
+		Name:    "system",
+		Mempool: mempool.DefaultPriorityMempool(),
+		Match: func(_ context.Context, tx sdk.Tx) bool {
+			return tx.(testTx).address.Equals(system)
+		},
+		MaxTxBytesShare: math.LegacyNewDecWithPrec(2, 1),
+	}
+	defaultLane := mempool.Lane{Name: "default", Mempool: mempool.DefaultPriorityMempool()}
+	mp, err := mempool.NewLaneMempool(systemLane, defaultLane)
+	s.Require().NoError(err)
+
+	txs := []testTx{
+		{id: 0, nonce: 1, address: user},
+		{id: 1, nonce: 1, address: system},
+		{id: 2, nonce: 2, address: user},
+		{id: 3, nonce: 2, address: system},
+	}
+	for _, tx := range txs {
+		s.Require().NoError(mp.Insert(ctx, tx))
+	}
+	s.Require().Equal(4, mp.CountTx())
+	s.Require().Equal(2, systemLane.Mempool.CountTx())
+	s.Require().Equal(0, mp.LaneIndex(ctx, txs[1]))
+	s.Require().Equal(1, mp.LaneIndex(ctx, txs[0]))
+
+	// the txs of the first lane come first
+	s.Require().Equal([]int{1, 3, 0, 2}, selectIDs(ctx, mp))
+
+	s.Require().NoError(mp.Remove(txs[1]))
+	s.Require().ErrorIs(mp.Remove(txs[1]), mempool.ErrTxNotFound)
+	s.Require().NoError(mp.Remove(txs[3]))
+	s.Require().Equal([]int{0, 2}, selectIDs(ctx, mp))
+
+	// a tx matched by no lane is rejected
+	mp, err = mempool.NewLaneMempool(systemLane)
+	s.Require().NoError(err)
+	s.Require().ErrorIs(mp.Insert(ctx, txs[0]), mempool.ErrNoMatchingLane)
+	s.Require().Nil(mp.Select(ctx, nil))
+}
+
+func (s *MempoolTestSuite) TestNewLaneMempool() {
+	lane := mempool.Lane{Name: "default", Mempool: mempool.DefaultPriorityMempool()}
+
+	_, err := mempool.NewLaneMempool()
+	s.Require().Error(err)
+	_, err = mempool.NewLaneMempool(lane, lane)
+	s.Require().ErrorContains(err, "duplicate lane")
+	_, err = mempool.NewLaneMempool(mempool.Lane{Name: "default"})
+	s.Require().ErrorContains(err, "must have a mempool")
+
+	invalid := lane
+	invalid.MaxGasShare = math.LegacyNewDecWithPrec(11, 1)
+	_, err = mempool.NewLaneMempool(invalid)
+	s.Require().ErrorContains(err, "must be in (0, 1]")
+	invalid.MaxGasShare = math.LegacyZeroDec()
+	_, err = mempool.NewLaneMempool(invalid)
+	s.Require().ErrorContains(err, "must be in (0, 1]")
+}
@@ -37,7 +37,22 @@ type Iterator interface {
 	Tx() sdk.Tx
 }
 
+// Snapshotter is implemented by the mempools which can copy their transactions
+// while holding their lock, so that they can be read, e.g. by queries, while
+// transactions are inserted and removed. Unlike Select, Snapshot must not change
+// the transactions of the mempool, e.g. by expiring them.
+type Snapshotter interface {
+	// Snapshot returns an iterator over a copy of the transactions of the
+	// mempool, nil if it is empty. The iterator implements
+	// interface{ Priority() int64 }, the priority being 0 if the mempool does
+	// not prioritize its transactions by an int64.
+	Snapshot(context.Context) (Iterator, error)
+}
+
 var (
 	ErrTxNotFound           = errors.New("tx not found in mempool")
 	ErrMempoolTxMaxCapacity = errors.New("pool reached max tx capacity")
+	// ErrTxReplacementUnderpriced is returned when a tx replacing the tx of the
+	// same sender and nonce does not pay enough more.
+	ErrTxReplacementUnderpriced = errors.New("replacement tx underpriced")
 )
@@ -52,6 +52,7 @@ type testTx struct {
 	priority int64
 	nonce    uint64
 	address  sdk.AccAddress
+	size     int
 	// useful for debugging
 	strAddress string
 }
@@ -77,7 +78,7 @@ var (
 )
 
 func (tx testTx) Bytes() []byte {
-	return []byte{}
+	return make([]byte, tx.size)
 }
 
 func (tx testTx) Hash() [32]byte {
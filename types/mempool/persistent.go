@@ -0,0 +1,468 @@
+package mempool
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	_ Mempool     = (*PersistentMempool)(nil)
+	_ Snapshotter = (*PersistentMempool)(nil)
+)
+
+// The operations of the records of the write-ahead file.
+const (
+	recordInsert byte = iota + 1
+	recordRemove
+)
+
+// minCompactRecords is the minimum number of records of the write-ahead file
+// before it is compacted.
+const minCompactRecords = 1000
+
+// PersistentMempoolConfig defines the configuration of a PersistentMempool.
+type PersistentMempoolConfig struct {
+	// Path is the path of the write-ahead file.
+	Path string
+
+	// SignerExtractor extracts the signer and nonce identifying the
+	// transactions. Defaults to the DefaultSignerExtractionAdapter.
+	SignerExtractor SignerExtractionAdapter
+}
+
+// PersistentMempool wraps a mempool to persist its transactions to a write-ahead
+// file, so that they are restored when the node restarts.
+//
+// The transactions inserted and removed are appended to the file, which is
+// compacted once most of its records are obsolete. The transactions are
+// identified by their first signer and its nonce, as in the other mempools, and
+// the transaction bytes are read from the sdk.Context given to Insert, which
+// BaseApp sets in CheckTx.
+//
+// The transactions the wrapped mempool drops by itself, such as the ones it
+// evicts when it is full or removes along with the transactions they depend
+// on, are recorded as removed on the next Insert or Remove which finds that it
+// holds fewer transactions than recorded, and the file is compacted to the
+// transactions it holds on Close.
+//
+// The file is not synced on each write, so a crash of the node loses no
+// transaction but a crash of the machine can lose the last ones.
+type PersistentMempool struct {
+	Mempool
+
+	path            string
+	signerExtractor SignerExtractionAdapter
+
+	mtx       sync.Mutex
+	file      *os.File
+	records   int
+	txs       map[txKey][]byte
+	restoring bool
+}
+
+// NewPersistentMempool returns the given mempool persisted to the write-ahead
+// file of the config. The transactions of an existing file are kept until they
+// are re-inserted with Restore.
+func NewPersistentMempool(mp Mempool, cfg PersistentMempoolConfig) (*PersistentMempool, error) {
+	if cfg.Path == "" {
+		return nil, errors.New("persistent mempool must have a path")
+	}
+	if cfg.SignerExtractor == nil {
+		cfg.SignerExtractor = NewDefaultSignerExtractionAdapter()
+	}
+
+	txs, err := readWriteAheadFile(cfg.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the mempool write-ahead file %s: %w", cfg.Path, err)
+	}
+
+	pmp := &PersistentMempool{
+		Mempool:         mp,
+		path:            cfg.Path,
+		signerExtractor: cfg.SignerExtractor,
+		txs:             txs,
+	}
+	// rewriting the file also drops a record truncated by a crash
+	if err := pmp.compact(); err != nil {
+		return nil, err
+	}
+	return pmp, nil
+}
+
+// Insert inserts a transaction into the wrapped mempool and appends it to the
+// write-ahead file. The transaction is not kept in the wrapped mempool if it
+// cannot be appended.
+func (mp *PersistentMempool) Insert(ctx context.Context, tx sdk.Tx) error {
+	sdkCtx, ok := sdk.TryUnwrapSDKContext(ctx)
+	if !ok {
+		return errors.New("persistent mempool requires an sdk context")
+	}
+	txBz := sdkCtx.TxBytes()
+	if len(txBz) == 0 {
+		return errors.New("persistent mempool requires the tx bytes in the context")
+	}
+	key, err := mp.txKey(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := mp.Mempool.Insert(ctx, tx); err != nil {
+		return err
+	}
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	prev, replaced := mp.txs[key]
+	mp.txs[key] = txBz
+	if err := mp.appendRecord(encodeRecord(recordInsert, key, txBz)); err != nil {
+		// the tx is rejected, so it is removed from the wrapped mempool again.
+		// The tx it replaced, if any, is not re-inserted but stays in the file,
+		// which may hold more txs than the mempool but never less.
+		if replaced {
+			mp.txs[key] = prev
+		} else {
+			delete(mp.txs, key)
+		}
+		if removeErr := mp.Mempool.Remove(tx); removeErr != nil {
+			return errors.Join(err, removeErr)
+		}
+		return err
+	}
+	return mp.removeDropped(ctx)
+}
+
+// Remove removes a transaction from the wrapped mempool and records its removal
+// in the write-ahead file.
+func (mp *PersistentMempool) Remove(tx sdk.Tx) error {
+	key, err := mp.txKey(tx)
+	if err != nil {
+		return err
+	}
+
+	if err := mp.Mempool.Remove(tx); err != nil {
+		return err
+	}
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	if _, ok := mp.txs[key]; !ok {
+		return nil
+	}
+	delete(mp.txs, key)
+	if err := mp.appendRecord(encodeRecord(recordRemove, key, nil)); err != nil {
+		return err
+	}
+	return mp.removeDropped(context.Background())
+}
+
+// Snapshot implements Snapshotter for the wrapped mempool, which must be a
+// Snapshotter.
+func (mp *PersistentMempool) Snapshot(ctx context.Context) (Iterator, error) {
+	snapshotter, ok := mp.Mempool.(Snapshotter)
+	if !ok {
+		return nil, fmt.Errorf("mempool of type %T cannot be snapshotted", mp.Mempool)
+	}
+	return snapshotter.Snapshot(ctx)
+}
+
+// Restore re-inserts the transactions of the write-ahead file with the given
+// function, typically running CheckTx, which inserts them into the mempool
+// again. The transactions for which it fails, which are no longer valid, are
+// dropped. The transactions of each signer are re-inserted by nonce.
+func (mp *PersistentMempool) Restore(insert func(txBz []byte) error) (restored, dropped int, err error) {
+	mp.mtx.Lock()
+	pending := sortedTxs(mp.txs)
+	mp.txs = make(map[txKey][]byte)
+	mp.restoring = true
+	mp.mtx.Unlock()
+
+	// the records of the pending txs are kept until the file is compacted, so
+	// that a crash while restoring them loses none
+	for _, txBz := range pending {
+		if err := insert(txBz); err != nil {
+			dropped++
+			continue
+		}
+		restored++
+	}
+
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	mp.restoring = false
+	return restored, dropped, mp.compact()
+}
+
+// Close compacts the write-ahead file to the transactions the wrapped mempool
+// holds, and closes it. It is safe to call it multiple times.
+func (mp *PersistentMempool) Close() error {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	if mp.file == nil {
+		return nil
+	}
+
+	held, err := mp.heldTxKeys(context.Background())
+	if err == nil {
+		for key := range mp.txs {
+			if _, ok := held[key]; !ok {
+				delete(mp.txs, key)
+			}
+		}
+		err = mp.compact()
+	}
+	return errors.Join(err, mp.closeFile())
+}
+
+// closeFile closes the write-ahead file. The caller must hold the lock.
+func (mp *PersistentMempool) closeFile() error {
+	if mp.file == nil {
+		return nil
+	}
+	err := mp.file.Close()
+	mp.file = nil
+	return err
+}
+
+// removeDropped records the removal of the transactions the wrapped mempool no
+// longer holds, if it holds fewer transactions than recorded. The caller must
+// hold the lock.
+func (mp *PersistentMempool) removeDropped(ctx context.Context) error {
+	if mp.Mempool.CountTx() >= len(mp.txs) {
+		return nil
+	}
+	held, err := mp.heldTxKeys(ctx)
+	if err != nil {
+		return err
+	}
+	for key := range mp.txs {
+		if _, ok := held[key]; ok {
+			continue
+		}
+		delete(mp.txs, key)
+		if err := mp.appendRecord(encodeRecord(recordRemove, key, nil)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// heldTxKeys returns the keys of the transactions the wrapped mempool holds,
+// read from a snapshot of it if it is a Snapshotter, else from Select.
+func (mp *PersistentMempool) heldTxKeys(ctx context.Context) (map[txKey]struct{}, error) {
+	var (
+		it  Iterator
+		err error
+	)
+	if snapshotter, ok := mp.Mempool.(Snapshotter); ok {
+		if it, err = snapshotter.Snapshot(ctx); err != nil {
+			return nil, err
+		}
+	} else {
+		it = mp.Mempool.Select(ctx, nil)
+	}
+
+	held := make(map[txKey]struct{})
+	for ; it != nil; it = it.Next() {
+		key, err := mp.txKey(it.Tx())
+		if err != nil {
+			return nil, err
+		}
+		held[key] = struct{}{}
+	}
+	return held, nil
+}
+
+func (mp *PersistentMempool) txKey(tx sdk.Tx) (txKey, error) {
+	sigs, err := mp.signerExtractor.GetSigners(tx)
+	if err != nil {
+		return txKey{}, err
+	}
+	if len(sigs) == 0 {
+		return txKey{}, errors.New("tx must have at least one signer")
+	}
+	return txKey{address: sigs[0].Signer.String(), nonce: sigs[0].Sequence}, nil
+}
+
+// appendRecord appends a record to the write-ahead file, and compacts the file
+// once most of its records are obsolete. The caller must hold the lock.
+func (mp *PersistentMempool) appendRecord(record []byte) error {
+	if mp.file == nil {
+		return errors.New("persistent mempool is closed")
+	}
+	if _, err := mp.file.Write(record); err != nil {
+		return fmt.Errorf("failed to write to the mempool write-ahead file: %w", err)
+	}
+	mp.records++
+
+	if !mp.restoring && mp.records >= minCompactRecords && mp.records > 2*len(mp.txs) {
+		return mp.compact()
+	}
+	return nil
+}
+
+// compact rewrites the write-ahead file with the insertion records of the
+// current transactions only. The caller must hold the lock.
+func (mp *PersistentMempool) compact() error {
+	tmpPath := mp.path + ".tmp"
+	tmp, err := os.Create(tmpPath)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(tmp)
+	for key, txBz := range mp.txs {
+		if _, err := w.Write(encodeRecord(recordInsert, key, txBz)); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if mp.file != nil {
+		if err := mp.file.Close(); err != nil {
+			return err
+		}
+		mp.file = nil
+	}
+	if err := os.Rename(tmpPath, mp.path); err != nil {
+		return err
+	}
+	mp.file, err = os.OpenFile(mp.path, os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return err
+	}
+	mp.records = len(mp.txs)
+	return nil
+}
+
+// encodeRecord encodes a record of the write-ahead file: the operation, the
+// length prefixed signer, its nonce and, for insertions, the length prefixed
+// transaction bytes.
+func encodeRecord(op byte, key txKey, txBz []byte) []byte {
+	record := []byte{op}
+	record = binary.AppendUvarint(record, uint64(len(key.address)))
+	record = append(record, key.address...)
+	record = binary.AppendUvarint(record, key.nonce)
+	if op == recordInsert {
+		record = binary.AppendUvarint(record, uint64(len(txBz)))
+		record = append(record, txBz...)
+	}
+	return record
+}
+
+// readWriteAheadFile returns the transactions of a write-ahead file, once its
+// records are applied. A missing file holds no transaction, and a record
+// truncated by a crash is ignored.
+func readWriteAheadFile(path string) (map[txKey][]byte, error) {
+	txs := make(map[txKey][]byte)
+	f, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return txs, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for {
+		op, key, txBz, err := decodeRecord(r)
+		if errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF) {
+			return txs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case recordInsert:
+			txs[key] = txBz
+		case recordRemove:
+			delete(txs, key)
+		}
+	}
+}
+
+func decodeRecord(r *bufio.Reader) (op byte, key txKey, txBz []byte, err error) {
+	op, err = r.ReadByte()
+	if err != nil {
+		return 0, txKey{}, nil, err
+	}
+	if op != recordInsert && op != recordRemove {
+		return 0, txKey{}, nil, fmt.Errorf("invalid record operation %d", op)
+	}
+
+	address, err := readLengthPrefixed(r)
+	if err != nil {
+		return 0, txKey{}, nil, unexpectedEOF(err)
+	}
+	key.address = string(address)
+	if key.nonce, err = binary.ReadUvarint(r); err != nil {
+		return 0, txKey{}, nil, unexpectedEOF(err)
+	}
+	if op == recordInsert {
+		if txBz, err = readLengthPrefixed(r); err != nil {
+			return 0, txKey{}, nil, unexpectedEOF(err)
+		}
+	}
+	return op, key, txBz, nil
+}
+
+func readLengthPrefixed(r *bufio.Reader) ([]byte, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, err
+	}
+	bz := make([]byte, n)
+	if _, err := io.ReadFull(r, bz); err != nil {
+		return nil, err
+	}
+	return bz, nil
+}
+
+// unexpectedEOF reports the end of the file in the middle of a record as a
+// truncated record.
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// sortedTxs returns the transactions by signer and nonce.
+func sortedTxs(txs map[txKey][]byte) [][]byte {
+	keys := make([]txKey, 0, len(txs))
+	for key := range txs {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].address != keys[j].address {
+			return keys[i].address < keys[j].address
+		}
+		return keys[i].nonce < keys[j].nonce
+	})
+
+	sorted := make([][]byte, len(keys))
+	for i, key := range keys {
+		sorted[i] = txs[key]
+	}
+	return sorted
+}
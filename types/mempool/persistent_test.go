@@ -0,0 +1,148 @@
+package mempool_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+
+	"cosmossdk.io/core/log"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/mempool"
+	simtypes "github.com/cosmos/cosmos-sdk/types/simulation"
+)
+
+func (s *MempoolTestSuite) TestPersistentMempool() {
+	ctx := sdk.NewContext(nil, false, log.NewNopLogger())
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 2)
+	sa, sb := accounts[0].Address, accounts[1].Address
+	path := filepath.Join(s.T().TempDir(), "mempool.wal")
+
+	txs := []testTx{
+		{id: 0, priority: 1, nonce: 2, address: sa},
+		{id: 1, priority: 1, nonce: 1, address: sa},
+		{id: 2, priority: 1, nonce: 1, address: sb},
+		{id: 3, priority: 1, nonce: 2, address: sb},
+	}
+	txBytes := func(tx testTx) []byte { return []byte{byte(tx.id)} }
+
+	mp, err := mempool.NewPersistentMempool(mempool.DefaultPriorityMempool(), mempool.PersistentMempoolConfig{Path: path})
+	s.Require().NoError(err)
+	for _, tx := range txs {
+		s.Require().NoError(mp.Insert(ctx.WithTxBytes(txBytes(tx)), tx))
+	}
+	s.Require().NoError(mp.Remove(txs[2]))
+	s.Require().Equal(3, mp.CountTx())
+	s.Require().ErrorContains(mp.Insert(ctx, txs[2]), "requires the tx bytes")
+	s.Require().NoError(mp.Close())
+	s.Require().NoError(mp.Close())
+
+	// a tx which cannot be persisted is not kept in the mempool
+	s.Require().ErrorContains(mp.Insert(ctx.WithTxBytes(txBytes(txs[2])), txs[2]), "closed")
+	s.Require().Equal(3, mp.CountTx())
+
+	// a record truncated by a crash is ignored
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0o600)
+	s.Require().NoError(err)
+	_, err = f.Write([]byte{1, 20, 'x'})
+	s.Require().NoError(err)
+	s.Require().NoError(f.Close())
+
+	// on restart, the txs are re-inserted by signer and nonce, and the ones
+	// which are no longer valid are dropped
+	restore := func(invalid ...int) ([]int, int, int) {
+		mp, err := mempool.NewPersistentMempool(mempool.DefaultPriorityMempool(), mempool.PersistentMempoolConfig{Path: path})
+		s.Require().NoError(err)
+		defer mp.Close()
+
+		var ids []int
+		restored, dropped, err := mp.Restore(func(txBz []byte) error {
+			for _, tx := range txs {
+				if !bytes.Equal(txBz, txBytes(tx)) {
+					continue
+				}
+				for _, id := range invalid {
+					if tx.id == id {
+						return errors.New("invalid tx")
+					}
+				}
+				ids = append(ids, tx.id)
+				return mp.Insert(ctx.WithTxBytes(txBz), tx)
+			}
+			return errors.New("unknown tx")
+		})
+		s.Require().NoError(err)
+		s.Require().Equal(restored, mp.CountTx())
+		return ids, restored, dropped
+	}
+
+	ids, restored, dropped := restore(3)
+	s.Require().Equal(2, restored)
+	s.Require().Equal(1, dropped)
+	// the txs of a signer are restored by nonce
+	s.Require().Equal([]int{1, 0}, ids)
+
+	// the dropped txs are no longer persisted
+	ids, restored, dropped = restore()
+	s.Require().Equal(2, restored)
+	s.Require().Equal(0, dropped)
+	s.Require().Equal([]int{1, 0}, ids)
+}
+
+func (s *MempoolTestSuite) TestPersistentMempoolEviction() {
+	ctx := sdk.NewContext(nil, false, log.NewNopLogger()).WithBlockHeight(1)
+	accounts := simtypes.RandomAccounts(rand.New(rand.NewSource(0)), 2)
+	sa, sb := accounts[0].Address, accounts[1].Address
+	path := filepath.Join(s.T().TempDir(), "mempool.wal")
+
+	txs := []testTx{
+		{id: 0, priority: 5, nonce: 1, address: sa},
+		{id: 1, priority: 50, nonce: 2, address: sa},
+		{id: 2, priority: 10, nonce: 1, address: sb},
+		{id: 3, priority: 20, nonce: 1, address: sb},
+	}
+	txBytes := func(tx testTx) []byte { return []byte{byte(tx.id)} }
+
+	cfg := testFeeMarketConfig()
+	cfg.MaxTx = 2
+	cfg.TxTTL = 2
+	open := func() *mempool.PersistentMempool {
+		mp, err := mempool.NewPersistentMempool(mempool.NewFeeMarketMempool(cfg), mempool.PersistentMempoolConfig{Path: path})
+		s.Require().NoError(err)
+		return mp
+	}
+	// restart re-opens the mempool and restores the persisted txs
+	restart := func() (*mempool.PersistentMempool, []int) {
+		mp := open()
+		_, _, err := mp.Restore(func(txBz []byte) error {
+			return mp.Insert(ctx.WithTxBytes(txBz), txs[txBz[0]])
+		})
+		s.Require().NoError(err)
+		return mp, selectIDs(ctx, mp)
+	}
+
+	mp := open()
+	s.Require().NoError(mp.Insert(ctx.WithTxBytes(txBytes(txs[0])), txs[0]))
+	s.Require().NoError(mp.Insert(ctx.WithTxBytes(txBytes(txs[1])), txs[1]))
+	// the tx of fee per gas 5 is evicted along with the later tx of its sender
+	s.Require().NoError(mp.Insert(ctx.WithTxBytes(txBytes(txs[2])), txs[2]))
+	// and the tx of sb is replaced
+	s.Require().NoError(mp.Insert(ctx.WithTxBytes(txBytes(txs[3])), txs[3]))
+	s.Require().Equal([]int{3}, selectIDs(ctx, mp))
+	s.Require().ErrorContains(mp.Insert(context.Background(), txs[0]), "requires an sdk context")
+
+	// the evicted and replaced txs are not restored, even after a crash which
+	// does not close the mempool
+	mp, ids := restart()
+	s.Require().Equal([]int{3}, ids)
+
+	// the expired txs are not restored once the mempool is closed
+	s.Require().Empty(selectIDs(ctx.WithBlockHeight(3), mp))
+	s.Require().NoError(mp.Close())
+	mp, ids = restart()
+	s.Require().Empty(ids)
+	s.Require().NoError(mp.Close())
+}
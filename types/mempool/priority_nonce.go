@@ -12,8 +12,9 @@ import (
 )
 
 var (
-	_ Mempool  = (*PriorityNonceMempool[int64])(nil)
-	_ Iterator = (*PriorityNonceIterator[int64])(nil)
+	_ Mempool     = (*PriorityNonceMempool[int64])(nil)
+	_ Snapshotter = (*PriorityNonceMempool[int64])(nil)
+	_ Iterator    = (*PriorityNonceIterator[int64])(nil)
 )
 
 type (
@@ -341,6 +342,11 @@ func (i *PriorityNonceIterator[C]) Tx() sdk.Tx {
 	return i.senderCursors[i.sender].Value.(sdk.Tx)
 }
 
+// Priority returns the priority of the current transaction.
+func (i *PriorityNonceIterator[C]) Priority() C {
+	return i.senderCursors[i.sender].Key().(txMeta[C]).priority
+}
+
 // Select returns a set of transactions from the mempool, ordered by priority
 // and sender-nonce in O(n) time. The passed in list of transactions are ignored.
 // This is a readonly operation, the mempool is not modified.
@@ -353,6 +359,20 @@ func (i *PriorityNonceIterator[C]) Tx() sdk.Tx {
 func (mp *PriorityNonceMempool[C]) Select(_ context.Context, _ [][]byte) Iterator {
 	mp.mtx.Lock()
 	defer mp.mtx.Unlock()
+	return mp.doSelect()
+}
+
+// Snapshot implements Snapshotter, copying the transactions in the order of
+// Select.
+func (mp *PriorityNonceMempool[C]) Snapshot(_ context.Context) (Iterator, error) {
+	mp.mtx.Lock()
+	defer mp.mtx.Unlock()
+	return newSnapshotIterator(appendSnapshot(nil, mp.doSelect())), nil
+}
+
+// doSelect returns an iterator over the transactions of the mempool. The caller
+// must hold the lock.
+func (mp *PriorityNonceMempool[C]) doSelect() Iterator {
 	if mp.priorityIndex.Len() == 0 {
 		return nil
 	}
@@ -16,8 +16,9 @@ import (
 )
 
 var (
-	_ Mempool  = (*SenderNonceMempool)(nil)
-	_ Iterator = (*senderNonceMempoolIterator)(nil)
+	_ Mempool     = (*SenderNonceMempool)(nil)
+	_ Snapshotter = (*SenderNonceMempool)(nil)
+	_ Iterator    = (*senderNonceMempoolIterator)(nil)
 )
 
 var DefaultMaxTx = -1
@@ -189,6 +190,26 @@ func (snm *SenderNonceMempool) Select(_ context.Context, _ [][]byte) Iterator {
 	return iter.Next()
 }
 
+// Snapshot implements Snapshotter. As the selection order is random, the
+// transactions are copied by sender and then by nonce.
+func (snm *SenderNonceMempool) Snapshot(_ context.Context) (Iterator, error) {
+	snm.mtx.Lock()
+	defer snm.mtx.Unlock()
+
+	orderedSenders := skiplist.New(skiplist.String)
+	for s := range snm.senders {
+		orderedSenders.Set(s, s)
+	}
+
+	var txs []snapshotTx
+	for s := orderedSenders.Front(); s != nil; s = s.Next() {
+		for e := snm.senders[s.Value.(string)].Front(); e != nil; e = e.Next() {
+			txs = append(txs, snapshotTx{tx: e.Value.(sdk.Tx)})
+		}
+	}
+	return newSnapshotIterator(txs), nil
+}
+
 // CountTx returns the total count of txs in the mempool.
 func (snm *SenderNonceMempool) CountTx() int {
 	snm.mtx.Lock()
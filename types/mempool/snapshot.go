@@ -0,0 +1,57 @@
+package mempool
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var _ Iterator = (*snapshotIterator)(nil)
+
+// snapshotTx is a transaction of a snapshot along with its priority.
+type snapshotTx struct {
+	tx       sdk.Tx
+	priority int64
+}
+
+// snapshotIterator iterates over the transactions copied by a Snapshotter.
+type snapshotIterator struct {
+	txs []snapshotTx
+}
+
+// appendSnapshot copies the transactions of the iterator, and their priority if
+// the iterator exposes an int64 one. The caller must hold the lock of the
+// mempool the iterator is over.
+func appendSnapshot(txs []snapshotTx, it Iterator) []snapshotTx {
+	for ; it != nil; it = it.Next() {
+		stx := snapshotTx{tx: it.Tx()}
+		if pit, ok := it.(interface{ Priority() int64 }); ok {
+			stx.priority = pit.Priority()
+		}
+		txs = append(txs, stx)
+	}
+	return txs
+}
+
+// newSnapshotIterator returns an iterator over the copied transactions, nil if
+// there are none.
+func newSnapshotIterator(txs []snapshotTx) Iterator {
+	if len(txs) == 0 {
+		return nil
+	}
+	return &snapshotIterator{txs: txs}
+}
+
+func (i *snapshotIterator) Next() Iterator {
+	if i.txs = i.txs[1:]; len(i.txs) == 0 {
+		return nil
+	}
+	return i
+}
+
+func (i *snapshotIterator) Tx() sdk.Tx {
+	return i.txs[0].tx
+}
+
+// Priority returns the priority of the current transaction.
+func (i *snapshotIterator) Priority() int64 {
+	return i.txs[0].priority
+}
@@ -19,6 +19,7 @@ require (
 	buf.build/gen/go/cometbft/cometbft/protocolbuffers/go v1.34.2-20240701160653-fedbb9acfd2f.2 // indirect
 	cosmossdk.io/core/testing v0.0.0-00010101000000-000000000000 // indirect
 	cosmossdk.io/depinject v1.0.0-alpha.4 // indirect
+	cosmossdk.io/schema v0.0.0-00010101000000-000000000000 // indirect
 	github.com/cometbft/cometbft/api v1.0.0-rc.1 // indirect
 	github.com/cosmos/crypto v0.1.1 // indirect
 	github.com/dgraph-io/badger/v4 v4.2.0 // indirect
@@ -177,6 +178,8 @@ replace (
 	cosmossdk.io/core/testing => ../../../../core/testing
 	cosmossdk.io/depinject => ../../../../depinject
 	cosmossdk.io/log => ../../../../log
+	cosmossdk.io/schema => ../../../../schema
+	cosmossdk.io/store => ../../../../store
 	cosmossdk.io/x/accounts => ../../.
 	cosmossdk.io/x/auth => ../../../auth
 	cosmossdk.io/x/bank => ../../../bank
@@ -89,3 +89,43 @@ func TestBankStateCompatibility(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, []byte{}, newRawValue)
 }
+
+func TestBankModuleCodec(t *testing.T) {
+	key := storetypes.NewKVStoreKey(banktypes.StoreKey)
+	encCfg := moduletestutil.MakeTestEncodingConfig(codectestutil.CodecOptions{})
+	env := runtime.NewEnvironment(runtime.NewKVStoreService(key), log.NewNopLogger())
+
+	ctrl := gomock.NewController(t)
+	authKeeper := banktestutil.NewMockAccountKeeper(ctrl)
+	authKeeper.EXPECT().AddressCodec().Return(address.NewBech32Codec("cosmos")).AnyTimes()
+
+	ac := codectestutil.CodecOptions{}.GetAddressCodec()
+	authority, err := ac.BytesToString(authtypes.NewModuleAddress(banktypes.GovModuleName))
+	require.NoError(t, err)
+
+	k := keeper.NewBaseKeeper(env, encCfg.Codec, authKeeper, map[string]bool{}, authority)
+
+	moduleCodec, err := k.Schema.ModuleCodec(collections.IndexingOptions{})
+	require.NoError(t, err)
+	require.NoError(t, moduleCodec.Schema.Validate())
+
+	rawKey, err := collections.EncodeKeyWithPrefix(banktypes.BalancesPrefix, k.Balances.KeyCodec(), collections.Join(sdk.AccAddress("test"), "atom"))
+	require.NoError(t, err)
+	rawValue, err := k.Balances.ValueCodec().Encode(math.NewInt(100))
+	require.NoError(t, err)
+
+	update, ok, err := moduleCodec.KVDecoder(rawKey, rawValue)
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.Equal(t, "balances", update.TypeName)
+	require.Equal(t, []any{[]byte("test"), "atom"}, update.Key)
+	require.Equal(t, "100", update.Value)
+	require.NoError(t, moduleCodec.Schema.ValidateObjectUpdate(update))
+
+	// the denom to address index is a secondary index and is not decoded
+	rawIndexKey, err := collections.EncodeKeyWithPrefix(banktypes.DenomAddressPrefix, k.Balances.Indexes.Denom.KeyCodec(), collections.Join("atom", sdk.AccAddress("test")))
+	require.NoError(t, err)
+	_, ok, err = moduleCodec.KVDecoder(rawIndexKey, []byte{})
+	require.NoError(t, err)
+	require.False(t, ok)
+}
@@ -9,9 +9,11 @@ import (
 	"github.com/spf13/cobra"
 	"google.golang.org/grpc"
 
+	"cosmossdk.io/collections"
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/core/legacy"
 	"cosmossdk.io/core/registry"
+	"cosmossdk.io/schema"
 	"cosmossdk.io/x/bank/client/cli"
 	"cosmossdk.io/x/bank/keeper"
 	"cosmossdk.io/x/bank/simulation"
@@ -39,6 +41,8 @@ var (
 	_ appmodule.HasMigrations         = AppModule{}
 	_ appmodule.HasGenesis            = AppModule{}
 	_ appmodule.HasRegisterInterfaces = AppModule{}
+
+	_ schema.HasModuleCodec = AppModule{}
 )
 
 // AppModule implements an application module for the bank module.
@@ -178,3 +182,13 @@ func (am AppModule) WeightedOperations(simState module.SimulationState) []simtyp
 		simState.AppParams, simState.Cdc, simState.TxConfig, am.accountKeeper, am.keeper,
 	)
 }
+
+// ModuleCodec implements schema.HasModuleCodec.
+// It allows the indexer to decode the module's KV-pair updates.
+func (am AppModule) ModuleCodec() (schema.ModuleCodec, error) {
+	k, ok := am.keeper.(keeper.BaseKeeper)
+	if !ok {
+		return schema.ModuleCodec{}, fmt.Errorf("unable to decode state of bank keeper %T", am.keeper)
+	}
+	return k.Schema.ModuleCodec(collections.IndexingOptions{})
+}
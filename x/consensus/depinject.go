@@ -4,6 +4,7 @@ import (
 	modulev1 "cosmossdk.io/api/cosmos/consensus/module/v1"
 	"cosmossdk.io/core/address"
 	"cosmossdk.io/core/appmodule"
+	"cosmossdk.io/core/gas"
 	"cosmossdk.io/depinject"
 	"cosmossdk.io/depinject/appconfig"
 	"cosmossdk.io/x/consensus/keeper"
@@ -38,9 +39,10 @@ type ModuleInputs struct {
 type ModuleOutputs struct {
 	depinject.Out
 
-	Keeper        keeper.Keeper
-	Module        appmodule.AppModule
-	BaseAppOption runtime.BaseAppOption
+	Keeper              keeper.Keeper
+	Module              appmodule.AppModule
+	BaseAppOption       runtime.BaseAppOption
+	GasScheduleProvider gas.ScheduleProvider
 }
 
 func ProvideModule(in ModuleInputs) ModuleOutputs {
@@ -67,8 +69,9 @@ func ProvideModule(in ModuleInputs) ModuleOutputs {
 	}
 
 	return ModuleOutputs{
-		Keeper:        k,
-		Module:        m,
-		BaseAppOption: baseappOpt,
+		Keeper:              k,
+		Module:              m,
+		BaseAppOption:       baseappOpt,
+		GasScheduleProvider: k.GasSchedule,
 	}
 }
@@ -0,0 +1,121 @@
+package consensus_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+	"time"
+
+	cmttypes "github.com/cometbft/cometbft/types"
+	gogoproto "github.com/cosmos/gogoproto/proto"
+	"github.com/stretchr/testify/require"
+
+	modulev1 "cosmossdk.io/api/cosmos/consensus/module/v1"
+	coreapp "cosmossdk.io/core/app"
+	"cosmossdk.io/core/appmodule"
+	appmodulev2 "cosmossdk.io/core/appmodule/v2"
+	"cosmossdk.io/core/gas"
+	"cosmossdk.io/core/log"
+	"cosmossdk.io/core/store"
+	"cosmossdk.io/server/v2/stf"
+	"cosmossdk.io/server/v2/stf/branch"
+	stfgas "cosmossdk.io/server/v2/stf/gas"
+	"cosmossdk.io/server/v2/stf/mock"
+	"cosmossdk.io/x/consensus"
+	"cosmossdk.io/x/consensus/types"
+
+	codectestutil "github.com/cosmos/cosmos-sdk/codec/testutil"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/address"
+)
+
+// TestGasSchedule checks that the txs of a block are metered with the gas
+// schedule kept by x/consensus in the state the block is delivered on.
+func TestGasSchedule(t *testing.T) {
+	cdcOpts := codectestutil.CodecOptions{}
+	env := appmodule.Environment{
+		Logger:         log.NewNopLogger(),
+		BranchService:  stf.BranchService{},
+		EventService:   stf.NewEventService(),
+		GasService:     stf.NewGasMeterService(),
+		HeaderService:  stf.HeaderService{},
+		KVStoreService: stf.NewKVStoreService([]byte(types.StoreKey)),
+	}
+	out := consensus.ProvideModule(consensus.ModuleInputs{
+		Config:       &modulev1.Module{},
+		Cdc:          cdcOpts.NewCodec(),
+		Environment:  env,
+		AddressCodec: cdcOpts.GetAddressCodec(),
+	})
+
+	msg := &types.MsgUpdateParams{Authority: out.Keeper.GetAuthority()}
+	params := cmttypes.DefaultConsensusParams().ToProto()
+	msg.Block, msg.Evidence, msg.Validator = params.Block, params.Evidence, params.Validator
+	msgRouterBuilder := stf.NewMsgRouterBuilder()
+	require.NoError(t, msgRouterBuilder.RegisterHandler(gogoproto.MessageName(msg), func(ctx context.Context, msg appmodulev2.Message) (appmodulev2.Message, error) {
+		return out.Keeper.UpdateParams(ctx, msg.(*types.MsgUpdateParams))
+	}))
+
+	// the first block stores the params and the gas schedule, if any, at its end
+	var schedule *gas.Schedule
+	s, err := stf.NewSTF[mock.Tx](
+		log.NewNopLogger(),
+		msgRouterBuilder,
+		stf.NewMsgRouterBuilder(),
+		func(ctx context.Context, txs []mock.Tx) error { return nil },
+		func(ctx context.Context) error { return nil },
+		func(ctx context.Context) error {
+			if env.HeaderService.HeaderInfo(ctx).Height > 1 {
+				return nil
+			}
+			if schedule != nil {
+				if err := out.Keeper.GasScheduleStore.Set(ctx, *schedule); err != nil {
+					return err
+				}
+			}
+			return out.Keeper.ParamsStore.Set(ctx, params)
+		},
+		func(ctx context.Context, tx mock.Tx) error { return nil },
+		func(ctx context.Context) ([]appmodulev2.ValidatorUpdate, error) { return nil, nil },
+		func(ctx context.Context, tx mock.Tx, success bool) error { return nil },
+		branch.DefaultNewWriterMap,
+	)
+	require.NoError(t, err)
+	s.SetGasScheduleProvider(out.GasScheduleProvider)
+
+	hash := sha256.Sum256([]byte("test-hash"))
+	deliver := func(state store.ReaderMap, height uint64, txs ...mock.Tx) (*coreapp.BlockResponse, store.WriterMap) {
+		t.Helper()
+		resp, newState, err := s.DeliverBlock(context.Background(), &coreapp.BlockRequest[mock.Tx]{
+			Height:  height,
+			Time:    time.Date(2024, 2, 3, 18, 23, 0, 0, time.UTC),
+			AppHash: hash[:],
+			Hash:    hash[:],
+			Txs:     txs,
+		}, state)
+		require.NoError(t, err)
+		return resp, newState
+	}
+	tx := mock.Tx{Sender: address.Module("gov"), Msg: msg, GasLimit: 1_000_000}
+
+	// the default gas costs apply without a schedule
+	_, state := deliver(mock.DB(), 1)
+	expected, _ := deliver(state, 2, tx)
+	require.NoError(t, expected.TxResults[0].Error)
+	require.Nil(t, expected.GasSchedule)
+
+	// the message updating the params writes them once
+	config := stfgas.DefaultConfig
+	config.WriteCostFlat += 1000
+	schedule = &gas.Schedule{
+		Default: stfgas.DefaultConfig.GasConfig(),
+		Messages: map[string]gas.MessageSchedule{
+			sdk.MsgTypeURL(msg): {Stores: map[string]gas.GasConfig{types.StoreKey: config.GasConfig()}},
+		},
+	}
+	_, state = deliver(mock.DB(), 1)
+	resp, _ := deliver(state, 2, tx)
+	require.NoError(t, resp.TxResults[0].Error)
+	require.Equal(t, expected.TxResults[0].GasUsed+1000, resp.TxResults[0].GasUsed)
+	require.Equal(t, schedule, resp.GasSchedule)
+}
@@ -15,6 +15,7 @@ import (
 	coreapp "cosmossdk.io/core/app"
 	"cosmossdk.io/core/appmodule"
 	"cosmossdk.io/core/event"
+	"cosmossdk.io/core/gas"
 	"cosmossdk.io/x/consensus/exported"
 	"cosmossdk.io/x/consensus/types"
 
@@ -28,19 +29,26 @@ type Keeper struct {
 
 	authority   string
 	ParamsStore collections.Item[cmtproto.ConsensusParams]
+	// GasScheduleStore is the gas schedule the stores are metered with, next to
+	// the consensus params. The default gas costs apply when it is not set.
+	GasScheduleStore collections.Item[gas.Schedule]
 	// storage of the last comet info
 	cometInfo collections.Item[types.CometInfo]
 }
 
-var _ exported.ConsensusParamSetter = Keeper{}.ParamsStore
+var (
+	_ exported.ConsensusParamSetter = Keeper{}.ParamsStore
+	_ gas.ScheduleProvider          = Keeper{}.GasSchedule
+)
 
 func NewKeeper(cdc codec.BinaryCodec, env appmodule.Environment, authority string) Keeper {
 	sb := collections.NewSchemaBuilder(env.KVStoreService)
 	return Keeper{
-		Environment: env,
-		authority:   authority,
-		ParamsStore: collections.NewItem(sb, collections.NewPrefix("Consensus"), "params", codec.CollValue[cmtproto.ConsensusParams](cdc)),
-		cometInfo:   collections.NewItem(sb, collections.NewPrefix("CometInfo"), "comet_info", codec.CollValue[types.CometInfo](cdc)),
+		Environment:      env,
+		authority:        authority,
+		ParamsStore:      collections.NewItem(sb, collections.NewPrefix("Consensus"), "params", codec.CollValue[cmtproto.ConsensusParams](cdc)),
+		GasScheduleStore: collections.NewItem(sb, collections.NewPrefix("GasSchedule"), "gas_schedule", types.GasScheduleValueCodec),
+		cometInfo:        collections.NewItem(sb, collections.NewPrefix("CometInfo"), "comet_info", codec.CollValue[types.CometInfo](cdc)),
 	}
 }
 
@@ -48,6 +56,19 @@ func (k *Keeper) GetAuthority() string {
 	return k.authority
 }
 
+// GasSchedule returns the gas schedule the stores are metered with, or nil if
+// it is not set.
+func (k Keeper) GasSchedule(ctx context.Context) (*gas.Schedule, error) {
+	schedule, err := k.GasScheduleStore.Get(ctx)
+	if errors.Is(err, collections.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &schedule, nil
+}
+
 // Querier
 
 var _ types.QueryServer = Keeper{}
@@ -0,0 +1,44 @@
+package types
+
+import (
+	"encoding/json"
+
+	collcodec "cosmossdk.io/collections/codec"
+	"cosmossdk.io/core/gas"
+)
+
+// GasScheduleValueCodec is the value codec of the gas schedule, which is stored
+// as JSON.
+var GasScheduleValueCodec collcodec.ValueCodec[gas.Schedule] = gasScheduleValueCodec{}
+
+type gasScheduleValueCodec struct{}
+
+func (gasScheduleValueCodec) Encode(value gas.Schedule) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (gasScheduleValueCodec) Decode(b []byte) (gas.Schedule, error) {
+	var value gas.Schedule
+	err := json.Unmarshal(b, &value)
+	return value, err
+}
+
+func (c gasScheduleValueCodec) EncodeJSON(value gas.Schedule) ([]byte, error) {
+	return c.Encode(value)
+}
+
+func (c gasScheduleValueCodec) DecodeJSON(b []byte) (gas.Schedule, error) {
+	return c.Decode(b)
+}
+
+func (c gasScheduleValueCodec) Stringify(value gas.Schedule) string {
+	b, err := c.Encode(value)
+	if err != nil {
+		return err.Error()
+	}
+	return string(b)
+}
+
+func (gasScheduleValueCodec) ValueType() string {
+	return "cosmossdk.io/core/gas/Schedule"
+}
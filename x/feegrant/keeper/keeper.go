@@ -39,7 +39,7 @@ var _ ante.FeegrantKeeper = &Keeper{}
 func NewKeeper(env appmodule.Environment, cdc codec.BinaryCodec, ak feegrant.AccountKeeper) Keeper {
 	sb := collections.NewSchemaBuilder(env.KVStoreService)
 
-	return Keeper{
+	k := Keeper{
 		Environment: env,
 		cdc:         cdc,
 		authKeeper:  ak,
@@ -58,6 +58,14 @@ func NewKeeper(env appmodule.Environment, cdc codec.BinaryCodec, ak feegrant.Acc
 			collections.BoolValue,
 		),
 	}
+
+	schema, err := sb.Build()
+	if err != nil {
+		panic(err)
+	}
+	k.Schema = schema
+
+	return k
 }
 
 // Logger returns a module-specific logger.